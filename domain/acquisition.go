@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Vendor struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Name      string    `gorm:"size:255;not null;index" json:"name"`
+	Contact   string    `gorm:"size:255" json:"contact"`
+	Email     string    `gorm:"size:255" json:"email"`
+	Phone     string    `gorm:"size:50" json:"phone"`
+	Notes     string    `gorm:"type:text" json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Fund is a budget line purchases are charged to, e.g. ADULT-FIC for one
+// fiscal year.
+type Fund struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Code       string    `gorm:"size:50;not null;uniqueIndex:idx_fund_code_year" json:"code"`
+	FiscalYear int       `gorm:"not null;uniqueIndex:idx_fund_code_year" json:"fiscal_year"`
+	Name       string    `gorm:"size:255;not null" json:"name"`
+	Budget     float64   `gorm:"not null" json:"budget"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type PurchaseOrder struct {
+	ID        uuid.UUID           `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Number    string              `gorm:"size:50;not null;uniqueIndex" json:"number"`
+	VendorID  uuid.UUID           `gorm:"type:uuid;not null;index" json:"vendor_id"`
+	Vendor    *Vendor             `gorm:"foreignKey:VendorID" json:"vendor,omitempty"`
+	Status    string              `gorm:"size:50;not null;index" json:"status"` // Draft, Ordered, Partial, Received, Cancelled
+	Note      string              `gorm:"type:text" json:"note"`
+	CreatedBy *uuid.UUID          `gorm:"type:uuid" json:"created_by"`
+	OrderedAt *time.Time          `json:"ordered_at"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	Lines     []PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderID" json:"lines,omitempty"`
+}
+
+// PurchaseOrderLine orders Quantity copies of a book at UnitPrice, charged
+// to a fund. Received copies are shelved at the line's home location.
+type PurchaseOrderLine struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	PurchaseOrderID uuid.UUID  `gorm:"type:uuid;not null;index" json:"purchase_order_id"`
+	BookID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"book_id"`
+	Book            *Book      `gorm:"foreignKey:BookID" json:"book,omitempty"`
+	FundID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"fund_id"`
+	Fund            *Fund      `gorm:"foreignKey:FundID" json:"fund,omitempty"`
+	Quantity        int        `gorm:"not null" json:"quantity"`
+	Received        int        `gorm:"not null;default:0" json:"received"`
+	UnitPrice       float64    `gorm:"not null" json:"unit_price"`
+	HomeBranchID    *uuid.UUID `gorm:"type:uuid" json:"home_branch_id"`
+	HomeShelfID     *uuid.UUID `gorm:"type:uuid" json:"home_shelf_id"`
+}
+
+type AcquisitionRepository interface {
+	FindVendors(ctx context.Context, search string) ([]Vendor, error)
+	FindVendorByID(ctx context.Context, id uuid.UUID) (*Vendor, error)
+	SaveVendor(ctx context.Context, vendor *Vendor) error
+	DeleteVendor(ctx context.Context, id uuid.UUID) error
+	CountVendorOrders(ctx context.Context, id uuid.UUID) (int64, error)
+
+	FindFunds(ctx context.Context, fiscalYear int) ([]Fund, error)
+	FindFundByID(ctx context.Context, id uuid.UUID) (*Fund, error)
+	FindFundByCode(ctx context.Context, code string, fiscalYear int) (*Fund, error)
+	SaveFund(ctx context.Context, fund *Fund) error
+	DeleteFund(ctx context.Context, id uuid.UUID) error
+	CountFundLines(ctx context.Context, id uuid.UUID) (int64, error)
+	// FundSpending sums committed and spent amounts per fund for the
+	// fiscal year; zero means every year.
+	FundSpending(ctx context.Context, fiscalYear int) ([]dto.FundReportRow, error)
+
+	FindOrders(ctx context.Context, filter dto.PurchaseOrderFilter) ([]PurchaseOrder, error)
+	FindOrderByID(ctx context.Context, id uuid.UUID) (*PurchaseOrder, error)
+	FindOrderByNumber(ctx context.Context, number string) (*PurchaseOrder, error)
+	CreateOrder(ctx context.Context, order *PurchaseOrder) error
+	// ReplaceOrder saves the order header and swaps its lines for
+	// order.Lines.
+	ReplaceOrder(ctx context.Context, order *PurchaseOrder) error
+	UpdateOrderStatus(ctx context.Context, order *PurchaseOrder) error
+	DeleteOrder(ctx context.Context, id uuid.UUID) error
+	// ReceiveCopies inserts the copies, bumps the received count of each
+	// line and saves the order status in one transaction.
+	ReceiveCopies(ctx context.Context, order *PurchaseOrder, received map[uuid.UUID]int, copies []BookStock) error
+}
+
+type AcquisitionService interface {
+	GetVendors(ctx context.Context, search string) ([]dto.VendorResponse, error)
+	GetVendorByID(ctx context.Context, id uuid.UUID) (*dto.VendorResponse, error)
+	CreateVendor(ctx context.Context, req dto.VendorRequest) (*dto.VendorResponse, error)
+	UpdateVendor(ctx context.Context, id uuid.UUID, req dto.VendorRequest) (*dto.VendorResponse, error)
+	DeleteVendor(ctx context.Context, id uuid.UUID) error
+
+	GetFunds(ctx context.Context, fiscalYear int) ([]dto.FundResponse, error)
+	GetFundByID(ctx context.Context, id uuid.UUID) (*dto.FundResponse, error)
+	CreateFund(ctx context.Context, req dto.FundRequest) (*dto.FundResponse, error)
+	UpdateFund(ctx context.Context, id uuid.UUID, req dto.FundRequest) (*dto.FundResponse, error)
+	DeleteFund(ctx context.Context, id uuid.UUID) error
+	GetFundReport(ctx context.Context, fiscalYear int) (*dto.FundReport, error)
+
+	GetPurchaseOrders(ctx context.Context, filter dto.PurchaseOrderFilter) ([]dto.PurchaseOrderResponse, error)
+	GetPurchaseOrderByID(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error)
+	CreatePurchaseOrder(ctx context.Context, createdBy uuid.UUID, req dto.PurchaseOrderRequest) (*dto.PurchaseOrderResponse, error)
+	UpdatePurchaseOrder(ctx context.Context, id uuid.UUID, req dto.PurchaseOrderRequest) (*dto.PurchaseOrderResponse, error)
+	DeletePurchaseOrder(ctx context.Context, id uuid.UUID) error
+	PlacePurchaseOrder(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error)
+	CancelPurchaseOrder(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error)
+	ReceivePurchaseOrder(ctx context.Context, id uuid.UUID, req dto.PurchaseOrderReceiveRequest) (*dto.PurchaseOrderReceipt, error)
+}
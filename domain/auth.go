@@ -9,4 +9,6 @@ type AuthService interface {
 	Authenticate(ctx context.Context, req dto.AuthReq) (dto.AuthRes, error)
 	Validate(ctx context.Context, tokenString string) (dto.UserData, error)
 	Register(ctx context.Context, req dto.RegisterReq) (dto.UserData, error)
+	RegisterPatron(ctx context.Context, req dto.PatronRegisterReq) (dto.UserData, error)
+	HasUsers(ctx context.Context) (bool, error)
 }
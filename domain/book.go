@@ -12,7 +12,25 @@ import (
 type Book struct {
 	ID               uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
 	Title            string            `gorm:"size:255;not null" json:"title"`
+	ISBN             string            `gorm:"size:20;index" json:"isbn"`
 	Description      string            `gorm:"type:text" json:"description"`
+	Author           string            `gorm:"size:255;index" json:"author"`
+	Publisher        string            `gorm:"size:255;index" json:"publisher"`
+	PublicationYear  int               `gorm:"index" json:"publication_year"`
+	Language         string            `gorm:"size:50;index" json:"language"`
+	Category         string            `gorm:"size:100;index" json:"category"`
+	Subjects         string            `gorm:"type:text" json:"subjects"` // Separated by "; "
+	WorkID           *uuid.UUID        `gorm:"index" json:"work_id"`
+	Work             *Work             `gorm:"foreignKey:WorkID" json:"work,omitempty"`
+	Edition          string            `gorm:"size:100" json:"edition"`
+	Format           string            `gorm:"size:50" json:"format"`                                 // Hardcover, Paperback, Ebook, Audiobook, Other
+	ItemType         string            `gorm:"size:20;not null;default:circulating" json:"item_type"` // Circulating, Reference, InLibrary, Restricted
+	MinAge           int               `gorm:"not null;default:0" json:"min_age"`                     // Restricted items only; 0 uses the configured default
+	SeriesID         *uuid.UUID        `gorm:"index" json:"series_id"`
+	Series           *Series           `gorm:"foreignKey:SeriesID" json:"series,omitempty"`
+	SeriesVolume     *int              `json:"series_volume"`
+	RatingAverage    float64           `gorm:"not null;default:0;index" json:"rating_average"` // Approved reviews only
+	RatingCount      int64             `gorm:"not null;default:0" json:"rating_count"`
 	CoverID          *uuid.UUID        `json:"cover_id"`
 	Cover            *Media            `gorm:"foreignKey:CoverID" json:"cover,omitempty"`
 	CreatedAt        time.Time         `json:"created_at"`
@@ -23,18 +41,37 @@ type Book struct {
 }
 
 type BookRepository interface {
-	FindBooks(ctx context.Context, page, perPage int, search string, cover_id *uuid.UUID) ([]Book, int64, error)
+	FindBooks(ctx context.Context, page, perPage int, filter dto.BookFilter) ([]Book, int64, error)
+	FindBookFacets(ctx context.Context, filter dto.BookFilter) (*dto.BookFacets, error)
+	CountBooksBy(ctx context.Context, column string) ([]dto.FacetCount, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*Book, error)
+	FindAvailability(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]dto.BookAvailability, error)
+	// FindBranchAvailability counts the copies of a book per current branch.
+	FindBranchAvailability(ctx context.Context, id uuid.UUID) ([]dto.BranchAvailability, error)
+	// FindByTitleAuthor ignores case; pairs must already be lower case.
+	FindByTitleAuthor(ctx context.Context, pairs [][]interface{}) ([]Book, error)
+	FindByISBN(ctx context.Context, isbn string) (*Book, error)
+	// HasCover reports whether a book that is not trashed uses the media at
+	// coverPath as its cover.
+	HasCover(ctx context.Context, coverPath string) (bool, error)
+	FindInBatches(ctx context.Context, batchSize int, fn func(books []Book) error) error
 	Create(ctx context.Context, book *Book) error
 	Update(ctx context.Context, book *Book) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	FindDeleted(ctx context.Context) ([]Book, error)
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*Book, error)
+	Purge(ctx context.Context, id uuid.UUID) error
+	CountDependents(ctx context.Context, id uuid.UUID) (int64, error)
 }
 
 type BookService interface {
-	GetBooks(ctx context.Context, page, perPage int, search string, cover_id *uuid.UUID) (*dto.PaginatedResponseData[[]dto.BookResponse], error)
+	GetBooks(ctx context.Context, page, perPage int, filter dto.BookFilter) (*dto.PaginatedResponseData[[]dto.BookResponse], error)
 	GetBookByID(ctx context.Context, id uuid.UUID) (*dto.BookResponse, error)
 	CreateBook(ctx context.Context, req dto.BookCreateRequest) (*dto.BookResponse, error)
 	UpdateBook(ctx context.Context, id uuid.UUID, req dto.BookUpdateRequest) (*dto.BookResponse, error)
 	DeleteBook(ctx context.Context, id uuid.UUID) error
 	DeleteBookCover(ctx context.Context, id uuid.UUID) error
+	GetTrashedBooks(ctx context.Context) ([]dto.BookResponse, error)
+	RestoreBook(ctx context.Context, id uuid.UUID) (*dto.BookResponse, error)
+	PurgeBook(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"io"
+)
+
+type BookCSVService interface {
+	ImportBooks(ctx context.Context, r io.Reader, opts dto.BookImportOptions) (*dto.BookImportReport, error)
+	ExportBooks(ctx context.Context, w io.Writer) error
+}
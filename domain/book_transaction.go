@@ -17,10 +17,17 @@ type BookTransaction struct {
 	CustomerID uuid.UUID  `gorm:"not null" json:"customer_id"`
 	Customer   Customer   `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
 	DueDate    time.Time  `json:"due_date"`
-	Status     string     `gorm:"size:50;not null" json:"status"` // Borrowed, Returned, Overdue
+	Status     string     `gorm:"size:50;not null" json:"status"` // Borrowed, Returned, Overdue, Lost
 	BorrowedAt *time.Time `json:"borrowed_at"`
 	ReturnAt   *time.Time `json:"return_at"`
-	Charges    []Charge   `gorm:"foreignKey:BookTransactionID" json:"charges,omitempty"`
+	Renewals   int        `gorm:"not null;default:0" json:"renewals"`
+	// Condition of the copy when it went out and came back, so damage can
+	// be traced to the loan it happened on.
+	CheckoutCondition string   `gorm:"size:20" json:"checkout_condition"`
+	CheckoutNotes     string   `gorm:"type:text" json:"checkout_notes"`
+	ReturnCondition   string   `gorm:"size:20" json:"return_condition"`
+	ReturnNotes       string   `gorm:"type:text" json:"return_notes"`
+	Charges           []Charge `gorm:"foreignKey:BookTransactionID" json:"charges,omitempty"`
 }
 
 type BookTransactionRepository interface {
@@ -31,12 +38,16 @@ type BookTransactionRepository interface {
 	UpdateBorrowStatus(id uuid.UUID, status string, borrowedAt *time.Time) error
 	UpdateReturnStatus(id uuid.UUID, status string, returnAt *time.Time) error
 	Delete(id uuid.UUID) error
+	// CountActiveByCustomer counts the customer's loans still out.
+	CountActiveByCustomer(ctx context.Context, customerID uuid.UUID) (int64, error)
 }
 
 type BookTransactionService interface {
 	GetAllBookTransactions(filter map[string]interface{}) ([]dto.BookTransactionResponse, error)
 	CreateBookTransaction(ctx context.Context, req dto.BookTransactionCreateRequest) (*dto.BookTransactionResponse, error)
 	UpdateBookTransaction(ctx context.Context, id uuid.UUID, req dto.BookTransactionUpdateRequest) (*dto.BookTransactionResponse, error)
-	ReturnBookTransaction(ctx context.Context, req dto.BookTransactionUpdateStatusRequest) (*dto.BookTransactionResponse, error)
+	ReturnBookTransaction(ctx context.Context, req dto.BookTransactionUpdateStatusRequest, userID uuid.UUID) (*dto.BookTransactionResponse, error)
+	RenewBookTransaction(ctx context.Context, id uuid.UUID) (*dto.BookTransactionResponse, error)
+	DeclareLost(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.BookTransactionResponse, error)
 	DeleteBookTransaction(ctx context.Context, id uuid.UUID) error
 }
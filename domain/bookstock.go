@@ -1,38 +1,90 @@
 package domain
 
 import (
+	"context"
 	"go-rest-api/dto"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type BookStock struct {
-	Code             string            `gorm:"primaryKey;size:50" json:"code"`
-	BookID           uuid.UUID         `gorm:"not null" json:"book_id"`
-	Book             Book              `gorm:"foreignKey:BookID" json:"book,omitempty"`
-	Status           string            `gorm:"size:50;not null" json:"status"` // Available, Borrowed, Damaged, Lost
-	BorrowedID       *uuid.UUID        `json:"borrowed_id"`
-	BorrowedAt       *time.Time        `json:"borrowed_at"`
-	BookTransactions []BookTransaction `gorm:"foreignKey:StockCode;references:Code" json:"book_transactions,omitempty"`
+	Code            string         `gorm:"primaryKey;size:50" json:"code"`
+	BookID          uuid.UUID      `gorm:"not null" json:"book_id"`
+	Book            Book           `gorm:"foreignKey:BookID" json:"book,omitempty"`
+	Status          string         `gorm:"size:50;not null" json:"status"` // Available, Borrowed, Damaged, Lost, In transit, Withdrawn
+	BorrowedID      *uuid.UUID     `json:"borrowed_id"`
+	BorrowedAt      *time.Time     `json:"borrowed_at"`
+	ItemType        string         `gorm:"size:20" json:"item_type"`                             // Empty follows the book
+	Condition       string         `gorm:"size:20;not null;default:good;index" json:"condition"` // New, Good, Worn, Poor
+	ConditionNotes  string         `gorm:"type:text" json:"condition_notes"`
+	HomeBranchID    *uuid.UUID     `gorm:"type:uuid;index" json:"home_branch_id"`
+	HomeBranch      *Branch        `gorm:"foreignKey:HomeBranchID" json:"home_branch,omitempty"`
+	HomeShelfID     *uuid.UUID     `gorm:"type:uuid;index" json:"home_shelf_id"`
+	HomeShelf       *ShelfLocation `gorm:"foreignKey:HomeShelfID" json:"home_shelf,omitempty"`
+	CurrentBranchID *uuid.UUID     `gorm:"type:uuid;index" json:"current_branch_id"`
+	CurrentBranch   *Branch        `gorm:"foreignKey:CurrentBranchID" json:"current_branch,omitempty"`
+	CurrentShelfID  *uuid.UUID     `gorm:"type:uuid;index" json:"current_shelf_id"`
+	CurrentShelf    *ShelfLocation `gorm:"foreignKey:CurrentShelfID" json:"current_shelf,omitempty"`
+	// UnitPrice is what was paid for the copy; ReplacementCost is charged
+	// when it is lost and defaults to the unit price on receipt.
+	UnitPrice           *float64          `json:"unit_price"`
+	ReplacementCost     *float64          `json:"replacement_cost"`
+	PurchaseOrderLineID *uuid.UUID        `gorm:"type:uuid;index" json:"purchase_order_line_id"`
+	WithdrawnAt         *time.Time        `json:"withdrawn_at"`
+	WithdrawnReason     string            `gorm:"size:500" json:"withdrawn_reason"`
+	CreatedAt           time.Time         `json:"created_at"`
+	BookTransactions    []BookTransaction `gorm:"foreignKey:StockCode;references:Code" json:"book_transactions,omitempty"`
 }
 
+// BookStockStatusLog records a status change made outside the loan flow,
+// together with the reason given for it.
+type BookStockStatusLog struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	StockCode  string     `gorm:"size:50;not null;index" json:"stock_code"`
+	FromStatus string     `gorm:"size:50;not null" json:"from_status"`
+	ToStatus   string     `gorm:"size:50;not null" json:"to_status"`
+	Reason     string     `gorm:"size:500;not null" json:"reason"`
+	ChangedBy  *uuid.UUID `gorm:"type:uuid" json:"changed_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// StatusChangeHook runs inside the transaction that applied log, so work
+// that must follow a status change commits or rolls back with it.
+type StatusChangeHook func(tx *gorm.DB, log BookStockStatusLog) error
+
 type BookstockRepository interface {
-	FindAll() ([]BookStock, error)
+	FindAll(filter dto.BookstockFilter) ([]BookStock, error)
 	FindByCode(code string) (*BookStock, error)
+	FindByCodes(codes []string) ([]BookStock, error)
 	FindByBookID(bookID uuid.UUID) ([]BookStock, error)
-	FindAvailableByBookID(bookID uuid.UUID) ([]BookStock, error)
+	FindAvailableByBookID(bookID uuid.UUID, filter dto.BookstockFilter) ([]BookStock, error)
 	Create(bookstock *BookStock) error
 	Update(bookstock *BookStock) error
 	Delete(code string) error
+	// CreateMany inserts all copies in one transaction.
+	CreateMany(ctx context.Context, bookstocks []BookStock) error
+	// ChangeStatuses applies the logged status changes and saves the logs in
+	// one transaction, calling onChange after each change. It fails with
+	// ErrBookstockStatusChanged, writing nothing, when a copy is no longer in
+	// its logged FromStatus.
+	ChangeStatuses(ctx context.Context, logs []BookStockStatusLog, onChange StatusChangeHook) error
+	FindStatusLogs(ctx context.Context, code string) ([]BookStockStatusLog, error)
 }
 
 type BookstockService interface {
-	GetAllBookstocks() ([]dto.BookstockResponse, error)
+	GetAllBookstocks(filter dto.BookstockFilter) ([]dto.BookstockResponse, error)
 	GetBookstockByCode(code string) (*dto.BookstockResponse, error)
 	GetBookstocksByBookID(bookID uuid.UUID) ([]dto.BookstockResponse, error)
-	GetAvailableBookstocksByBookID(bookID uuid.UUID) ([]dto.BookstockResponse, error)
+	GetAvailableBookstocksByBookID(bookID uuid.UUID, filter dto.BookstockFilter) ([]dto.BookstockResponse, error)
 	CreateBookstock(req dto.BookstockCreateRequest) (*dto.BookstockResponse, error)
-	UpdateBookstock(code string, req dto.BookstockUpdateRequest) (*dto.BookstockResponse, error)
+	UpdateBookstock(ctx context.Context, code string, changedBy uuid.UUID, req dto.BookstockUpdateRequest) (*dto.BookstockResponse, error)
+	UpdateLocation(ctx context.Context, code string, req dto.BookstockLocationRequest) (*dto.BookstockResponse, error)
+	UpdateCondition(ctx context.Context, code string, req dto.BookstockConditionRequest) (*dto.BookstockResponse, error)
+	UpdateItemType(ctx context.Context, code string, req dto.BookstockItemTypeRequest) (*dto.BookstockResponse, error)
 	DeleteBookstock(code string) error
+	BulkCreateBookstocks(ctx context.Context, req dto.BookstockBulkCreateRequest) (*dto.BookstockBulkReport, error)
+	BulkUpdateStatus(ctx context.Context, changedBy uuid.UUID, req dto.BookstockBulkStatusRequest) (*dto.BookstockBulkReport, error)
+	GetStatusHistory(ctx context.Context, code string) ([]dto.BookstockStatusLogResponse, error)
 }
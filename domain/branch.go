@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Branch is a library location. Its Code is used as the branch segment of
+// generated stock codes.
+type Branch struct {
+	ID        uuid.UUID       `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Code      string          `gorm:"size:20;not null;uniqueIndex" json:"code"`
+	Name      string          `gorm:"size:255;not null" json:"name"`
+	Address   string          `gorm:"type:text" json:"address"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Shelves   []ShelfLocation `gorm:"foreignKey:BranchID" json:"shelves,omitempty"`
+}
+
+// ShelfLocation is a shelf or area inside a branch, e.g. "A3" for adult
+// fiction.
+type ShelfLocation struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	BranchID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_shelf_branch_code" json:"branch_id"`
+	Branch      *Branch   `gorm:"foreignKey:BranchID" json:"branch,omitempty"`
+	Code        string    `gorm:"size:50;not null;uniqueIndex:idx_shelf_branch_code" json:"code"`
+	Name        string    `gorm:"size:255" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type BranchRepository interface {
+	FindAll(ctx context.Context) ([]Branch, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Branch, error)
+	FindByCode(ctx context.Context, code string) (*Branch, error)
+	Create(ctx context.Context, branch *Branch) error
+	Update(ctx context.Context, branch *Branch) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindShelves(ctx context.Context, branchID uuid.UUID) ([]ShelfLocation, error)
+	FindShelfByID(ctx context.Context, id uuid.UUID) (*ShelfLocation, error)
+	CreateShelf(ctx context.Context, shelf *ShelfLocation) error
+	UpdateShelf(ctx context.Context, shelf *ShelfLocation) error
+	DeleteShelf(ctx context.Context, id uuid.UUID) error
+	// CountBranchCopies and CountShelfCopies count the copies whose home or
+	// current location is the given branch or shelf.
+	CountBranchCopies(ctx context.Context, id uuid.UUID) (int64, error)
+	CountShelfCopies(ctx context.Context, id uuid.UUID) (int64, error)
+}
+
+type BranchService interface {
+	GetBranches(ctx context.Context) ([]dto.BranchResponse, error)
+	GetBranchByID(ctx context.Context, id uuid.UUID) (*dto.BranchResponse, error)
+	CreateBranch(ctx context.Context, req dto.BranchRequest) (*dto.BranchResponse, error)
+	UpdateBranch(ctx context.Context, id uuid.UUID, req dto.BranchRequest) (*dto.BranchResponse, error)
+	DeleteBranch(ctx context.Context, id uuid.UUID) error
+	GetShelves(ctx context.Context, branchID uuid.UUID) ([]dto.ShelfResponse, error)
+	CreateShelf(ctx context.Context, branchID uuid.UUID, req dto.ShelfRequest) (*dto.ShelfResponse, error)
+	UpdateShelf(ctx context.Context, id uuid.UUID, req dto.ShelfRequest) (*dto.ShelfResponse, error)
+	DeleteShelf(ctx context.Context, id uuid.UUID) error
+}
@@ -9,13 +9,25 @@ import (
 )
 
 type Customer struct {
-	ID               uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
-	Code             string            `gorm:"size:50;not null;unique" json:"code"`
-	Name             string            `gorm:"size:255;not null" json:"name"`
-	CreatedAt        time.Time         `json:"created_at"`
-	UpdatedAt        time.Time         `json:"updated_at"`
-	DeletedAt        gorm.DeletedAt    `gorm:"index" json:"-"`
-	BookTransactions []BookTransaction `gorm:"foreignKey:CustomerID" json:"book_transactions,omitempty"`
+	ID                  uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Code                string     `gorm:"size:50;not null;unique" json:"code"`
+	Name                string     `gorm:"size:255;not null" json:"name"`
+	Email               string     `gorm:"size:255;index" json:"email"`
+	Phone               string     `gorm:"size:30" json:"phone"`
+	Address             string     `gorm:"type:text" json:"address"`
+	BirthDate           *time.Time `gorm:"type:date" json:"birth_date"`
+	MembershipType      string     `gorm:"size:20;not null;default:standard" json:"membership_type"`
+	MembershipStartedAt *time.Time `gorm:"type:date" json:"membership_started_at"`
+	MembershipExpiresAt *time.Time `gorm:"type:date;index" json:"membership_expires_at"` // Nil never expires
+	NotificationChannel string     `gorm:"size:10;not null;default:none" json:"notification_channel"`
+	// PatronClaimHash is the SHA-256 of the one-time code staff hand to the
+	// customer so they can register a patron account.
+	PatronClaimHash      string            `gorm:"size:64" json:"-"`
+	PatronClaimExpiresAt *time.Time        `json:"-"`
+	CreatedAt            time.Time         `json:"created_at"`
+	UpdatedAt            time.Time         `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt    `gorm:"index" json:"-"`
+	BookTransactions     []BookTransaction `gorm:"foreignKey:CustomerID" json:"book_transactions,omitempty"`
 }
 
 type CustomerRepository interface {
@@ -25,6 +37,11 @@ type CustomerRepository interface {
 	Create(customer *Customer) error
 	Update(customer *Customer) error
 	Delete(id uuid.UUID) error
+	FindDeleted() ([]Customer, error)
+	FindDeletedByID(id uuid.UUID) (*Customer, error)
+	Restore(id uuid.UUID) error
+	Purge(id uuid.UUID) error
+	CountDependents(id uuid.UUID) (int64, error)
 }
 
 type CustomerService interface {
@@ -34,4 +51,9 @@ type CustomerService interface {
 	CreateCustomer(req dto.CustomerCreateRequest) (*dto.CustomerResponse, error)
 	UpdateCustomer(id uuid.UUID, req dto.CustomerUpdateRequest) (*dto.CustomerResponse, error)
 	DeleteCustomer(id uuid.UUID) error
+	GetTrashedCustomers() ([]dto.CustomerResponse, error)
+	RestoreCustomer(id uuid.UUID) (*dto.CustomerResponse, error)
+	PurgeCustomer(id uuid.UUID) error
+	RenewMembership(id uuid.UUID, req dto.MembershipRenewRequest) (*dto.CustomerResponse, error)
+	IssuePatronClaim(id uuid.UUID) (*dto.PatronClaimResponse, error)
 }
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomerMerge is the audit record of a duplicate customer folded into a
+// surviving one. The duplicate's code and name are kept because the
+// duplicate itself is soft-deleted.
+type CustomerMerge struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	SurvivorID    uuid.UUID `gorm:"type:uuid;not null;index" json:"survivor_id"`
+	DuplicateID   uuid.UUID `gorm:"type:uuid;not null;index" json:"duplicate_id"`
+	DuplicateCode string    `gorm:"size:50;not null" json:"duplicate_code"`
+	DuplicateName string    `gorm:"size:255;not null" json:"duplicate_name"`
+	MergedBy      uuid.UUID `gorm:"type:uuid;not null" json:"merged_by"`
+	// Counts of the records moved to the survivor.
+	Loans        int  `gorm:"not null" json:"loans"`
+	Charges      int  `gorm:"not null" json:"charges"`
+	Holds        int  `gorm:"not null" json:"holds"`
+	Suspensions  int  `gorm:"not null" json:"suspensions"`
+	Reviews      int  `gorm:"not null" json:"reviews"`
+	ReadingLists int  `gorm:"not null" json:"reading_lists"`
+	AccountMoved bool `gorm:"not null" json:"account_moved"`
+	// WishlistItems were added to the survivor's wishlist; HoldsCancelled
+	// duplicated a hold the survivor already had.
+	WishlistItems  int       `gorm:"not null;default:0" json:"wishlist_items"`
+	HoldsCancelled int       `gorm:"not null;default:0" json:"holds_cancelled"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// HoldCancelFunc cancels hold inside tx, passing on any copy set aside for it.
+type HoldCancelFunc func(tx *gorm.DB, hold *Hold) error
+
+type CustomerMergeRepository interface {
+	// Merge moves everything the duplicate owns to the survivor, soft-deletes
+	// the duplicate and saves merge, all in one transaction. The counts on
+	// merge are filled in as records move. Holds the survivor ends up with
+	// twice are closed with cancelHold.
+	Merge(ctx context.Context, survivor *Customer, duplicate *Customer, merge *CustomerMerge, cancelHold HoldCancelFunc) error
+	FindMerges(ctx context.Context, customerID *uuid.UUID) ([]CustomerMerge, error)
+}
+
+type CustomerMergeService interface {
+	FindDuplicates(ctx context.Context, filter dto.DuplicateFilter) ([]dto.DuplicateCandidate, error)
+	Merge(ctx context.Context, userID uuid.UUID, req dto.CustomerMergeRequest) (*dto.CustomerMergeResponse, error)
+	GetMerges(ctx context.Context, customerID *uuid.UUID) ([]dto.CustomerMergeResponse, error)
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+
+	"github.com/google/uuid"
+)
+
+// Dependents are the records that still reference a book, copy or customer.
+type Dependents struct {
+	ActiveLoans        []BookTransaction
+	OutstandingCharges []Charge
+	Copies             []BookStock
+	LoanHistory        []BookTransaction
+	ActiveTransfers    []Transfer
+	ActiveHolds        []Hold
+}
+
+// DeleteBlockedError is returned when a delete rule refuses a delete. It
+// matches constants.ErrDeleteBlocked with errors.Is.
+type DeleteBlockedError struct {
+	Dependents []dto.DependentRecord
+}
+
+func (e *DeleteBlockedError) Error() string {
+	return constants.ErrDeleteBlocked.Error()
+}
+
+func (e *DeleteBlockedError) Unwrap() error {
+	return constants.ErrDeleteBlocked
+}
+
+type DependencyRepository interface {
+	FindBookDependents(ctx context.Context, bookID uuid.UUID) (*Dependents, error)
+	FindBookstockDependents(ctx context.Context, code string) (*Dependents, error)
+	FindCustomerDependents(ctx context.Context, customerID uuid.UUID) (*Dependents, error)
+	CascadeDeleteBook(ctx context.Context, bookID uuid.UUID) error
+	ArchiveBook(ctx context.Context, bookID uuid.UUID) error
+	UnarchiveBook(ctx context.Context, bookID uuid.UUID) error
+	CascadeDeleteBookstock(ctx context.Context, code string) error
+	ArchiveBookstock(ctx context.Context, code string) error
+}
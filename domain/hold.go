@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Hold is a customer's request for the next copy of a book. A hold targets
+// either one edition (BookID) or any edition of a work (WorkID).
+type Hold struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	CustomerID uuid.UUID  `gorm:"not null;index" json:"customer_id"`
+	BookID     *uuid.UUID `gorm:"index" json:"book_id"`
+	WorkID     *uuid.UUID `gorm:"index" json:"work_id"`
+	// PickupBranchID is where the customer collects the copy. Copies found
+	// at other branches are transferred there first.
+	PickupBranchID *uuid.UUID `gorm:"type:uuid;index" json:"pickup_branch_id"`
+	Status         string     `gorm:"size:50;not null;index" json:"status"` // Waiting, Ready, Fulfilled, Cancelled, Expired
+	StockCode      *string    `gorm:"size:50" json:"stock_code"`            // Copy set aside once the hold is ready
+	ReadyAt        *time.Time `json:"ready_at"`
+	PickupBy       *time.Time `gorm:"index" json:"pickup_by"` // A ready hold expires after this
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type HoldRepository interface {
+	Find(ctx context.Context, filter dto.HoldFilter) ([]Hold, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Hold, error)
+	CountActive(ctx context.Context, filter dto.HoldFilter) (int64, error)
+	// FindExpired returns the ready holds whose pickup deadline is before at.
+	FindExpired(ctx context.Context, at time.Time) ([]Hold, error)
+	Create(ctx context.Context, hold *Hold) error
+	Update(ctx context.Context, hold *Hold) error
+}
+
+type HoldService interface {
+	GetHolds(ctx context.Context, filter dto.HoldFilter) ([]dto.HoldResponse, error)
+	GetHoldByID(ctx context.Context, id uuid.UUID) (*dto.HoldResponse, error)
+	PlaceHold(ctx context.Context, req dto.HoldCreateRequest) (*dto.HoldResponse, error)
+	CancelHold(ctx context.Context, id uuid.UUID) (*dto.HoldResponse, error)
+	ExpireHolds(ctx context.Context) (int, error)
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+)
+
+type LabelService interface {
+	// RenderBarcode returns the barcode image of a stock code and its
+	// content type.
+	RenderBarcode(ctx context.Context, code string, opts dto.BarcodeOptions) ([]byte, string, error)
+	RenderLabelSheet(ctx context.Context, req dto.LabelSheetRequest) ([]byte, error)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+type MARCService interface {
+	ImportRecords(ctx context.Context, r io.Reader, opts dto.MARCImportOptions) (*dto.MARCImportReport, error)
+	ExportBooks(ctx context.Context, w io.Writer, ids []uuid.UUID) error
+}
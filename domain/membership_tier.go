@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MembershipTier sets the borrowing privileges of the customers whose
+// MembershipType matches its Code.
+type MembershipTier struct {
+	ID             uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Code           string    `gorm:"size:20;not null;uniqueIndex" json:"code"`
+	Name           string    `gorm:"size:255;not null" json:"name"`
+	LoanLimit      int       `gorm:"not null" json:"loan_limit"`       // Copies on loan at once
+	LoanPeriodDays int       `gorm:"not null" json:"loan_period_days"` // Default due date for new loans and renewals
+	RenewalLimit   int       `gorm:"not null" json:"renewal_limit"`    // Renewals per loan
+	DailyFine      float64   `gorm:"not null" json:"daily_fine"`       // Late fee per day overdue
+	HoldLimit      int       `gorm:"not null" json:"hold_limit"`       // Active holds at once
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type MembershipTierRepository interface {
+	FindAll(ctx context.Context) ([]MembershipTier, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*MembershipTier, error)
+	FindByCode(ctx context.Context, code string) (*MembershipTier, error)
+	Create(ctx context.Context, tier *MembershipTier) error
+	Update(ctx context.Context, tier *MembershipTier) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	CountCustomers(ctx context.Context, code string) (int64, error)
+}
+
+type MembershipTierService interface {
+	GetTiers(ctx context.Context) ([]dto.MembershipTierResponse, error)
+	GetTierByID(ctx context.Context, id uuid.UUID) (*dto.MembershipTierResponse, error)
+	CreateTier(ctx context.Context, req dto.MembershipTierRequest) (*dto.MembershipTierResponse, error)
+	UpdateTier(ctx context.Context, id uuid.UUID, req dto.MembershipTierRequest) (*dto.MembershipTierResponse, error)
+	DeleteTier(ctx context.Context, id uuid.UUID) error
+}
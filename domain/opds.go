@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+
+	"github.com/google/uuid"
+)
+
+type OPDSService interface {
+	Root(baseURL string) *dto.OPDSFeed
+	NewArrivals(ctx context.Context, baseURL string, page int) (*dto.OPDSFeed, error)
+	Categories(ctx context.Context, baseURL string) (*dto.OPDSFeed, error)
+	BooksByCategory(ctx context.Context, baseURL, category string, page int) (*dto.OPDSFeed, error)
+	Authors(ctx context.Context, baseURL string) (*dto.OPDSFeed, error)
+	BooksByAuthor(ctx context.Context, baseURL, author string, page int) (*dto.OPDSFeed, error)
+	Search(ctx context.Context, baseURL, query string, page int) (*dto.OPDSFeed, error)
+	Book(ctx context.Context, baseURL string, id uuid.UUID) (*dto.OPDSEntry, error)
+	OpenSearchDescription(baseURL string) *dto.OpenSearchDescription
+	// CoverFile returns the path on disk of a cover linked from the feed.
+	CoverFile(ctx context.Context, name string) (string, error)
+}
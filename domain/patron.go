@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+
+	"github.com/google/uuid"
+)
+
+// PatronService serves the self-service portal. Every method is scoped to
+// the signed-in customer; records of other customers read as not found.
+type PatronService interface {
+	GetProfile(ctx context.Context, customerID uuid.UUID) (*dto.CustomerResponse, error)
+	GetLoans(ctx context.Context, customerID uuid.UUID, status string) ([]dto.BookTransactionResponse, error)
+	RenewLoan(ctx context.Context, customerID uuid.UUID, loanID uuid.UUID) (*dto.BookTransactionResponse, error)
+	GetCharges(ctx context.Context, customerID uuid.UUID) ([]dto.ChargeResponse, error)
+	GetHolds(ctx context.Context, customerID uuid.UUID) ([]dto.HoldResponse, error)
+	PlaceHold(ctx context.Context, customerID uuid.UUID, req dto.PatronHoldRequest) (*dto.HoldResponse, error)
+	CancelHold(ctx context.Context, customerID uuid.UUID, holdID uuid.UUID) (*dto.HoldResponse, error)
+	GetReviews(ctx context.Context, customerID uuid.UUID) ([]dto.ReviewResponse, error)
+	CreateReview(ctx context.Context, customerID uuid.UUID, req dto.PatronReviewRequest) (*dto.ReviewResponse, error)
+	UpdateReview(ctx context.Context, customerID uuid.UUID, reviewID uuid.UUID, req dto.ReviewUpdateRequest) (*dto.ReviewResponse, error)
+	DeleteReview(ctx context.Context, customerID uuid.UUID, reviewID uuid.UUID) error
+}
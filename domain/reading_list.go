@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReadingList is an ordered list of books. Curated lists are published by
+// librarians; wishlists belong to a single customer.
+type ReadingList struct {
+	ID           uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Title        string            `gorm:"size:255;not null" json:"title"`
+	Description  string            `gorm:"type:text" json:"description"`
+	Kind         string            `gorm:"size:50;not null;index" json:"kind"`       // Curated, Wishlist
+	Visibility   string            `gorm:"size:50;not null;index" json:"visibility"` // Public, Staff, Private
+	CustomerID   *uuid.UUID        `gorm:"index" json:"customer_id"`
+	CoverID      *uuid.UUID        `json:"cover_id"`
+	Cover        *Media            `gorm:"foreignKey:CoverID" json:"cover,omitempty"`
+	PublishFrom  *time.Time        `json:"publish_from"`
+	PublishUntil *time.Time        `json:"publish_until"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	Items        []ReadingListItem `gorm:"foreignKey:ReadingListID" json:"items,omitempty"`
+}
+
+type ReadingListItem struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	ReadingListID uuid.UUID `gorm:"not null;uniqueIndex:idx_reading_list_items_book" json:"reading_list_id"`
+	BookID        uuid.UUID `gorm:"not null;uniqueIndex:idx_reading_list_items_book" json:"book_id"`
+	Book          Book      `gorm:"foreignKey:BookID" json:"book,omitempty"`
+	Position      int       `gorm:"not null" json:"position"`
+	Note          string    `gorm:"type:text" json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ReadingListRepository interface {
+	FindAll(ctx context.Context, filter dto.ReadingListFilter) ([]ReadingList, error)
+	FindPublished(ctx context.Context, at time.Time) ([]ReadingList, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*ReadingList, error)
+	Create(ctx context.Context, list *ReadingList) error
+	Update(ctx context.Context, list *ReadingList) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	AddItem(ctx context.Context, item *ReadingListItem) error
+	UpdateItem(ctx context.Context, item *ReadingListItem) error
+	RemoveItem(ctx context.Context, listID, bookID uuid.UUID) error
+	Reorder(ctx context.Context, listID uuid.UUID, bookIDs []uuid.UUID) error
+}
+
+type ReadingListService interface {
+	GetReadingLists(ctx context.Context, filter dto.ReadingListFilter) ([]dto.ReadingListResponse, error)
+	GetPublishedReadingLists(ctx context.Context) ([]dto.ReadingListResponse, error)
+	GetReadingListByID(ctx context.Context, id uuid.UUID) (*dto.ReadingListResponse, error)
+	GetPublishedReadingListByID(ctx context.Context, id uuid.UUID) (*dto.ReadingListResponse, error)
+	CreateReadingList(ctx context.Context, req dto.ReadingListCreateRequest) (*dto.ReadingListResponse, error)
+	UpdateReadingList(ctx context.Context, id uuid.UUID, req dto.ReadingListUpdateRequest) (*dto.ReadingListResponse, error)
+	DeleteReadingList(ctx context.Context, id uuid.UUID) error
+	AddItem(ctx context.Context, id uuid.UUID, req dto.ReadingListItemRequest) (*dto.ReadingListResponse, error)
+	UpdateItem(ctx context.Context, id, bookID uuid.UUID, req dto.ReadingListItemUpdateRequest) (*dto.ReadingListResponse, error)
+	RemoveItem(ctx context.Context, id, bookID uuid.UUID) (*dto.ReadingListResponse, error)
+	Reorder(ctx context.Context, id uuid.UUID, req dto.ReadingListReorderRequest) (*dto.ReadingListResponse, error)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookRecommendation is a precomputed "borrowed together" pair. Score is the
+// number of distinct customers who borrowed both books.
+type BookRecommendation struct {
+	BookID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"book_id"`
+	RecommendedBookID uuid.UUID `gorm:"type:uuid;primaryKey" json:"recommended_book_id"`
+	RecommendedBook   Book      `gorm:"foreignKey:RecommendedBookID" json:"recommended_book,omitempty"`
+	Score             int64     `gorm:"not null" json:"score"`
+	ComputedAt        time.Time `gorm:"not null" json:"computed_at"`
+}
+
+// CustomerRecommendation is a precomputed suggestion for a customer, scored
+// by summing the pair scores of the books in their loan history.
+type CustomerRecommendation struct {
+	CustomerID uuid.UUID `gorm:"type:uuid;primaryKey" json:"customer_id"`
+	BookID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"book_id"`
+	Book       Book      `gorm:"foreignKey:BookID" json:"book,omitempty"`
+	Score      int64     `gorm:"not null" json:"score"`
+	ComputedAt time.Time `gorm:"not null" json:"computed_at"`
+}
+
+type RecommendationRepository interface {
+	Rebuild(ctx context.Context, minCoBorrow, limit int, computedAt time.Time) (*dto.RecommendationRunReport, error)
+	FindForBook(ctx context.Context, bookID uuid.UUID) ([]BookRecommendation, error)
+	FindForCustomer(ctx context.Context, customerID uuid.UUID) ([]CustomerRecommendation, error)
+}
+
+type RecommendationService interface {
+	Rebuild(ctx context.Context) (*dto.RecommendationRunReport, error)
+	GetBookRecommendations(ctx context.Context, bookID uuid.UUID) ([]dto.RecommendationResponse, error)
+	GetCustomerRecommendations(ctx context.Context, customerID uuid.UUID) ([]dto.RecommendationResponse, error)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Review is a customer's rating of a book they have borrowed and returned.
+// Only approved reviews are shown to patrons and counted in the book rating.
+type Review struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	BookID         uuid.UUID  `gorm:"not null;uniqueIndex:idx_reviews_book_customer" json:"book_id"`
+	CustomerID     uuid.UUID  `gorm:"not null;uniqueIndex:idx_reviews_book_customer" json:"customer_id"`
+	Customer       Customer   `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	Rating         int        `gorm:"not null" json:"rating"`
+	Body           string     `gorm:"type:text" json:"body"`
+	Status         string     `gorm:"size:50;not null;index" json:"status"` // Pending, Approved, Rejected
+	ModerationNote string     `gorm:"type:text" json:"moderation_note"`
+	ModeratedBy    *uuid.UUID `json:"moderated_by"`
+	ModeratedAt    *time.Time `json:"moderated_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type ReviewRepository interface {
+	Find(ctx context.Context, filter dto.ReviewFilter) ([]Review, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Review, error)
+	ExistsForBook(ctx context.Context, bookID, customerID uuid.UUID) (bool, error)
+	HasCompletedLoan(ctx context.Context, bookID, customerID uuid.UUID) (bool, error)
+	Create(ctx context.Context, review *Review) error
+	Update(ctx context.Context, review *Review) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	RefreshBookRating(ctx context.Context, bookID uuid.UUID) error
+}
+
+type ReviewService interface {
+	GetReviews(ctx context.Context, filter dto.ReviewFilter) ([]dto.ReviewResponse, error)
+	GetReviewByID(ctx context.Context, id uuid.UUID) (*dto.ReviewResponse, error)
+	CreateReview(ctx context.Context, req dto.ReviewCreateRequest) (*dto.ReviewResponse, error)
+	UpdateReview(ctx context.Context, id uuid.UUID, req dto.ReviewUpdateRequest) (*dto.ReviewResponse, error)
+	ModerateReview(ctx context.Context, id, moderatorID uuid.UUID, req dto.ReviewModerationRequest) (*dto.ReviewResponse, error)
+	DeleteReview(ctx context.Context, id uuid.UUID) error
+}
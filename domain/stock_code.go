@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// StockCodeSequence is the last sequence number handed out for a code
+// prefix and branch.
+type StockCodeSequence struct {
+	Scope     string    `gorm:"primaryKey;size:100" json:"scope"`
+	Value     int64     `gorm:"not null" json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type StockCodeRepository interface {
+	// Reserve claims count consecutive numbers in scope and returns the
+	// first one.
+	Reserve(ctx context.Context, scope string, count int) (int64, error)
+}
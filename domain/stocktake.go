@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stocktake is an inventory session over the copies currently at a branch or
+// shelf, whose code starts with Prefix, or whose code falls in the inclusive
+// CodeFrom..CodeTo range. Set criteria are combined.
+type Stocktake struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Name       string     `gorm:"size:255;not null" json:"name"`
+	BranchID   *uuid.UUID `gorm:"type:uuid" json:"branch_id"`
+	ShelfID    *uuid.UUID `gorm:"type:uuid" json:"shelf_id"`
+	Prefix     string     `gorm:"size:50" json:"prefix"`
+	CodeFrom   string     `gorm:"size:50" json:"code_from"`
+	CodeTo     string     `gorm:"size:50" json:"code_to"`
+	Status     string     `gorm:"size:50;not null;index" json:"status"` // Open, Closed, Approved
+	OpenedBy   *uuid.UUID `gorm:"type:uuid" json:"opened_by"`
+	ApprovedBy *uuid.UUID `gorm:"type:uuid" json:"approved_by"`
+	ClosedAt   *time.Time `json:"closed_at"`
+	ApprovedAt *time.Time `json:"approved_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// StocktakeScan is a code scanned during a session. Scanning the same code
+// twice is a no-op.
+type StocktakeScan struct {
+	StocktakeID uuid.UUID `gorm:"type:uuid;primaryKey" json:"stocktake_id"`
+	Code        string    `gorm:"size:50;primaryKey" json:"code"`
+	ScannedAt   time.Time `json:"scanned_at"`
+}
+
+// StocktakeMissing is a copy reported missing when the session closed, with
+// the status it had then. Approval acts on this snapshot only.
+type StocktakeMissing struct {
+	StocktakeID uuid.UUID `gorm:"type:uuid;primaryKey" json:"stocktake_id"`
+	Code        string    `gorm:"size:50;primaryKey" json:"code"`
+	Status      string    `gorm:"size:50;not null" json:"status"`
+	BookStock   BookStock `gorm:"foreignKey:Code;references:Code" json:"-"`
+}
+
+type StocktakeRepository interface {
+	FindAll(ctx context.Context) ([]Stocktake, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Stocktake, error)
+	Create(ctx context.Context, stocktake *Stocktake) error
+	Update(ctx context.Context, stocktake *Stocktake) error
+	// AddScans records codes and returns how many were new to the session.
+	AddScans(ctx context.Context, id uuid.UUID, codes []string, scannedAt time.Time) (int64, error)
+	CountScans(ctx context.Context, id uuid.UUID) (int64, error)
+	CountExpected(ctx context.Context, stocktake *Stocktake) (int64, error)
+	// FindMissing returns the copies in scope that should be on the shelf
+	// but were not scanned.
+	FindMissing(ctx context.Context, stocktake *Stocktake) ([]BookStock, error)
+	// Close saves the closed session together with its missing copies.
+	Close(ctx context.Context, stocktake *Stocktake) error
+	FindMissingSnapshot(ctx context.Context, id uuid.UUID) ([]StocktakeMissing, error)
+	// FindScannedWithStatus returns scanned copies currently in one of
+	// statuses.
+	FindScannedWithStatus(ctx context.Context, id uuid.UUID, statuses []string) ([]BookStock, error)
+	FindUnknownScans(ctx context.Context, id uuid.UUID) ([]string, error)
+	FindOutOfScopeScans(ctx context.Context, stocktake *Stocktake) ([]string, error)
+	// Approve marks the logged copies with their new status and saves the
+	// session in one transaction, calling onChange after each change.
+	Approve(ctx context.Context, stocktake *Stocktake, logs []BookStockStatusLog, onChange StatusChangeHook) error
+}
+
+type StocktakeService interface {
+	GetStocktakes(ctx context.Context) ([]dto.StocktakeResponse, error)
+	GetStocktakeByID(ctx context.Context, id uuid.UUID) (*dto.StocktakeResponse, error)
+	OpenStocktake(ctx context.Context, openedBy uuid.UUID, req dto.StocktakeCreateRequest) (*dto.StocktakeResponse, error)
+	Scan(ctx context.Context, id uuid.UUID, codes []string) (*dto.StocktakeScanResult, error)
+	CloseStocktake(ctx context.Context, id uuid.UUID) (*dto.StocktakeReport, error)
+	GetReport(ctx context.Context, id uuid.UUID) (*dto.StocktakeReport, error)
+	ApproveStocktake(ctx context.Context, id uuid.UUID, approvedBy uuid.UUID) (*dto.StocktakeReport, error)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Suspension blocks a customer from borrowing, renewing and placing holds.
+// It is active until lifted by staff or, when it has one, until ExpiresAt.
+type Suspension struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	CustomerID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"customer_id"`
+	Reason      string     `gorm:"size:50;not null" json:"reason"` // Unpaid fines, Behaviour, Lost card, Other
+	Note        string     `gorm:"type:text" json:"note"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	SuspendedBy uuid.UUID  `gorm:"type:uuid;not null" json:"suspended_by"`
+	LiftedAt    *time.Time `json:"lifted_at"`
+	LiftedBy    *uuid.UUID `gorm:"type:uuid" json:"lifted_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type SuspensionRepository interface {
+	FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]Suspension, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Suspension, error)
+	Create(ctx context.Context, suspension *Suspension) error
+	Update(ctx context.Context, suspension *Suspension) error
+	// HasActive reports whether the customer has a suspension in force at now.
+	HasActive(ctx context.Context, customerID uuid.UUID, now time.Time) (bool, error)
+}
+
+type SuspensionService interface {
+	GetSuspensions(ctx context.Context, customerID uuid.UUID) ([]dto.SuspensionResponse, error)
+	Suspend(ctx context.Context, customerID uuid.UUID, userID uuid.UUID, req dto.SuspensionCreateRequest) (*dto.SuspensionResponse, error)
+	Lift(ctx context.Context, customerID uuid.UUID, id uuid.UUID, userID uuid.UUID) (*dto.SuspensionResponse, error)
+}
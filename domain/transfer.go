@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Transfer moves a copy from its current branch to another one. A transfer
+// raised for a hold carries the hold's ID and readies it on arrival.
+type Transfer struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	StockCode    string     `gorm:"size:50;not null;index" json:"stock_code"`
+	BookStock    BookStock  `gorm:"foreignKey:StockCode;references:Code" json:"book_stock,omitempty"`
+	FromBranchID uuid.UUID  `gorm:"type:uuid;not null;index" json:"from_branch_id"`
+	FromBranch   *Branch    `gorm:"foreignKey:FromBranchID" json:"from_branch,omitempty"`
+	ToBranchID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"to_branch_id"`
+	ToBranch     *Branch    `gorm:"foreignKey:ToBranchID" json:"to_branch,omitempty"`
+	ToShelfID    *uuid.UUID `gorm:"type:uuid" json:"to_shelf_id"`
+	Status       string     `gorm:"size:50;not null;index" json:"status"` // Requested, In transit, Received, Cancelled
+	Reason       string     `gorm:"size:50;not null" json:"reason"`       // Manual or Hold
+	HoldID       *uuid.UUID `gorm:"type:uuid;index" json:"hold_id"`
+	Note         string     `gorm:"type:text" json:"note"`
+	RequestedBy  *uuid.UUID `gorm:"type:uuid" json:"requested_by"`
+	ShippedAt    *time.Time `json:"shipped_at"`
+	ReceivedAt   *time.Time `json:"received_at"`
+	CancelledAt  *time.Time `json:"cancelled_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type TransferRepository interface {
+	Find(ctx context.Context, filter dto.TransferFilter) ([]Transfer, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Transfer, error)
+}
+
+type TransferService interface {
+	GetTransfers(ctx context.Context, filter dto.TransferFilter) ([]dto.TransferResponse, error)
+	GetTransferByID(ctx context.Context, id uuid.UUID) (*dto.TransferResponse, error)
+	GetQueue(ctx context.Context, branchID uuid.UUID) (*dto.TransferQueue, error)
+	RequestTransfer(ctx context.Context, requestedBy uuid.UUID, req dto.TransferCreateRequest) (*dto.TransferResponse, error)
+	ShipTransfer(ctx context.Context, id uuid.UUID) (*dto.TransferResponse, error)
+	ReceiveTransfer(ctx context.Context, id uuid.UUID) (*dto.TransferResponse, error)
+	CancelTransfer(ctx context.Context, id uuid.UUID) (*dto.TransferResponse, error)
+}
@@ -8,13 +8,15 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID    `gorm:"type:uuid;default:uuid_generate_v4()" db:"id"`
-	Name      string       `gorm:"size:100;not null" db:"name"`
-	Email     string       `gorm:"size:100;uniqueIndex;not null" db:"email"`
-	Password  string       `gorm:"size:255;not null" db:"password"`
-	Role      string       `gorm:"size:50;not null;default:user" db:"role"`
-	CreatedAt sql.NullTime `gorm:"autoCreateTime" db:"created_at"`
-	UpdatedAt sql.NullTime `gorm:"autoUpdateTime" db:"updated_at"`
+	ID       uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()" db:"id"`
+	Name     string    `gorm:"size:100;not null" db:"name"`
+	Email    string    `gorm:"size:100;uniqueIndex;not null" db:"email"`
+	Password string    `gorm:"size:255;not null" db:"password"`
+	Role     string    `gorm:"size:50;not null;default:STAFF" db:"role"`
+	// CustomerID links a patron account to its customer record.
+	CustomerID *uuid.UUID   `gorm:"type:uuid;uniqueIndex" db:"customer_id"`
+	CreatedAt  sql.NullTime `gorm:"autoCreateTime" db:"created_at"`
+	UpdatedAt  sql.NullTime `gorm:"autoUpdateTime" db:"updated_at"`
 }
 
 type UserRepository interface {
@@ -22,4 +24,6 @@ type UserRepository interface {
 	Update(ctx context.Context, user *User) error
 	FindById(ctx context.Context, id string) (User, error)
 	FindByEmail(ctx context.Context, email string) (User, error)
+	FindByCustomerID(ctx context.Context, customerID uuid.UUID) (User, error)
+	Count(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Deaccession is a reviewed batch of copies to withdraw. Nothing changes
+// until it is approved; approval marks each copy WITHDRAWN with the batch
+// reason.
+type Deaccession struct {
+	ID          uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Reason      string            `gorm:"size:500;not null" json:"reason"`
+	Status      string            `gorm:"size:50;not null;index" json:"status"` // Pending, Approved, Rejected
+	RequestedBy *uuid.UUID        `gorm:"type:uuid" json:"requested_by"`
+	ReviewedBy  *uuid.UUID        `gorm:"type:uuid" json:"reviewed_by"`
+	ReviewedAt  *time.Time        `json:"reviewed_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Items       []DeaccessionItem `gorm:"foreignKey:DeaccessionID" json:"items,omitempty"`
+}
+
+// DeaccessionItem is one copy in a batch. FromStatus is the copy's status
+// when it was proposed; Withdrawn is set for copies actually withdrawn on
+// approval.
+type DeaccessionItem struct {
+	DeaccessionID uuid.UUID  `gorm:"type:uuid;primaryKey" json:"deaccession_id"`
+	StockCode     string     `gorm:"size:50;primaryKey" json:"stock_code"`
+	BookStock     *BookStock `gorm:"foreignKey:StockCode;references:Code" json:"book_stock,omitempty"`
+	FromStatus    string     `gorm:"size:50;not null" json:"from_status"`
+	Withdrawn     bool       `gorm:"not null;default:false" json:"withdrawn"`
+}
+
+type WeedingRepository interface {
+	// FindCandidates returns shelved copies that are damaged, graded poor or
+	// not borrowed since cutoff, leaving out copies already proposed.
+	FindCandidates(ctx context.Context, filter dto.WeedingFilter, cutoff time.Time) ([]dto.WeedingCandidate, error)
+	// FindPendingCodes returns which of codes are in a pending deaccession.
+	FindPendingCodes(ctx context.Context, codes []string) ([]string, error)
+	FindAll(ctx context.Context, status string) ([]Deaccession, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Deaccession, error)
+	Create(ctx context.Context, deaccession *Deaccession) error
+	Update(ctx context.Context, deaccession *Deaccession) error
+	// Approve withdraws the logged copies and saves the batch in one
+	// transaction, calling onChange after each copy withdrawn.
+	Approve(ctx context.Context, deaccession *Deaccession, logs []BookStockStatusLog, onChange StatusChangeHook) error
+}
+
+type WeedingService interface {
+	GetCandidates(ctx context.Context, filter dto.WeedingFilter) ([]dto.WeedingCandidate, error)
+	GetDeaccessions(ctx context.Context, status string) ([]dto.DeaccessionResponse, error)
+	GetDeaccessionByID(ctx context.Context, id uuid.UUID) (*dto.DeaccessionResponse, error)
+	CreateDeaccession(ctx context.Context, requestedBy uuid.UUID, req dto.DeaccessionCreateRequest) (*dto.DeaccessionResponse, *dto.BookstockBulkReport, error)
+	ApproveDeaccession(ctx context.Context, id uuid.UUID, reviewedBy uuid.UUID) (*dto.DeaccessionResponse, error)
+	RejectDeaccession(ctx context.Context, id uuid.UUID, reviewedBy uuid.UUID) (*dto.DeaccessionResponse, error)
+}
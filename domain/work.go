@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Work groups the editions and translations of the same title. Each edition
+// is a Book with its own ISBN, language and format.
+type Work struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Title       string    `gorm:"size:255;not null;index" json:"title"`
+	Author      string    `gorm:"size:255;index" json:"author"`
+	Description string    `gorm:"type:text" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Editions    []Book    `gorm:"foreignKey:WorkID" json:"editions,omitempty"`
+}
+
+type Series struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4()" json:"id"`
+	Title       string    `gorm:"size:255;not null;index" json:"title"`
+	Description string    `gorm:"type:text" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Books       []Book    `gorm:"foreignKey:SeriesID" json:"books,omitempty"`
+}
+
+type WorkRepository interface {
+	FindAll(ctx context.Context, search string) ([]Work, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Work, error)
+	CountEditions(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]int64, error)
+	Create(ctx context.Context, work *Work) error
+	Update(ctx context.Context, work *Work) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type WorkService interface {
+	GetWorks(ctx context.Context, search string) ([]dto.WorkResponse, error)
+	GetWorkByID(ctx context.Context, id uuid.UUID) (*dto.WorkResponse, error)
+	CreateWork(ctx context.Context, req dto.WorkCreateRequest) (*dto.WorkResponse, error)
+	UpdateWork(ctx context.Context, id uuid.UUID, req dto.WorkUpdateRequest) (*dto.WorkResponse, error)
+	DeleteWork(ctx context.Context, id uuid.UUID) error
+}
+
+type SeriesRepository interface {
+	FindAll(ctx context.Context, search string) ([]Series, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*Series, error)
+	Create(ctx context.Context, series *Series) error
+	Update(ctx context.Context, series *Series) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type SeriesService interface {
+	GetSeries(ctx context.Context, search string) ([]dto.SeriesResponse, error)
+	GetSeriesByID(ctx context.Context, id uuid.UUID) (*dto.SeriesResponse, error)
+	CreateSeries(ctx context.Context, req dto.SeriesCreateRequest) (*dto.SeriesResponse, error)
+	UpdateSeries(ctx context.Context, id uuid.UUID, req dto.SeriesUpdateRequest) (*dto.SeriesResponse, error)
+	DeleteSeries(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,132 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VendorRequest struct {
+	Name    string `json:"name" validate:"required,max=255"`
+	Contact string `json:"contact" validate:"max=255"`
+	Email   string `json:"email" validate:"omitempty,email,max=255"`
+	Phone   string `json:"phone" validate:"max=50"`
+	Notes   string `json:"notes"`
+}
+
+type VendorResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Contact   string    `json:"contact"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	Notes     string    `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type FundRequest struct {
+	Code       string  `json:"code" validate:"required,max=50"`
+	FiscalYear int     `json:"fiscal_year" validate:"required,min=2000,max=2100"`
+	Name       string  `json:"name" validate:"required,max=255"`
+	Budget     float64 `json:"budget" validate:"min=0"`
+}
+
+type FundResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Code       string    `json:"code"`
+	FiscalYear int       `json:"fiscal_year"`
+	Name       string    `json:"name"`
+	Budget     float64   `json:"budget"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// FundReportRow compares a fund's budget with what has been spent on
+// received copies and committed to copies still on order.
+type FundReportRow struct {
+	FundID     uuid.UUID `json:"fund_id"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	FiscalYear int       `json:"fiscal_year"`
+	Budget     float64   `json:"budget"`
+	Spent      float64   `json:"spent"`
+	Committed  float64   `json:"committed"`
+	Remaining  float64   `json:"remaining"`
+}
+
+type FundReport struct {
+	FiscalYear int             `json:"fiscal_year,omitempty"`
+	Funds      []FundReportRow `json:"funds"`
+	Budget     float64         `json:"budget"`
+	Spent      float64         `json:"spent"`
+	Committed  float64         `json:"committed"`
+	Remaining  float64         `json:"remaining"`
+}
+
+type PurchaseOrderLineRequest struct {
+	BookID       uuid.UUID  `json:"book_id" validate:"required"`
+	FundID       uuid.UUID  `json:"fund_id" validate:"required"`
+	Quantity     int        `json:"quantity" validate:"required,min=1,max=500"`
+	UnitPrice    float64    `json:"unit_price" validate:"min=0"`
+	HomeBranchID *uuid.UUID `json:"home_branch_id"`
+	HomeShelfID  *uuid.UUID `json:"home_shelf_id"`
+}
+
+// PurchaseOrderRequest creates a draft order or replaces a draft's lines.
+type PurchaseOrderRequest struct {
+	Number   string                     `json:"number" validate:"required,max=50"`
+	VendorID uuid.UUID                  `json:"vendor_id" validate:"required"`
+	Note     string                     `json:"note"`
+	Lines    []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1,max=200,dive"`
+}
+
+type PurchaseOrderReceiveLine struct {
+	LineID   uuid.UUID `json:"line_id" validate:"required"`
+	Quantity int       `json:"quantity" validate:"required,min=1,max=500"`
+}
+
+type PurchaseOrderReceiveRequest struct {
+	Lines []PurchaseOrderReceiveLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+type PurchaseOrderLineResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	BookID       uuid.UUID  `json:"book_id"`
+	Title        string     `json:"title,omitempty"`
+	FundID       uuid.UUID  `json:"fund_id"`
+	FundCode     string     `json:"fund_code,omitempty"`
+	Quantity     int        `json:"quantity"`
+	Received     int        `json:"received"`
+	UnitPrice    float64    `json:"unit_price"`
+	Total        float64    `json:"total"`
+	HomeBranchID *uuid.UUID `json:"home_branch_id"`
+	HomeShelfID  *uuid.UUID `json:"home_shelf_id"`
+}
+
+type PurchaseOrderResponse struct {
+	ID         uuid.UUID                   `json:"id"`
+	Number     string                      `json:"number"`
+	VendorID   uuid.UUID                   `json:"vendor_id"`
+	VendorName string                      `json:"vendor_name,omitempty"`
+	Status     string                      `json:"status"`
+	Note       string                      `json:"note"`
+	Total      float64                     `json:"total"`
+	CreatedBy  *uuid.UUID                  `json:"created_by"`
+	OrderedAt  *time.Time                  `json:"ordered_at"`
+	Lines      []PurchaseOrderLineResponse `json:"lines"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	UpdatedAt  time.Time                   `json:"updated_at"`
+}
+
+// PurchaseOrderReceipt is the order after receiving, with the codes of the
+// copies created per line.
+type PurchaseOrderReceipt struct {
+	Order  PurchaseOrderResponse  `json:"order"`
+	Copies map[uuid.UUID][]string `json:"copies"`
+}
+
+type PurchaseOrderFilter struct {
+	VendorID *uuid.UUID
+	Status   string
+}
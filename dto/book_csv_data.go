@@ -0,0 +1,23 @@
+package dto
+
+type BookImportOptions struct {
+	DryRun bool
+	Mode   string // atomic or chunked
+}
+
+type BookImportError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+type BookImportReport struct {
+	DryRun        bool              `json:"dry_run"`
+	Mode          string            `json:"mode"`
+	TotalRows     int               `json:"total_rows"`
+	ValidRows     int               `json:"valid_rows"`
+	BooksCreated  int               `json:"books_created"`
+	CopiesCreated int               `json:"copies_created"`
+	Errors        []BookImportError `json:"errors"`
+}
@@ -7,23 +7,123 @@ import (
 )
 
 type BookCreateRequest struct {
-	Title       string     `json:"title" validate:"required"`
-	Description string     `json:"description" validate:"required"`
-	CoverID     *uuid.UUID `json:"cover_id" validate:"omitempty"`
+	Title           string     `json:"title" validate:"required"`
+	Description     string     `json:"description" validate:"required"`
+	ISBN            string     `json:"isbn" validate:"omitempty,max=20"`
+	Author          string     `json:"author" validate:"omitempty,max=255"`
+	Publisher       string     `json:"publisher" validate:"omitempty,max=255"`
+	PublicationYear int        `json:"publication_year" validate:"omitempty,gte=0,lte=9999"`
+	Language        string     `json:"language" validate:"omitempty,max=50"`
+	Category        string     `json:"category" validate:"omitempty,max=100"`
+	Subjects        string     `json:"subjects" validate:"omitempty"`
+	WorkID          *uuid.UUID `json:"work_id" validate:"omitempty"`
+	Edition         string     `json:"edition" validate:"omitempty,max=100"`
+	Format          string     `json:"format" validate:"omitempty,oneof=hardcover paperback ebook audiobook other"`
+	ItemType        string     `json:"item_type" validate:"omitempty,oneof=circulating reference in_library restricted"`
+	MinAge          *int       `json:"min_age" validate:"omitempty,gte=0,lte=120"`
+	SeriesID        *uuid.UUID `json:"series_id" validate:"omitempty"`
+	SeriesVolume    *int       `json:"series_volume" validate:"omitempty,gte=0"`
+	CoverID         *uuid.UUID `json:"cover_id" validate:"omitempty"`
 }
 
 type BookUpdateRequest struct {
-	Title       string     `json:"title" validate:"omitempty"`
-	Description string     `json:"description" validate:"omitempty"`
-	CoverID     *uuid.UUID `json:"cover_id" validate:"omitempty"`
+	Title           string     `json:"title" validate:"omitempty"`
+	Description     string     `json:"description" validate:"omitempty"`
+	ISBN            string     `json:"isbn" validate:"omitempty,max=20"`
+	Author          string     `json:"author" validate:"omitempty,max=255"`
+	Publisher       string     `json:"publisher" validate:"omitempty,max=255"`
+	PublicationYear int        `json:"publication_year" validate:"omitempty,gte=0,lte=9999"`
+	Language        string     `json:"language" validate:"omitempty,max=50"`
+	Category        string     `json:"category" validate:"omitempty,max=100"`
+	Subjects        string     `json:"subjects" validate:"omitempty"`
+	WorkID          *uuid.UUID `json:"work_id" validate:"omitempty"`
+	Edition         string     `json:"edition" validate:"omitempty,max=100"`
+	Format          string     `json:"format" validate:"omitempty,oneof=hardcover paperback ebook audiobook other"`
+	ItemType        string     `json:"item_type" validate:"omitempty,oneof=circulating reference in_library restricted"`
+	MinAge          *int       `json:"min_age" validate:"omitempty,gte=0,lte=120"`
+	SeriesID        *uuid.UUID `json:"series_id" validate:"omitempty"`
+	SeriesVolume    *int       `json:"series_volume" validate:"omitempty,gte=0"`
+	CoverID         *uuid.UUID `json:"cover_id" validate:"omitempty"`
 }
 
 type BookResponse struct {
-	ID          uuid.UUID      `json:"id"`
-	Title       string         `json:"title"`
-	Description string         `json:"description"`
-	CoverID     *uuid.UUID     `json:"-"`
-	Cover       *MediaResponse `json:"cover,omitempty"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID              uuid.UUID            `json:"id"`
+	Title           string               `json:"title"`
+	Description     string               `json:"description"`
+	ISBN            string               `json:"isbn"`
+	Author          string               `json:"author"`
+	Publisher       string               `json:"publisher"`
+	PublicationYear int                  `json:"publication_year"`
+	Language        string               `json:"language"`
+	Category        string               `json:"category"`
+	Subjects        string               `json:"subjects"`
+	WorkID          *uuid.UUID           `json:"work_id"`
+	Edition         string               `json:"edition"`
+	Format          string               `json:"format"`
+	ItemType        string               `json:"item_type"`
+	MinAge          int                  `json:"min_age,omitempty"`
+	SeriesID        *uuid.UUID           `json:"series_id"`
+	SeriesVolume    *int                 `json:"series_volume"`
+	EditionCount    int64                `json:"edition_count,omitempty"`
+	RatingAverage   float64              `json:"rating_average"`
+	RatingCount     int64                `json:"rating_count"`
+	CoverID         *uuid.UUID           `json:"-"`
+	Cover           *MediaResponse       `json:"cover,omitempty"`
+	Availability    *BookAvailability    `json:"availability,omitempty"`
+	Branches        []BranchAvailability `json:"branches,omitempty"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+	DeletedAt       *time.Time           `json:"deleted_at,omitempty"`
+}
+
+type BookAvailability struct {
+	Total     int64 `json:"total"`
+	Available int64 `json:"available"`
+	// OnHold copies are on the shelf but set aside for a ready hold.
+	OnHold      int64      `json:"on_hold"`
+	Borrowed    int64      `json:"borrowed"`
+	Damaged     int64      `json:"damaged"`
+	Lost        int64      `json:"lost"`
+	InTransit   int64      `json:"in_transit"`
+	NextDueDate *time.Time `json:"next_due_date"`
+}
+
+// BookFilter holds the active catalog filters. Every facet in BookFacets
+// maps to one of these fields so a facet value can be selected as a filter.
+type BookFilter struct {
+	Search    string
+	CoverID   *uuid.UUID
+	Available *bool
+	Category  string
+	Author    string
+	Publisher string
+	Language  string
+	YearFrom  int
+	YearTo    int
+	WorkID    *uuid.UUID
+	SeriesID  *uuid.UUID
+	Collapse  string
+	Sort      string
+}
+
+type FacetCount struct {
+	Value    string `json:"value"`
+	Count    int64  `json:"count"`
+	Selected bool   `json:"selected"`
+}
+
+type YearRangeFacet struct {
+	From     int   `json:"from"`
+	To       int   `json:"to"`
+	Count    int64 `json:"count"`
+	Selected bool  `json:"selected"`
+}
+
+type BookFacets struct {
+	Availability    []FacetCount     `json:"availability"`
+	Category        []FacetCount     `json:"category"`
+	Author          []FacetCount     `json:"author"`
+	Publisher       []FacetCount     `json:"publisher"`
+	Language        []FacetCount     `json:"language"`
+	PublicationYear []YearRangeFacet `json:"publication_year"`
 }
@@ -10,7 +10,13 @@ type BookTransactionCreateRequest struct {
 	BookID     uuid.UUID `json:"book_id" validate:"required"`
 	StockCode  string    `json:"stock_code" validate:"required"`
 	CustomerID uuid.UUID `json:"customer_id" validate:"required"`
-	DueDate    string    `json:"due_date" validate:"required"`
+	// DueDate is YYYY-MM-DD; it defaults to the loan period of the customer's
+	// membership tier.
+	DueDate string `json:"due_date" validate:"omitempty,datetime=2006-01-02"`
+	// Condition regrades the copy as it goes out; the current grade is
+	// recorded when empty.
+	Condition      string `json:"condition" validate:"omitempty,oneof=new good worn poor"`
+	ConditionNotes string `json:"condition_notes"`
 }
 
 type BookTransactionUpdateRequest struct {
@@ -26,18 +32,28 @@ type BookTransactionUpdateStatusRequest struct {
 	ID     uuid.UUID `json:"id" validate:"required"`
 	Status string    `json:"status" validate:"required"`
 	Date   time.Time `json:"date" validate:"required"`
+	// Condition regrades the copy as it comes back; the current grade is
+	// recorded when empty.
+	Condition      string `json:"condition" validate:"omitempty,oneof=new good worn poor"`
+	ConditionNotes string `json:"condition_notes"`
 }
 
 type BookTransactionResponse struct {
-	ID         uuid.UUID          `json:"id"`
-	BookID     uuid.UUID          `json:"book_id"`
-	Book       *BookResponse      `json:"book,omitempty"`
-	StockCode  string             `json:"stock_code"`
-	BookStock  *BookstockResponse `json:"book_stock,omitempty"`
-	CustomerID uuid.UUID          `json:"customer_id"`
-	Customer   *CustomerResponse  `json:"customer,omitempty"`
-	DueDate    time.Time          `json:"due_date"`
-	Status     string             `json:"status"`
-	BorrowedAt *time.Time         `json:"borrowed_at"`
-	ReturnAt   *time.Time         `json:"return_at"`
+	ID                uuid.UUID          `json:"id"`
+	BookID            uuid.UUID          `json:"book_id"`
+	Book              *BookResponse      `json:"book,omitempty"`
+	StockCode         string             `json:"stock_code"`
+	BookStock         *BookstockResponse `json:"book_stock,omitempty"`
+	CustomerID        uuid.UUID          `json:"customer_id"`
+	Customer          *CustomerResponse  `json:"customer,omitempty"`
+	DueDate           time.Time          `json:"due_date"`
+	Status            string             `json:"status"`
+	BorrowedAt        *time.Time         `json:"borrowed_at"`
+	ReturnAt          *time.Time         `json:"return_at"`
+	Renewals          int                `json:"renewals"`
+	CheckoutCondition string             `json:"checkout_condition,omitempty"`
+	CheckoutNotes     string             `json:"checkout_notes,omitempty"`
+	ReturnCondition   string             `json:"return_condition,omitempty"`
+	ReturnNotes       string             `json:"return_notes,omitempty"`
+	Charges           []ChargeResponse   `json:"charges,omitempty"`
 }
@@ -6,20 +6,117 @@ import (
 	"github.com/google/uuid"
 )
 
+// BookstockCreateRequest creates one copy. A code is generated when Code is
+// left empty.
 type BookstockCreateRequest struct {
-	Code   string    `json:"code" validate:"required"`
-	BookID uuid.UUID `json:"book_id" validate:"required"`
+	Code            string     `json:"code" validate:"omitempty,max=50"`
+	BookID          uuid.UUID  `json:"book_id" validate:"required"`
+	HomeBranchID    *uuid.UUID `json:"home_branch_id"`
+	HomeShelfID     *uuid.UUID `json:"home_shelf_id"`
+	ItemType        string     `json:"item_type" validate:"omitempty,oneof=circulating reference in_library restricted"`
+	Condition       string     `json:"condition" validate:"omitempty,oneof=new good worn poor"`
+	ConditionNotes  string     `json:"condition_notes"`
+	UnitPrice       *float64   `json:"unit_price" validate:"omitempty,min=0"`
+	ReplacementCost *float64   `json:"replacement_cost" validate:"omitempty,min=0"`
+}
+
+// BookstockLocationRequest replaces the home and current location of a copy.
+// A shelf implies its branch; leaving the current location empty moves the
+// copy back home.
+type BookstockLocationRequest struct {
+	HomeBranchID    *uuid.UUID `json:"home_branch_id"`
+	HomeShelfID     *uuid.UUID `json:"home_shelf_id"`
+	CurrentBranchID *uuid.UUID `json:"current_branch_id"`
+	CurrentShelfID  *uuid.UUID `json:"current_shelf_id"`
+}
+
+// BookstockConditionRequest regrades a copy outside the loan flow.
+type BookstockConditionRequest struct {
+	Condition string `json:"condition" validate:"required,oneof=new good worn poor"`
+	Notes     string `json:"notes"`
+}
+
+// BookstockItemTypeRequest overrides the book's item type for one copy. An
+// empty type makes the copy follow its book again.
+type BookstockItemTypeRequest struct {
+	ItemType string `json:"item_type" validate:"omitempty,oneof=circulating reference in_library restricted"`
+}
+
+// BookstockFilter narrows copy listings. Branch and shelf match the current
+// location unless Location is "home".
+type BookstockFilter struct {
+	BookID    *uuid.UUID
+	Status    string
+	Condition string
+	BranchID  *uuid.UUID
+	ShelfID   *uuid.UUID
+	Location  string
 }
 
 type BookstockUpdateRequest struct {
 	Status string `json:"status" validate:"required,oneof=AVAILABLE BORROWED DAMAGED LOST"`
+	Reason string `json:"reason" validate:"max=500"`
 }
 
 type BookstockResponse struct {
-	Code       string        `json:"code"`
-	BookID     uuid.UUID     `json:"book_id"`
-	Book       *BookResponse `json:"book,omitempty"`
-	Status     string        `json:"status"`
-	BorrowedID *uuid.UUID    `json:"borrowed_id"`
-	BorrowedAt *time.Time    `json:"borrowed_at"`
+	Code                string        `json:"code"`
+	BookID              uuid.UUID     `json:"book_id"`
+	Book                *BookResponse `json:"book,omitempty"`
+	Status              string        `json:"status"`
+	ItemType            string        `json:"item_type,omitempty"`
+	Condition           string        `json:"condition"`
+	ConditionNotes      string        `json:"condition_notes"`
+	BorrowedID          *uuid.UUID    `json:"borrowed_id"`
+	BorrowedAt          *time.Time    `json:"borrowed_at"`
+	Home                *CopyLocation `json:"home_location,omitempty"`
+	Current             *CopyLocation `json:"current_location,omitempty"`
+	UnitPrice           *float64      `json:"unit_price"`
+	ReplacementCost     *float64      `json:"replacement_cost"`
+	PurchaseOrderLineID *uuid.UUID    `json:"purchase_order_line_id,omitempty"`
+	WithdrawnAt         *time.Time    `json:"withdrawn_at,omitempty"`
+	WithdrawnReason     string        `json:"withdrawn_reason,omitempty"`
+}
+
+// BookstockBulkCreateRequest creates Quantity copies of a book with
+// generated codes.
+type BookstockBulkCreateRequest struct {
+	BookID       uuid.UUID  `json:"book_id" validate:"required"`
+	Quantity     int        `json:"quantity" validate:"required,min=1,max=500"`
+	ItemType     string     `json:"item_type" validate:"omitempty,oneof=circulating reference in_library restricted"`
+	HomeBranchID *uuid.UUID `json:"home_branch_id"`
+	HomeShelfID  *uuid.UUID `json:"home_shelf_id"`
+}
+
+// BookstockBulkStatusRequest moves many copies to the same status. Copies on
+// loan must go through the return flow instead.
+type BookstockBulkStatusRequest struct {
+	Codes  []string `json:"codes" validate:"required,min=1,max=500,dive,required"`
+	Status string   `json:"status" validate:"required,oneof=AVAILABLE DAMAGED LOST"`
+	Reason string   `json:"reason" validate:"required,max=500"`
+}
+
+type BookstockBulkItem struct {
+	Code   string `json:"code"`
+	Result string `json:"result"` // created, updated, unchanged or failed
+	Error  string `json:"error,omitempty"`
+}
+
+// BookstockBulkReport lists the outcome per item. Applied is false when any
+// item failed, in which case nothing was written.
+type BookstockBulkReport struct {
+	Applied   bool                `json:"applied"`
+	Total     int                 `json:"total"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Items     []BookstockBulkItem `json:"items"`
+}
+
+type BookstockStatusLogResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	StockCode  string     `json:"stock_code"`
+	FromStatus string     `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	Reason     string     `json:"reason"`
+	ChangedBy  *uuid.UUID `json:"changed_by"`
+	CreatedAt  time.Time  `json:"created_at"`
 }
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BranchRequest struct {
+	Code    string `json:"code" validate:"required,max=20,alphanum"`
+	Name    string `json:"name" validate:"required,max=255"`
+	Address string `json:"address"`
+}
+
+type ShelfRequest struct {
+	Code        string `json:"code" validate:"required,max=50"`
+	Name        string `json:"name" validate:"max=255"`
+	Description string `json:"description"`
+}
+
+type BranchResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Code      string          `json:"code"`
+	Name      string          `json:"name"`
+	Address   string          `json:"address"`
+	Shelves   []ShelfResponse `json:"shelves,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type ShelfResponse struct {
+	ID          uuid.UUID `json:"id"`
+	BranchID    uuid.UUID `json:"branch_id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CopyLocation describes where a copy lives or currently is.
+type CopyLocation struct {
+	BranchID   *uuid.UUID `json:"branch_id"`
+	BranchCode string     `json:"branch_code,omitempty"`
+	BranchName string     `json:"branch_name,omitempty"`
+	ShelfID    *uuid.UUID `json:"shelf_id"`
+	ShelfCode  string     `json:"shelf_code,omitempty"`
+	ShelfName  string     `json:"shelf_name,omitempty"`
+}
+
+// BranchAvailability counts the copies of a book currently at a branch.
+// Copies without a location are grouped under a nil BranchID.
+type BranchAvailability struct {
+	BranchID   *uuid.UUID `json:"branch_id"`
+	BranchCode string     `json:"branch_code"`
+	BranchName string     `json:"branch_name"`
+	Total      int64      `json:"total"`
+	Available  int64      `json:"available"`
+}
@@ -22,10 +22,12 @@ type ChargeResponse struct {
 	ID                uuid.UUID                `json:"id"`
 	BookTransactionID uuid.UUID                `json:"book_transaction_id"`
 	BookTransaction   *BookTransactionResponse `json:"book_transaction,omitempty"`
+	Kind              string                   `json:"kind"`
 	DaysLate          int                      `json:"days_late"`
 	DailyLateFee      float64                  `json:"daily_late_fee"`
 	Total             float64                  `json:"total"`
 	UserID            uuid.UUID                `json:"user_id"`
 	User              *UserData                `json:"user,omitempty"`
+	PaidAt            *time.Time               `json:"paid_at"`
 	CreatedAt         time.Time                `json:"created_at"`
 }
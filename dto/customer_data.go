@@ -7,19 +7,72 @@ import (
 )
 
 type CustomerCreateRequest struct {
-	Code string `json:"code" validate:"required"`
-	Name string `json:"name" validate:"required"`
+	Code    string `json:"code" validate:"required"`
+	Name    string `json:"name" validate:"required"`
+	Email   string `json:"email" validate:"omitempty,email,max=255"`
+	Phone   string `json:"phone" validate:"omitempty,e164"`
+	Address string `json:"address" validate:"omitempty,max=500"`
+	// BirthDate is YYYY-MM-DD; it is needed to borrow restricted items.
+	BirthDate string `json:"birth_date" validate:"omitempty,datetime=2006-01-02"`
+	// MembershipType is a membership tier code; it defaults to standard.
+	MembershipType string `json:"membership_type" validate:"omitempty,max=20"`
+	// MembershipStartedAt defaults to today and MembershipExpiresAt to the
+	// configured membership length after it.
+	MembershipStartedAt string `json:"membership_started_at" validate:"omitempty,datetime=2006-01-02"`
+	MembershipExpiresAt string `json:"membership_expires_at" validate:"omitempty,datetime=2006-01-02"`
+	// NotificationChannel defaults to the first of email, sms and post the
+	// customer has contact details for.
+	NotificationChannel string `json:"notification_channel" validate:"omitempty,oneof=email sms post none"`
 }
 
+// CustomerUpdateRequest replaces the code and name; every other field left
+// empty keeps its stored value.
 type CustomerUpdateRequest struct {
-	Name string `json:"name" validate:"required"`
-	Code string `json:"code" validate:"required"`
+	Name                string `json:"name" validate:"required"`
+	Code                string `json:"code" validate:"required"`
+	Email               string `json:"email" validate:"omitempty,email,max=255"`
+	Phone               string `json:"phone" validate:"omitempty,e164"`
+	Address             string `json:"address" validate:"omitempty,max=500"`
+	BirthDate           string `json:"birth_date" validate:"omitempty,datetime=2006-01-02"`
+	MembershipType      string `json:"membership_type" validate:"omitempty,max=20"`
+	MembershipStartedAt string `json:"membership_started_at" validate:"omitempty,datetime=2006-01-02"`
+	MembershipExpiresAt string `json:"membership_expires_at" validate:"omitempty,datetime=2006-01-02"`
+	NotificationChannel string `json:"notification_channel" validate:"omitempty,oneof=email sms post none"`
+}
+
+// MembershipRenewRequest extends a membership by Months, or by the configured
+// membership length when zero. Renewal counts from the current expiry, or from
+// today once it has lapsed.
+type MembershipRenewRequest struct {
+	Months int `json:"months" validate:"omitempty,gte=1,lte=120"`
 }
 
 type CustomerResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Code      string    `json:"code"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                  uuid.UUID  `json:"id"`
+	Code                string     `json:"code"`
+	Name                string     `json:"name"`
+	Email               string     `json:"email,omitempty"`
+	Phone               string     `json:"phone,omitempty"`
+	Address             string     `json:"address,omitempty"`
+	BirthDate           string     `json:"birth_date,omitempty"`
+	MembershipType      string     `json:"membership_type"`
+	MembershipStartedAt string     `json:"membership_started_at,omitempty"`
+	MembershipExpiresAt string     `json:"membership_expires_at,omitempty"`
+	MembershipExpired   bool       `json:"membership_expired"`
+	NotificationChannel string     `json:"notification_channel"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	DeletedAt           *time.Time `json:"deleted_at,omitempty"`
+	// Suspended and the suspension history, newest first, are only filled
+	// in when a single customer is fetched.
+	Suspended   *bool                `json:"suspended,omitempty"`
+	Suspensions []SuspensionResponse `json:"suspensions,omitempty"`
+}
+
+// PatronClaimResponse carries a claim code for staff to hand to the customer.
+// It is shown once; only its hash is stored.
+type PatronClaimResponse struct {
+	CustomerCode string    `json:"customer_code"`
+	ClaimCode    string    `json:"claim_code"`
+	ExpiresAt    time.Time `json:"expires_at"`
 }
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DuplicateFilter struct {
+	// MinNameSimilarity is the lowest name similarity, from 0 to 1, reported
+	// on its own; contact matches are always reported.
+	MinNameSimilarity float64
+	Limit             int
+}
+
+type DuplicateCustomer struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	Phone     string    `json:"phone,omitempty"`
+	BirthDate string    `json:"birth_date,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DuplicateCandidate is a pair of customers that may be the same person.
+// Reasons lists what matched; Score ranks the pairs.
+type DuplicateCandidate struct {
+	Customer       DuplicateCustomer `json:"customer"`
+	Duplicate      DuplicateCustomer `json:"duplicate"`
+	Reasons        []string          `json:"reasons"`
+	NameSimilarity float64           `json:"name_similarity"`
+	Score          float64           `json:"score"`
+}
+
+type CustomerMergeRequest struct {
+	SurvivorID  uuid.UUID `json:"survivor_id" validate:"required"`
+	DuplicateID uuid.UUID `json:"duplicate_id" validate:"required"`
+}
+
+type CustomerMergeResponse struct {
+	ID             uuid.UUID         `json:"id"`
+	SurvivorID     uuid.UUID         `json:"survivor_id"`
+	DuplicateID    uuid.UUID         `json:"duplicate_id"`
+	DuplicateCode  string            `json:"duplicate_code"`
+	DuplicateName  string            `json:"duplicate_name"`
+	MergedBy       uuid.UUID         `json:"merged_by"`
+	Loans          int               `json:"loans"`
+	Charges        int               `json:"charges"`
+	Holds          int               `json:"holds"`
+	Suspensions    int               `json:"suspensions"`
+	Reviews        int               `json:"reviews"`
+	ReadingLists   int               `json:"reading_lists"`
+	AccountMoved   bool              `json:"account_moved"`
+	WishlistItems  int               `json:"wishlist_items"`
+	HoldsCancelled int               `json:"holds_cancelled"`
+	Survivor       *CustomerResponse `json:"survivor,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type DependentRecord struct {
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Detail string `json:"detail"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HoldCreateRequest places a hold on one edition (book_id) or on the first
+// available copy of any edition of a work (work_id).
+type HoldCreateRequest struct {
+	CustomerID uuid.UUID  `json:"customer_id" validate:"required"`
+	BookID     *uuid.UUID `json:"book_id" validate:"required_without=WorkID,excluded_with=WorkID"`
+	WorkID     *uuid.UUID `json:"work_id" validate:"required_without=BookID,excluded_with=BookID"`
+	// PickupBranchID, when set, lets a copy at another branch be
+	// transferred in for the hold.
+	PickupBranchID *uuid.UUID `json:"pickup_branch_id"`
+}
+
+type HoldResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	CustomerID     uuid.UUID  `json:"customer_id"`
+	BookID         *uuid.UUID `json:"book_id,omitempty"`
+	WorkID         *uuid.UUID `json:"work_id,omitempty"`
+	PickupBranchID *uuid.UUID `json:"pickup_branch_id,omitempty"`
+	TransferID     *uuid.UUID `json:"transfer_id,omitempty"`
+	Status         string     `json:"status"`
+	StockCode      *string    `json:"stock_code,omitempty"`
+	ReadyAt        *time.Time `json:"ready_at,omitempty"`
+	PickupBy       *time.Time `json:"pickup_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type HoldFilter struct {
+	CustomerID *uuid.UUID
+	BookID     *uuid.UUID
+	WorkID     *uuid.UUID
+	Status     string
+}
+
+// PatronHoldRequest is a hold placed by a customer for themselves.
+type PatronHoldRequest struct {
+	BookID         *uuid.UUID `json:"book_id" validate:"required_without=WorkID,excluded_with=WorkID"`
+	WorkID         *uuid.UUID `json:"work_id" validate:"required_without=BookID,excluded_with=BookID"`
+	PickupBranchID *uuid.UUID `json:"pickup_branch_id"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+type BarcodeOptions struct {
+	Type   string // code128 or qr
+	Format string // png or svg
+	Width  int    // Pixels, PNG only
+	Height int    // Pixels, PNG only
+}
+
+// LabelSheetRequest prints one label per stock code on A4 pages laid out in
+// a Columns x Rows grid.
+type LabelSheetRequest struct {
+	Codes   []string `json:"codes" validate:"required,min=1,max=1000,dive,required"`
+	Type    string   `json:"type" validate:"omitempty,oneof=code128 qr"`
+	Columns int      `json:"columns" validate:"omitempty,min=1,max=6"`
+	Rows    int      `json:"rows" validate:"omitempty,min=1,max=20"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "github.com/google/uuid"
+
+type MARCImportOptions struct {
+	Format string // binary or xml
+	DryRun bool
+}
+
+type MARCFieldMapping struct {
+	Tag   string `json:"tag"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+type MARCRecordReport struct {
+	Index    int                `json:"index"`
+	Status   string             `json:"status"`
+	Title    string             `json:"title"`
+	BookID   *uuid.UUID         `json:"book_id,omitempty"`
+	Mapped   []MARCFieldMapping `json:"mapped"`
+	Unmapped []string           `json:"unmapped"`
+	Errors   []string           `json:"errors,omitempty"`
+}
+
+type MARCImportReport struct {
+	DryRun   bool               `json:"dry_run"`
+	Format   string             `json:"format"`
+	Total    int                `json:"total"`
+	Imported int                `json:"imported"`
+	Records  []MARCRecordReport `json:"records"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MembershipTierRequest struct {
+	Code           string  `json:"code" validate:"required,max=20"`
+	Name           string  `json:"name" validate:"required,max=255"`
+	LoanLimit      int     `json:"loan_limit" validate:"gte=0"`
+	LoanPeriodDays int     `json:"loan_period_days" validate:"required,gte=1,lte=365"`
+	RenewalLimit   int     `json:"renewal_limit" validate:"gte=0"`
+	DailyFine      float64 `json:"daily_fine" validate:"gte=0"`
+	HoldLimit      int     `json:"hold_limit" validate:"gte=0"`
+}
+
+type MembershipTierResponse struct {
+	ID             uuid.UUID `json:"id"`
+	Code           string    `json:"code"`
+	Name           string    `json:"name"`
+	LoanLimit      int       `json:"loan_limit"`
+	LoanPeriodDays int       `json:"loan_period_days"`
+	RenewalLimit   int       `json:"renewal_limit"`
+	DailyFine      float64   `json:"daily_fine"`
+	HoldLimit      int       `json:"hold_limit"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,73 @@
+package dto
+
+import "encoding/xml"
+
+type OPDSFeed struct {
+	XMLName      xml.Name    `xml:"feed"`
+	Xmlns        string      `xml:"xmlns,attr"`
+	XmlnsDC      string      `xml:"xmlns:dc,attr"`
+	XmlnsOPDS    string      `xml:"xmlns:opds,attr"`
+	XmlnsOS      string      `xml:"xmlns:opensearch,attr"`
+	ID           string      `xml:"id"`
+	Title        string      `xml:"title"`
+	Updated      string      `xml:"updated"`
+	Author       *OPDSAuthor `xml:"author,omitempty"`
+	TotalResults int64       `xml:"opensearch:totalResults,omitempty"`
+	ItemsPerPage int         `xml:"opensearch:itemsPerPage,omitempty"`
+	Links        []OPDSLink  `xml:"link"`
+	Entries      []OPDSEntry `xml:"entry"`
+}
+
+type OPDSAuthor struct {
+	Name string `xml:"name"`
+}
+
+type OPDSLink struct {
+	Rel   string `xml:"rel,attr,omitempty"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+type OPDSCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr,omitempty"`
+}
+
+type OPDSContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type OPDSEntry struct {
+	XMLName    xml.Name       `xml:"entry"`
+	Xmlns      string         `xml:"xmlns,attr,omitempty"`
+	XmlnsDC    string         `xml:"xmlns:dc,attr,omitempty"`
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Authors    []OPDSAuthor   `xml:"author"`
+	Identifier string         `xml:"dc:identifier,omitempty"`
+	Language   string         `xml:"dc:language,omitempty"`
+	Publisher  string         `xml:"dc:publisher,omitempty"`
+	Issued     string         `xml:"dc:issued,omitempty"`
+	Categories []OPDSCategory `xml:"category"`
+	Summary    string         `xml:"summary,omitempty"`
+	Content    *OPDSContent   `xml:"content,omitempty"`
+	Links      []OPDSLink     `xml:"link"`
+}
+
+type OpenSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Template string `xml:"template,attr"`
+}
+
+type OpenSearchDescription struct {
+	XMLName        xml.Name      `xml:"OpenSearchDescription"`
+	Xmlns          string        `xml:"xmlns,attr"`
+	ShortName      string        `xml:"ShortName"`
+	Description    string        `xml:"Description"`
+	InputEncoding  string        `xml:"InputEncoding"`
+	OutputEncoding string        `xml:"OutputEncoding"`
+	URL            OpenSearchURL `xml:"Url"`
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReadingListCreateRequest struct {
+	Title        string     `json:"title" validate:"required,max=255"`
+	Description  string     `json:"description" validate:"omitempty"`
+	Kind         string     `json:"kind" validate:"required,oneof=CURATED WISHLIST"`
+	Visibility   string     `json:"visibility" validate:"required,oneof=public staff private"`
+	CustomerID   *uuid.UUID `json:"customer_id" validate:"required_if=Kind WISHLIST,excluded_if=Kind CURATED"`
+	CoverID      *uuid.UUID `json:"cover_id" validate:"omitempty"`
+	PublishFrom  *time.Time `json:"publish_from" validate:"omitempty"`
+	PublishUntil *time.Time `json:"publish_until" validate:"omitempty"`
+}
+
+type ReadingListUpdateRequest struct {
+	Title        string     `json:"title" validate:"omitempty,max=255"`
+	Description  string     `json:"description" validate:"omitempty"`
+	Visibility   string     `json:"visibility" validate:"omitempty,oneof=public staff private"`
+	CoverID      *uuid.UUID `json:"cover_id" validate:"omitempty"`
+	PublishFrom  *time.Time `json:"publish_from" validate:"omitempty"`
+	PublishUntil *time.Time `json:"publish_until" validate:"omitempty"`
+}
+
+// ReadingListItemRequest adds a book to a list. Without a position the book
+// is appended; with one it is inserted there and later items move down.
+type ReadingListItemRequest struct {
+	BookID   uuid.UUID `json:"book_id" validate:"required"`
+	Position int       `json:"position" validate:"omitempty,min=1"`
+	Note     string    `json:"note" validate:"omitempty"`
+}
+
+type ReadingListItemUpdateRequest struct {
+	Note string `json:"note" validate:"omitempty"`
+}
+
+// ReadingListReorderRequest lists every book shown on the list in its new order.
+type ReadingListReorderRequest struct {
+	BookIDs []uuid.UUID `json:"book_ids" validate:"required,min=1"`
+}
+
+type ReadingListResponse struct {
+	ID           uuid.UUID                 `json:"id"`
+	Title        string                    `json:"title"`
+	Description  string                    `json:"description"`
+	Kind         string                    `json:"kind"`
+	Visibility   string                    `json:"visibility"`
+	CustomerID   *uuid.UUID                `json:"customer_id,omitempty"`
+	Cover        *MediaResponse            `json:"cover,omitempty"`
+	PublishFrom  *time.Time                `json:"publish_from"`
+	PublishUntil *time.Time                `json:"publish_until"`
+	ItemCount    int                       `json:"item_count"`
+	Items        []ReadingListItemResponse `json:"items,omitempty"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	UpdatedAt    time.Time                 `json:"updated_at"`
+}
+
+type ReadingListItemResponse struct {
+	Position int         `json:"position"`
+	Note     string      `json:"note"`
+	Book     BookSummary `json:"book"`
+}
+
+type ReadingListFilter struct {
+	Kind       string
+	Visibility string
+	CustomerID *uuid.UUID
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type RecommendationResponse struct {
+	Book       BookSummary `json:"book"`
+	Score      int64       `json:"score"`
+	ComputedAt time.Time   `json:"computed_at"`
+}
+
+type RecommendationRunReport struct {
+	BookPairs           int64     `json:"book_pairs"`
+	CustomerSuggestions int64     `json:"customer_suggestions"`
+	ComputedAt          time.Time `json:"computed_at"`
+	Duration            string    `json:"duration"`
+}
@@ -24,6 +24,7 @@ type PaginatedResponseData[T any] struct {
 	PerPage    int       `json:"per_page,omitempty"`
 	TotalPages int       `json:"total_pages,omitempty"`
 	TotalItems int64     `json:"total_items,omitempty"`
+	Facets     any       `json:"facets,omitempty"`
 }
 
 func NewResponseMessage(message any) ResponseMessage {
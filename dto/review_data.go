@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReviewCreateRequest struct {
+	BookID     uuid.UUID `json:"book_id" validate:"required"`
+	CustomerID uuid.UUID `json:"customer_id" validate:"required"`
+	Rating     int       `json:"rating" validate:"required,min=1,max=5"`
+	Body       string    `json:"body" validate:"omitempty,max=5000"`
+}
+
+// PatronReviewRequest is a review a customer writes for themselves.
+type PatronReviewRequest struct {
+	BookID uuid.UUID `json:"book_id" validate:"required"`
+	Rating int       `json:"rating" validate:"required,min=1,max=5"`
+	Body   string    `json:"body" validate:"omitempty,max=5000"`
+}
+
+type ReviewUpdateRequest struct {
+	Rating int    `json:"rating" validate:"omitempty,min=1,max=5"`
+	Body   string `json:"body" validate:"omitempty,max=5000"`
+}
+
+type ReviewModerationRequest struct {
+	Status string `json:"status" validate:"required,oneof=APPROVED REJECTED"`
+	Note   string `json:"note" validate:"omitempty"`
+}
+
+type ReviewResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	BookID         uuid.UUID  `json:"book_id"`
+	CustomerID     uuid.UUID  `json:"customer_id"`
+	CustomerName   string     `json:"customer_name,omitempty"`
+	Rating         int        `json:"rating"`
+	Body           string     `json:"body"`
+	Status         string     `json:"status"`
+	ModerationNote string     `json:"moderation_note,omitempty"`
+	ModeratedBy    *uuid.UUID `json:"moderated_by,omitempty"`
+	ModeratedAt    *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type ReviewFilter struct {
+	BookID     *uuid.UUID
+	CustomerID *uuid.UUID
+	Status     string
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StocktakeCreateRequest scopes a session by branch, shelf, code prefix or
+// an inclusive code range. At least one is required.
+type StocktakeCreateRequest struct {
+	Name     string     `json:"name" validate:"required,max=255"`
+	BranchID *uuid.UUID `json:"branch_id"`
+	ShelfID  *uuid.UUID `json:"shelf_id"`
+	Prefix   string     `json:"prefix" validate:"required_without_all=CodeFrom CodeTo BranchID ShelfID,max=50"`
+	CodeFrom string     `json:"code_from" validate:"required_with=CodeTo,max=50"`
+	CodeTo   string     `json:"code_to" validate:"required_with=CodeFrom,max=50"`
+}
+
+type StocktakeScanRequest struct {
+	Codes []string `json:"codes" validate:"required,min=1,max=5000,dive,required,max=50"`
+}
+
+type StocktakeScanResult struct {
+	Received int   `json:"received"`
+	Added    int64 `json:"added"`
+	Scanned  int64 `json:"scanned"`
+	// Rejected lists codes whose check digit is wrong; they were not recorded.
+	Rejected []string `json:"rejected,omitempty"`
+}
+
+type StocktakeResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	BranchID   *uuid.UUID `json:"branch_id,omitempty"`
+	ShelfID    *uuid.UUID `json:"shelf_id,omitempty"`
+	Prefix     string     `json:"prefix,omitempty"`
+	CodeFrom   string     `json:"code_from,omitempty"`
+	CodeTo     string     `json:"code_to,omitempty"`
+	Status     string     `json:"status"`
+	OpenedBy   *uuid.UUID `json:"opened_by"`
+	ApprovedBy *uuid.UUID `json:"approved_by,omitempty"`
+	ClosedAt   *time.Time `json:"closed_at,omitempty"`
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type StocktakeReportItem struct {
+	Code   string    `json:"code"`
+	BookID uuid.UUID `json:"book_id"`
+	Title  string    `json:"title"`
+	Status string    `json:"status"`
+}
+
+// StocktakeReport lists the discrepancies of a session. Missing copies are
+// the ones approval marks LOST.
+type StocktakeReport struct {
+	Stocktake  StocktakeResponse     `json:"stocktake"`
+	Expected   int64                 `json:"expected"`
+	Scanned    int64                 `json:"scanned"`
+	Missing    []StocktakeReportItem `json:"missing"`
+	Borrowed   []StocktakeReportItem `json:"borrowed"`
+	Lost       []StocktakeReportItem `json:"lost"`
+	Unknown    []string              `json:"unknown"`
+	OutOfScope []string              `json:"out_of_scope"`
+}
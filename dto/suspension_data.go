@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuspensionCreateRequest struct {
+	Reason string `json:"reason" validate:"required,oneof=unpaid_fines behaviour lost_card other"`
+	Note   string `json:"note" validate:"max=1000"`
+	// ExpiresAt lifts the suspension automatically; without it the
+	// suspension lasts until staff lift it.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type SuspensionResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	CustomerID  uuid.UUID  `json:"customer_id"`
+	Reason      string     `json:"reason"`
+	Note        string     `json:"note,omitempty"`
+	Status      string     `json:"status"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	SuspendedBy uuid.UUID  `json:"suspended_by"`
+	LiftedAt    *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy    *uuid.UUID `json:"lifted_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
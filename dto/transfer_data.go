@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransferCreateRequest struct {
+	StockCode  string     `json:"stock_code" validate:"required,max=50"`
+	ToBranchID uuid.UUID  `json:"to_branch_id" validate:"required"`
+	ToShelfID  *uuid.UUID `json:"to_shelf_id"`
+	Note       string     `json:"note" validate:"max=1000"`
+}
+
+type TransferResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	StockCode      string     `json:"stock_code"`
+	BookID         uuid.UUID  `json:"book_id"`
+	Title          string     `json:"title,omitempty"`
+	FromBranchID   uuid.UUID  `json:"from_branch_id"`
+	FromBranchCode string     `json:"from_branch_code,omitempty"`
+	ToBranchID     uuid.UUID  `json:"to_branch_id"`
+	ToBranchCode   string     `json:"to_branch_code,omitempty"`
+	ToShelfID      *uuid.UUID `json:"to_shelf_id"`
+	Status         string     `json:"status"`
+	Reason         string     `json:"reason"`
+	HoldID         *uuid.UUID `json:"hold_id,omitempty"`
+	Note           string     `json:"note,omitempty"`
+	RequestedBy    *uuid.UUID `json:"requested_by"`
+	ShippedAt      *time.Time `json:"shipped_at"`
+	ReceivedAt     *time.Time `json:"received_at"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TransferQueue is the work list of a branch desk: copies on their way in
+// to be received, and requested copies to pull and ship.
+type TransferQueue struct {
+	BranchID uuid.UUID          `json:"branch_id"`
+	Incoming []TransferResponse `json:"incoming"`
+	Outgoing []TransferResponse `json:"outgoing"`
+}
+
+// TransferFilter narrows transfer listings. Direction picks whether
+// BranchID matches the destination (incoming) or origin (outgoing); empty
+// matches either.
+type TransferFilter struct {
+	BranchID  *uuid.UUID
+	Direction string
+	Status    string
+	StockCode string
+	HoldID    *uuid.UUID
+}
@@ -5,10 +5,23 @@ type UserData struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
 	Role  string `json:"role"`
+	// CustomerID is set for patron accounts only.
+	CustomerID string `json:"customer_id,omitempty"`
 }
 
+// RegisterReq creates a staff account. Role defaults to STAFF.
 type RegisterReq struct {
 	Name     string `json:"name" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
+	Role     string `json:"role" validate:"omitempty,oneof=ADMIN STAFF"`
+}
+
+// PatronRegisterReq claims a customer record for self-service. ClaimCode is
+// the one-time code staff issued for that customer.
+type PatronRegisterReq struct {
+	CustomerCode string `json:"customer_code" validate:"required"`
+	ClaimCode    string `json:"claim_code" validate:"required"`
+	Email        string `json:"email" validate:"required,email"`
+	Password     string `json:"password" validate:"required,min=6"`
 }
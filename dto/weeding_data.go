@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WeedingFilter selects candidates by location. Copies count as unused when
+// they have not been borrowed for Years years.
+type WeedingFilter struct {
+	Years    int
+	BranchID *uuid.UUID
+	ShelfID  *uuid.UUID
+	Limit    int
+}
+
+type WeedingCandidate struct {
+	Code           string     `json:"code"`
+	BookID         uuid.UUID  `json:"book_id"`
+	Title          string     `json:"title"`
+	Status         string     `json:"status"`
+	Condition      string     `json:"condition"`
+	BranchID       *uuid.UUID `json:"branch_id"`
+	ShelfID        *uuid.UUID `json:"shelf_id"`
+	LoanCount      int64      `json:"loan_count"`
+	LastBorrowedAt *time.Time `json:"last_borrowed_at"`
+	AddedAt        *time.Time `json:"added_at"`
+	Reasons        []string   `json:"reasons" gorm:"-"`
+}
+
+type DeaccessionCreateRequest struct {
+	Codes  []string `json:"codes" validate:"required,min=1,max=500,dive,required,max=50"`
+	Reason string   `json:"reason" validate:"required,max=500"`
+}
+
+type DeaccessionItemResponse struct {
+	StockCode  string    `json:"stock_code"`
+	BookID     uuid.UUID `json:"book_id"`
+	Title      string    `json:"title,omitempty"`
+	FromStatus string    `json:"from_status"`
+	Status     string    `json:"status"`
+	Withdrawn  bool      `json:"withdrawn"`
+}
+
+type DeaccessionResponse struct {
+	ID          uuid.UUID                 `json:"id"`
+	Reason      string                    `json:"reason"`
+	Status      string                    `json:"status"`
+	RequestedBy *uuid.UUID                `json:"requested_by"`
+	ReviewedBy  *uuid.UUID                `json:"reviewed_by"`
+	ReviewedAt  *time.Time                `json:"reviewed_at"`
+	Items       []DeaccessionItemResponse `json:"items"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+}
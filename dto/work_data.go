@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WorkCreateRequest struct {
+	Title       string `json:"title" validate:"required,max=255"`
+	Author      string `json:"author" validate:"omitempty,max=255"`
+	Description string `json:"description" validate:"omitempty"`
+}
+
+type WorkUpdateRequest struct {
+	Title       string `json:"title" validate:"omitempty,max=255"`
+	Author      string `json:"author" validate:"omitempty,max=255"`
+	Description string `json:"description" validate:"omitempty"`
+}
+
+type WorkResponse struct {
+	ID           uuid.UUID     `json:"id"`
+	Title        string        `json:"title"`
+	Author       string        `json:"author"`
+	Description  string        `json:"description"`
+	EditionCount int64         `json:"edition_count"`
+	Editions     []BookSummary `json:"editions,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+type SeriesCreateRequest struct {
+	Title       string `json:"title" validate:"required,max=255"`
+	Description string `json:"description" validate:"omitempty"`
+}
+
+type SeriesUpdateRequest struct {
+	Title       string `json:"title" validate:"omitempty,max=255"`
+	Description string `json:"description" validate:"omitempty"`
+}
+
+type SeriesResponse struct {
+	ID          uuid.UUID     `json:"id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Books       []BookSummary `json:"books,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// BookSummary is the short form of a book listed inside a work or series.
+type BookSummary struct {
+	ID              uuid.UUID `json:"id"`
+	Title           string    `json:"title"`
+	Author          string    `json:"author"`
+	ISBN            string    `json:"isbn"`
+	Edition         string    `json:"edition"`
+	Language        string    `json:"language"`
+	Format          string    `json:"format"`
+	PublicationYear int       `json:"publication_year"`
+	SeriesVolume    *int      `json:"series_volume,omitempty"`
+}
@@ -0,0 +1,471 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type acquisitionApi struct {
+	acquisitionService domain.AcquisitionService
+}
+
+func NewAcquisitionApi(app *fiber.App, authHandler fiber.Handler, acquisitionService domain.AcquisitionService) {
+	aa := acquisitionApi{
+		acquisitionService: acquisitionService,
+	}
+
+	staffOnly := middleware.RoleMiddleware(constants.RoleAdmin, constants.RoleStaff)
+	adminOnly := middleware.RoleMiddleware(constants.RoleAdmin)
+
+	vendorGroup := app.Group("/v1/vendors")
+
+	vendorGroup.Get("/", authHandler, staffOnly, aa.getAllVendors)
+	vendorGroup.Get("/:id", authHandler, staffOnly, aa.getVendorByID)
+	vendorGroup.Post("/", authHandler, staffOnly, aa.createVendor)
+	vendorGroup.Put("/:id", authHandler, staffOnly, aa.updateVendor)
+	vendorGroup.Delete("/:id", authHandler, staffOnly, aa.deleteVendor)
+
+	fundGroup := app.Group("/v1/funds")
+
+	fundGroup.Get("/", authHandler, staffOnly, aa.getAllFunds)
+	fundGroup.Get("/report", authHandler, staffOnly, aa.getFundReport)
+	fundGroup.Get("/:id", authHandler, staffOnly, aa.getFundByID)
+	fundGroup.Post("/", authHandler, adminOnly, aa.createFund)
+	fundGroup.Put("/:id", authHandler, adminOnly, aa.updateFund)
+	fundGroup.Delete("/:id", authHandler, adminOnly, aa.deleteFund)
+
+	orderGroup := app.Group("/v1/purchase-orders")
+
+	orderGroup.Get("/", authHandler, staffOnly, aa.getAllPurchaseOrders)
+	orderGroup.Get("/:id", authHandler, staffOnly, aa.getPurchaseOrderByID)
+	orderGroup.Post("/", authHandler, staffOnly, aa.createPurchaseOrder)
+	orderGroup.Put("/:id", authHandler, staffOnly, aa.updatePurchaseOrder)
+	orderGroup.Delete("/:id", authHandler, staffOnly, aa.deletePurchaseOrder)
+	orderGroup.Post("/:id/place", authHandler, staffOnly, aa.placePurchaseOrder)
+	orderGroup.Post("/:id/cancel", authHandler, staffOnly, aa.cancelPurchaseOrder)
+	orderGroup.Post("/:id/receive", authHandler, staffOnly, aa.receivePurchaseOrder)
+}
+
+func (aa *acquisitionApi) getAllVendors(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	vendors, err := aa.acquisitionService.GetVendors(c, ctx.Query("search"))
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(vendors))
+}
+
+func (aa *acquisitionApi) getVendorByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	vendor, err := aa.acquisitionService.GetVendorByID(c, id)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(vendor))
+}
+
+func (aa *acquisitionApi) createVendor(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.VendorRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	vendor, err := aa.acquisitionService.CreateVendor(c, req)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(vendor))
+}
+
+func (aa *acquisitionApi) updateVendor(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.VendorRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	vendor, err := aa.acquisitionService.UpdateVendor(c, id, req)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(vendor))
+}
+
+func (aa *acquisitionApi) deleteVendor(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := aa.acquisitionService.DeleteVendor(c, id); err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Vendor deleted successfully"))
+}
+
+func (aa *acquisitionApi) getAllFunds(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	fiscalYear, err := parseFiscalYear(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	funds, err := aa.acquisitionService.GetFunds(c, fiscalYear)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(funds))
+}
+
+func (aa *acquisitionApi) getFundReport(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	fiscalYear, err := parseFiscalYear(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	report, err := aa.acquisitionService.GetFundReport(c, fiscalYear)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(report))
+}
+
+func (aa *acquisitionApi) getFundByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	fund, err := aa.acquisitionService.GetFundByID(c, id)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(fund))
+}
+
+func (aa *acquisitionApi) createFund(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.FundRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	fund, err := aa.acquisitionService.CreateFund(c, req)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(fund))
+}
+
+func (aa *acquisitionApi) updateFund(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.FundRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	fund, err := aa.acquisitionService.UpdateFund(c, id, req)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(fund))
+}
+
+func (aa *acquisitionApi) deleteFund(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := aa.acquisitionService.DeleteFund(c, id); err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Fund deleted successfully"))
+}
+
+func (aa *acquisitionApi) getAllPurchaseOrders(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	filter := dto.PurchaseOrderFilter{
+		Status: ctx.Query("status"),
+	}
+
+	if ctx.Query("vendor_id") != "" {
+		vendorID, err := uuid.Parse(ctx.Query("vendor_id"))
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid vendor_id format"))
+		}
+		filter.VendorID = &vendorID
+	}
+
+	orders, err := aa.acquisitionService.GetPurchaseOrders(c, filter)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(orders))
+}
+
+func (aa *acquisitionApi) getPurchaseOrderByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	order, err := aa.acquisitionService.GetPurchaseOrderByID(c, id)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(order))
+}
+
+func (aa *acquisitionApi) createPurchaseOrder(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	var req dto.PurchaseOrderRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	order, err := aa.acquisitionService.CreatePurchaseOrder(c, userID, req)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(order))
+}
+
+func (aa *acquisitionApi) updatePurchaseOrder(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.PurchaseOrderRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	order, err := aa.acquisitionService.UpdatePurchaseOrder(c, id, req)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(order))
+}
+
+func (aa *acquisitionApi) deletePurchaseOrder(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := aa.acquisitionService.DeletePurchaseOrder(c, id); err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Purchase order deleted successfully"))
+}
+
+func (aa *acquisitionApi) placePurchaseOrder(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	order, err := aa.acquisitionService.PlacePurchaseOrder(c, id)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(order))
+}
+
+func (aa *acquisitionApi) cancelPurchaseOrder(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	order, err := aa.acquisitionService.CancelPurchaseOrder(c, id)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(order))
+}
+
+func (aa *acquisitionApi) receivePurchaseOrder(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.PurchaseOrderReceiveRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	receipt, err := aa.acquisitionService.ReceivePurchaseOrder(c, id, req)
+	if err != nil {
+		return sendAcquisitionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(receipt))
+}
+
+// parseFiscalYear reads the optional fiscal_year query, returning zero when
+// it is absent.
+func parseFiscalYear(ctx *fiber.Ctx) (int, error) {
+	if ctx.Query("fiscal_year") == "" {
+		return 0, nil
+	}
+	fiscalYear, err := strconv.Atoi(ctx.Query("fiscal_year"))
+	if err != nil {
+		return 0, errors.New("Invalid fiscal_year value")
+	}
+	return fiscalYear, nil
+}
+
+func sendAcquisitionError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, constants.ErrVendorNotFound), errors.Is(err, constants.ErrFundNotFound),
+		errors.Is(err, constants.ErrPurchaseOrderNotFound), errors.Is(err, constants.ErrPurchaseOrderLineNotFound),
+		errors.Is(err, constants.ErrBookNotFound), errors.Is(err, constants.ErrBranchNotFound),
+		errors.Is(err, constants.ErrShelfNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrShelfNotInBranch), errors.Is(err, constants.ErrPurchaseOrderOverflow),
+		errors.Is(err, constants.ErrStockCodeBranch):
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrVendorInUse), errors.Is(err, constants.ErrFundExists),
+		errors.Is(err, constants.ErrFundInUse), errors.Is(err, constants.ErrPurchaseOrderExists),
+		errors.Is(err, constants.ErrPurchaseOrderNotDraft), errors.Is(err, constants.ErrPurchaseOrderNotOpen):
+		return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
@@ -6,6 +6,7 @@ import (
 	"go-rest-api/domain"
 	"go-rest-api/dto"
 	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
 	"go-rest-api/internal/utils"
 	"net/http"
 	"time"
@@ -24,13 +25,30 @@ func NewAuth(app *fiber.App, authHandler fiber.Handler,
 		authService: authService,
 	}
 
+	adminOnly := middleware.RoleMiddleware(constants.RoleAdmin)
+
 	authenticateGroup := app.Group("/v1/authenticate")
 
 	authenticateGroup.Post("/", ha.authenticate)
-	authenticateGroup.Post("/register", ha.register)
+	authenticateGroup.Post("/register", ha.bootstrap, authHandler, adminOnly, ha.register)
+	authenticateGroup.Post("/register/patron", ha.registerPatron)
 	authenticateGroup.Post("/validate", authHandler, ha.authenticateValidate)
 }
 
+// bootstrap lets the first account register without a token so a fresh
+// install can create its administrator. Once any account exists only admins
+// register staff.
+func (a authApi) bootstrap(ctx *fiber.Ctx) error {
+	hasUsers, err := a.authService.HasUsers(ctx.Context())
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage("Internal server error"))
+	}
+	if !hasUsers {
+		return a.register(ctx)
+	}
+	return ctx.Next()
+}
+
 func (a authApi) register(ctx *fiber.Ctx) error {
 	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
 	defer cancel()
@@ -56,6 +74,36 @@ func (a authApi) register(ctx *fiber.Ctx) error {
 	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData[dto.UserData](res))
 }
 
+func (a authApi) registerPatron(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.PatronRegisterReq
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(dto.NewResponseMessage("Invalid request format"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	res, err := a.authService.RegisterPatron(c, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrPatronClaimMismatch):
+			return ctx.Status(http.StatusUnprocessableEntity).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrCustomerAccountExists):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrEmailAlreadyExists):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage("Email is already registered"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage("Internal server error"))
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData[dto.UserData](res))
+}
+
 func (a authApi) authenticate(ctx *fiber.Ctx) error {
 	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
 	defer cancel()
@@ -2,8 +2,11 @@ package api
 
 import (
 	"context"
+	"errors"
 	"go-rest-api/domain"
 	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
 	"go-rest-api/internal/utils"
 	"net/http"
 	"strconv"
@@ -11,6 +14,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type bookApi struct {
@@ -25,11 +29,14 @@ func NewBookApi(app *fiber.App, authHandler fiber.Handler, bookService domain.Bo
 	bookGroup := app.Group("/v1/books")
 
 	bookGroup.Get("/", authHandler, ba.getAllBooks)
+	bookGroup.Get("/trash", authHandler, ba.getTrashedBooks)
 	bookGroup.Get("/:id", authHandler, ba.getBookByID)
 	bookGroup.Post("/", authHandler, ba.createBook)
 	bookGroup.Put("/:id", authHandler, ba.updateBook)
 	bookGroup.Delete("/:id", authHandler, ba.deleteBook)
 	bookGroup.Delete("/cover/:id", authHandler, ba.deleteBookCover)
+	bookGroup.Post("/:id/restore", authHandler, ba.restoreBook)
+	bookGroup.Delete("/:id/purge", authHandler, middleware.RoleMiddleware(constants.RoleAdmin), ba.purgeBook)
 }
 
 func (ba *bookApi) getAllBooks(ctx *fiber.Ctx) error {
@@ -38,22 +45,87 @@ func (ba *bookApi) getAllBooks(ctx *fiber.Ctx) error {
 
 	page, _ := strconv.Atoi(ctx.Query("page", "1"))
 	perPage, _ := strconv.Atoi(ctx.Query("perPage", "10"))
-	search := ctx.Query("search", "")
-	cover_id := uuid.Nil
+
+	filter, err := parseBookFilter(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	books, err := ba.bookService.GetBooks(c, page, perPage, filter)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	res := dto.NewPaginatedResponseData(books.Data, books.Page, books.PerPage, books.TotalPages, books.TotalItems)
+	res.Facets = books.Facets
+
+	return ctx.Status(http.StatusOK).JSON(res)
+}
+
+func parseBookFilter(ctx *fiber.Ctx) (dto.BookFilter, error) {
+	filter := dto.BookFilter{
+		Search:    ctx.Query("search", ""),
+		Category:  ctx.Query("category"),
+		Author:    ctx.Query("author"),
+		Publisher: ctx.Query("publisher"),
+		Language:  ctx.Query("language"),
+		Collapse:  ctx.Query("collapse"),
+		Sort:      ctx.Query("sort"),
+	}
+
+	if filter.Collapse != "" && filter.Collapse != constants.BookCollapseWork {
+		return filter, errors.New("Invalid collapse value, use work")
+	}
+
+	if ctx.Query("work_id") != "" {
+		workID, err := uuid.Parse(ctx.Query("work_id"))
+		if err != nil {
+			return filter, errors.New("Invalid work_id format")
+		}
+		filter.WorkID = &workID
+	}
+
+	if ctx.Query("series_id") != "" {
+		seriesID, err := uuid.Parse(ctx.Query("series_id"))
+		if err != nil {
+			return filter, errors.New("Invalid series_id format")
+		}
+		filter.SeriesID = &seriesID
+	}
+
 	if ctx.Query("cover_id") != "" {
-		var err error
-		cover_id, err = uuid.Parse(ctx.Query("cover_id"))
+		coverID, err := uuid.Parse(ctx.Query("cover_id"))
 		if err != nil {
-			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+			return filter, errors.New("Invalid ID format")
 		}
+		filter.CoverID = &coverID
 	}
 
-	books, err := ba.bookService.GetBooks(c, page, perPage, search, &cover_id)
-	if err != nil {
-		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	if ctx.Query("available") != "" {
+		available, err := strconv.ParseBool(ctx.Query("available"))
+		if err != nil {
+			return filter, errors.New("Invalid available value, use true or false")
+		}
+		filter.Available = &available
+	}
+
+	if ctx.Query("year_from") != "" {
+		yearFrom, err := strconv.Atoi(ctx.Query("year_from"))
+		if err != nil {
+			return filter, errors.New("Invalid year_from value")
+		}
+		filter.YearFrom = yearFrom
+	}
+
+	if ctx.Query("year_to") != "" {
+		yearTo, err := strconv.Atoi(ctx.Query("year_to"))
+		if err != nil {
+			return filter, errors.New("Invalid year_to value")
+		}
+		filter.YearTo = yearTo
 	}
 
-	return ctx.Status(http.StatusOK).JSON(dto.NewPaginatedResponseData(books.Data, books.Page, books.PerPage, books.TotalPages, books.TotalItems))
+	return filter, nil
 }
 
 func (ba *bookApi) getBookByID(ctx *fiber.Ctx) error {
@@ -109,6 +181,11 @@ func (ba *bookApi) updateBook(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
 	}
 
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
 	book, err := ba.bookService.UpdateBook(c, id, req)
 	if err != nil {
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
@@ -127,6 +204,9 @@ func (ba *bookApi) deleteBook(ctx *fiber.Ctx) error {
 	}
 
 	if err := ba.bookService.DeleteBook(c, id); err != nil {
+		if handled, err := sendDeleteBlocked(ctx, err); handled {
+			return err
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage("Failed to delete book"))
 	}
 
@@ -148,3 +228,57 @@ func (ba *bookApi) deleteBookCover(ctx *fiber.Ctx) error {
 
 	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Book cover deleted successfully"))
 }
+
+func (ba *bookApi) getTrashedBooks(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	books, err := ba.bookService.GetTrashedBooks(c)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(books))
+}
+
+func (ba *bookApi) restoreBook(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	book, err := ba.bookService.RestoreBook(c, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Book not found in trash"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(book))
+}
+
+func (ba *bookApi) purgeBook(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := ba.bookService.PurgeBook(c, id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Book not found in trash"))
+		case errors.Is(err, constants.ErrRetentionNotElapsed), errors.Is(err, constants.ErrHasDependents):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage(constants.MsgPurgeSuccess))
+}
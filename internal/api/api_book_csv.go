@@ -0,0 +1,116 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/config"
+	"go-rest-api/internal/constants"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BookImportPath reads its upload as a stream and is exempt from BODY_LIMIT.
+const BookImportPath = "/v1/books/import"
+
+type bookCSVApi struct {
+	bookCSVService domain.BookCSVService
+	cnf            *config.Config
+}
+
+// NewBookCSVApi must be registered before NewBookApi, otherwise
+// GET /v1/books/export is matched by GET /v1/books/:id.
+func NewBookCSVApi(app *fiber.App, authHandler fiber.Handler, bookCSVService domain.BookCSVService, cnf *config.Config) {
+	ba := bookCSVApi{
+		bookCSVService: bookCSVService,
+		cnf:            cnf,
+	}
+
+	bookGroup := app.Group("/v1/books")
+
+	bookGroup.Post("/import", authHandler, ba.importBooks)
+	bookGroup.Get("/export", authHandler, ba.exportBooks)
+}
+
+func (ba *bookCSVApi) importBooks(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 5*time.Minute)
+	defer cancel()
+
+	dryRun, err := strconv.ParseBool(ctx.Query("dry_run", "false"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid dry_run value, use true or false"))
+	}
+
+	mode := ctx.Query("mode", constants.BookImportModeAtomic)
+	if mode != constants.BookImportModeAtomic && mode != constants.BookImportModeChunked {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid mode, use atomic or chunked"))
+	}
+
+	// The upload may be rejected part way through, and whatever is left of
+	// it must not be read as the next request on this connection.
+	ctx.Context().SetConnectionClose()
+
+	src, err := ba.uploadedFile(ctx, "file")
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("CSV file upload is required"))
+	}
+
+	report, err := ba.bookCSVService.ImportBooks(c, src, dto.BookImportOptions{DryRun: dryRun, Mode: mode})
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return ctx.Status(http.StatusRequestEntityTooLarge).JSON(dto.NewResponseMessage("CSV file is too large"))
+		}
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(report))
+}
+
+// uploadedFile reads the multipart body as it arrives and returns the part
+// named field, capped at the import limit.
+func (ba *bookCSVApi) uploadedFile(ctx *fiber.Ctx, field string) (io.Reader, error) {
+	boundary := string(ctx.Request().Header.MultipartFormBoundary())
+	if boundary == "" {
+		return nil, http.ErrNotMultipart
+	}
+
+	body := ctx.Context().RequestBodyStream()
+	if body == nil {
+		body = bytes.NewReader(ctx.Body())
+	}
+
+	reader := multipart.NewReader(http.MaxBytesReader(nil, io.NopCloser(body), int64(ba.cnf.Server.ImportLimit)), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == field {
+			return part, nil
+		}
+	}
+}
+
+func (ba *bookCSVApi) exportBooks(ctx *fiber.Ctx) error {
+	ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="books.csv"`)
+
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := ba.bookCSVService.ExportBooks(context.Background(), w); err != nil {
+			slog.Error("failed to export books", "error", err)
+		}
+		w.Flush()
+	})
+
+	return nil
+}
@@ -2,8 +2,11 @@ package api
 
 import (
 	"context"
+	"errors"
 	"go-rest-api/domain"
 	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
 	"go-rest-api/internal/utils"
 	"net/http"
 	"time"
@@ -21,12 +24,16 @@ func NewBookTransactionApi(app *fiber.App, authHandler fiber.Handler, bookTransa
 		bookTransactionService: bookTransactionService,
 	}
 
+	staffOnly := middleware.RoleMiddleware(constants.RoleAdmin, constants.RoleStaff)
+
 	bookTransactionGroup := app.Group("/v1/book-transactions")
 
 	bookTransactionGroup.Get("/", authHandler, bta.getAllBookTransactions)
 	bookTransactionGroup.Post("/", authHandler, bta.createBookTransaction)
 	bookTransactionGroup.Put("/:id", authHandler, bta.updateBookTransaction)
 	bookTransactionGroup.Put("/:id/return", authHandler, bta.returnBookTransaction)
+	bookTransactionGroup.Put("/:id/renew", authHandler, bta.renewBookTransaction)
+	bookTransactionGroup.Put("/:id/lost", authHandler, staffOnly, bta.declareLost)
 	bookTransactionGroup.Delete("/:id", authHandler, bta.deleteBookTransaction)
 }
 
@@ -64,6 +71,18 @@ func (bta *bookTransactionApi) createBookTransaction(ctx *fiber.Ctx) error {
 
 	transaction, err := bta.bookTransactionService.CreateBookTransaction(c, req)
 	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrStockBookMismatch), errors.Is(err, constants.ErrStockCodeMistyped):
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrStockOnHold):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrItemReferenceOnly), errors.Is(err, constants.ErrItemInLibraryOnly),
+			errors.Is(err, constants.ErrLoanLimitReached), errors.Is(err, constants.ErrMembershipTierNotFound):
+			return ctx.Status(http.StatusUnprocessableEntity).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrCustomerTooYoung), errors.Is(err, constants.ErrCustomerBirthDateNeeded),
+			errors.Is(err, constants.ErrMembershipExpired), errors.Is(err, constants.ErrCustomerSuspended):
+			return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage(err.Error()))
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
 	}
 
@@ -101,8 +120,68 @@ func (bta *bookTransactionApi) returnBookTransaction(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
 	}
 
-	transaction, err := bta.bookTransactionService.ReturnBookTransaction(c, req)
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	transaction, err := bta.bookTransactionService.ReturnBookTransaction(c, req, userID)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(transaction))
+}
+
+func (bta *bookTransactionApi) renewBookTransaction(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	transaction, err := bta.bookTransactionService.RenewBookTransaction(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrBookTransactionNotFound):
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrLoanNotActive):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrRenewalLimitReached), errors.Is(err, constants.ErrMembershipTierNotFound):
+			return ctx.Status(http.StatusUnprocessableEntity).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrMembershipExpired), errors.Is(err, constants.ErrCustomerSuspended):
+			return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(transaction))
+}
+
+func (bta *bookTransactionApi) declareLost(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	transaction, err := bta.bookTransactionService.DeclareLost(c, id, userID)
 	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrBookTransactionNotFound), errors.Is(err, constants.ErrBookstockNotFound):
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrLoanNotActive):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
 	}
 
@@ -2,11 +2,13 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
 	"go-rest-api/domain"
 	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
 	"go-rest-api/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -26,10 +28,16 @@ func NewBookstockApi(app *fiber.App, authHandler fiber.Handler, bookstockService
 
 	bookstockGroup.Get("/", authHandler, ba.getAllBookstocks)
 	bookstockGroup.Get("/:code", authHandler, ba.getBookstockByCode)
+	bookstockGroup.Get("/:code/history", authHandler, ba.getStatusHistory)
 	bookstockGroup.Get("/book/:bookId", authHandler, ba.getBookstocksByBookID)
 	bookstockGroup.Get("/book/:bookId/available", authHandler, ba.getAvailableBookstocksByBookID)
 	bookstockGroup.Post("/", authHandler, ba.createBookstock)
+	bookstockGroup.Post("/bulk", authHandler, ba.bulkCreateBookstocks)
+	bookstockGroup.Post("/bulk/status", authHandler, ba.bulkUpdateStatus)
 	bookstockGroup.Put("/:code", authHandler, ba.updateBookstock)
+	bookstockGroup.Put("/:code/location", authHandler, ba.updateLocation)
+	bookstockGroup.Put("/:code/condition", authHandler, ba.updateCondition)
+	bookstockGroup.Put("/:code/item-type", authHandler, ba.updateItemType)
 	bookstockGroup.Delete("/:code", authHandler, ba.deleteBookstock)
 }
 
@@ -39,7 +47,12 @@ func (ba *bookstockApi) getAllBookstocks(ctx *fiber.Ctx) error {
 
 	_ = c // Using the timeout context even though the service method doesn't take it yet
 
-	bookstocks, err := ba.bookstockService.GetAllBookstocks()
+	filter, err := parseBookstockFilter(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	bookstocks, err := ba.bookstockService.GetAllBookstocks(filter)
 	if err != nil {
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
 	}
@@ -47,6 +60,50 @@ func (ba *bookstockApi) getAllBookstocks(ctx *fiber.Ctx) error {
 	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(bookstocks))
 }
 
+func parseBookstockFilter(ctx *fiber.Ctx) (dto.BookstockFilter, error) {
+	filter := dto.BookstockFilter{
+		Status:    ctx.Query("status"),
+		Condition: ctx.Query("condition"),
+		Location:  ctx.Query("location", constants.LocationCurrent),
+	}
+
+	switch filter.Condition {
+	case "", constants.ConditionNew, constants.ConditionGood, constants.ConditionWorn, constants.ConditionPoor:
+	default:
+		return filter, errors.New("Invalid condition value, use new, good, worn or poor")
+	}
+
+	if filter.Location != constants.LocationCurrent && filter.Location != constants.LocationHome {
+		return filter, errors.New("Invalid location value, use current or home")
+	}
+
+	if ctx.Query("book_id") != "" {
+		bookID, err := uuid.Parse(ctx.Query("book_id"))
+		if err != nil {
+			return filter, errors.New("Invalid book_id format")
+		}
+		filter.BookID = &bookID
+	}
+
+	if ctx.Query("branch_id") != "" {
+		branchID, err := uuid.Parse(ctx.Query("branch_id"))
+		if err != nil {
+			return filter, errors.New("Invalid branch_id format")
+		}
+		filter.BranchID = &branchID
+	}
+
+	if ctx.Query("shelf_id") != "" {
+		shelfID, err := uuid.Parse(ctx.Query("shelf_id"))
+		if err != nil {
+			return filter, errors.New("Invalid shelf_id format")
+		}
+		filter.ShelfID = &shelfID
+	}
+
+	return filter, nil
+}
+
 func (ba *bookstockApi) getBookstockByCode(ctx *fiber.Ctx) error {
 	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
 	defer cancel()
@@ -96,7 +153,12 @@ func (ba *bookstockApi) getAvailableBookstocksByBookID(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid book ID format"))
 	}
 
-	bookstocks, err := ba.bookstockService.GetAvailableBookstocksByBookID(bookId)
+	filter, err := parseBookstockFilter(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	bookstocks, err := ba.bookstockService.GetAvailableBookstocksByBookID(bookId, filter)
 	if err != nil {
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
 	}
@@ -122,6 +184,9 @@ func (ba *bookstockApi) createBookstock(ctx *fiber.Ctx) error {
 
 	bookstock, err := ba.bookstockService.CreateBookstock(req)
 	if err != nil {
+		if isLocationError(err) {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
 	}
 
@@ -132,8 +197,6 @@ func (ba *bookstockApi) updateBookstock(ctx *fiber.Ctx) error {
 	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
 	defer cancel()
 
-	_ = c // Using the timeout context
-
 	code := ctx.Params("code")
 	if code == "" {
 		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid code parameter"))
@@ -149,7 +212,12 @@ func (ba *bookstockApi) updateBookstock(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
 	}
 
-	bookstock, err := ba.bookstockService.UpdateBookstock(code, req)
+	changedBy, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	bookstock, err := ba.bookstockService.UpdateBookstock(c, code, changedBy, req)
 	if err != nil {
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
 	}
@@ -169,8 +237,170 @@ func (ba *bookstockApi) deleteBookstock(ctx *fiber.Ctx) error {
 	}
 
 	if err := ba.bookstockService.DeleteBookstock(code); err != nil {
+		if handled, err := sendDeleteBlocked(ctx, err); handled {
+			return err
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
 	}
 
 	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Bookstock deleted successfully"))
 }
+
+func (ba *bookstockApi) bulkCreateBookstocks(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	var req dto.BookstockBulkCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	report, err := ba.bookstockService.BulkCreateBookstocks(c, req)
+	if err != nil {
+		if isLocationError(err) {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(report))
+}
+
+func (ba *bookstockApi) bulkUpdateStatus(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	var req dto.BookstockBulkStatusRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	changedBy, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	report, err := ba.bookstockService.BulkUpdateStatus(c, changedBy, req)
+	if err != nil {
+		if errors.Is(err, constants.ErrBulkRejected) {
+			return ctx.Status(http.StatusUnprocessableEntity).JSON(dto.ResponseData[*dto.BookstockBulkReport]{
+				Timestamp: time.Now(),
+				Message:   err.Error(),
+				Data:      report,
+			})
+		}
+		if errors.Is(err, constants.ErrBookstockStatusChanged) {
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(report))
+}
+
+func (ba *bookstockApi) getStatusHistory(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	history, err := ba.bookstockService.GetStatusHistory(c, ctx.Params("code"))
+	if err != nil {
+		if errors.Is(err, constants.ErrBookstockNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Bookstock not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(history))
+}
+
+func (ba *bookstockApi) updateLocation(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.BookstockLocationRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	bookstock, err := ba.bookstockService.UpdateLocation(c, ctx.Params("code"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrBookstockNotFound):
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Bookstock not found"))
+		case isLocationError(err):
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(bookstock))
+}
+
+func (ba *bookstockApi) updateCondition(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.BookstockConditionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	bookstock, err := ba.bookstockService.UpdateCondition(c, ctx.Params("code"), req)
+	if err != nil {
+		if errors.Is(err, constants.ErrBookstockNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Bookstock not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(bookstock))
+}
+
+func (ba *bookstockApi) updateItemType(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.BookstockItemTypeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	bookstock, err := ba.bookstockService.UpdateItemType(c, ctx.Params("code"), req)
+	if err != nil {
+		if errors.Is(err, constants.ErrBookstockNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Bookstock not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(bookstock))
+}
+
+// isLocationError reports whether err is an invalid branch or shelf in the
+// request.
+func isLocationError(err error) bool {
+	return errors.Is(err, constants.ErrBranchNotFound) ||
+		errors.Is(err, constants.ErrShelfNotFound) ||
+		errors.Is(err, constants.ErrShelfNotInBranch) ||
+		errors.Is(err, constants.ErrStockCodeBranch)
+}
@@ -0,0 +1,235 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type branchApi struct {
+	branchService domain.BranchService
+}
+
+func NewBranchApi(app *fiber.App, authHandler fiber.Handler, branchService domain.BranchService) {
+	ba := branchApi{
+		branchService: branchService,
+	}
+
+	adminOnly := middleware.RoleMiddleware(constants.RoleAdmin)
+
+	branchGroup := app.Group("/v1/branches")
+
+	branchGroup.Get("/", authHandler, ba.getAllBranches)
+	branchGroup.Get("/:id", authHandler, ba.getBranchByID)
+	branchGroup.Post("/", authHandler, adminOnly, ba.createBranch)
+	branchGroup.Put("/:id", authHandler, adminOnly, ba.updateBranch)
+	branchGroup.Delete("/:id", authHandler, adminOnly, ba.deleteBranch)
+	branchGroup.Get("/:id/shelves", authHandler, ba.getShelves)
+	branchGroup.Post("/:id/shelves", authHandler, adminOnly, ba.createShelf)
+
+	shelfGroup := app.Group("/v1/shelves")
+
+	shelfGroup.Put("/:id", authHandler, adminOnly, ba.updateShelf)
+	shelfGroup.Delete("/:id", authHandler, adminOnly, ba.deleteShelf)
+}
+
+func (ba *branchApi) getAllBranches(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	branches, err := ba.branchService.GetBranches(c)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(branches))
+}
+
+func (ba *branchApi) getBranchByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	branch, err := ba.branchService.GetBranchByID(c, id)
+	if err != nil {
+		return sendBranchError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(branch))
+}
+
+func (ba *branchApi) createBranch(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.BranchRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	branch, err := ba.branchService.CreateBranch(c, req)
+	if err != nil {
+		return sendBranchError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(branch))
+}
+
+func (ba *branchApi) updateBranch(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.BranchRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	branch, err := ba.branchService.UpdateBranch(c, id, req)
+	if err != nil {
+		return sendBranchError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(branch))
+}
+
+func (ba *branchApi) deleteBranch(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := ba.branchService.DeleteBranch(c, id); err != nil {
+		return sendBranchError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Branch deleted successfully"))
+}
+
+func (ba *branchApi) getShelves(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	shelves, err := ba.branchService.GetShelves(c, id)
+	if err != nil {
+		return sendBranchError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(shelves))
+}
+
+func (ba *branchApi) createShelf(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.ShelfRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	shelf, err := ba.branchService.CreateShelf(c, id, req)
+	if err != nil {
+		return sendBranchError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(shelf))
+}
+
+func (ba *branchApi) updateShelf(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.ShelfRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	shelf, err := ba.branchService.UpdateShelf(c, id, req)
+	if err != nil {
+		return sendBranchError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(shelf))
+}
+
+func (ba *branchApi) deleteShelf(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := ba.branchService.DeleteShelf(c, id); err != nil {
+		return sendBranchError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Shelf deleted successfully"))
+}
+
+func sendBranchError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, constants.ErrBranchNotFound), errors.Is(err, constants.ErrShelfNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrBranchExists), errors.Is(err, constants.ErrShelfExists),
+		errors.Is(err, constants.ErrBranchInUse), errors.Is(err, constants.ErrShelfInUse):
+		return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
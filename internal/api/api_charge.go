@@ -0,0 +1,149 @@
+package api
+
+import (
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type chargeApi struct {
+	chargeService domain.ChargeService
+}
+
+func NewChargeApi(app *fiber.App, authHandler fiber.Handler, chargeService domain.ChargeService) {
+	ca := chargeApi{chargeService: chargeService}
+
+	staffOnly := middleware.RoleMiddleware(constants.RoleAdmin, constants.RoleStaff)
+	adminOnly := middleware.RoleMiddleware(constants.RoleAdmin)
+
+	chargeGroup := app.Group("/v1/charges")
+
+	chargeGroup.Get("/", authHandler, staffOnly, ca.getCharges)
+	chargeGroup.Get("/:id", authHandler, staffOnly, ca.getChargeByID)
+	chargeGroup.Post("/", authHandler, staffOnly, ca.createCharge)
+	chargeGroup.Put("/:id", authHandler, staffOnly, ca.updateCharge)
+	chargeGroup.Put("/:id/pay", authHandler, staffOnly, ca.payCharge)
+	chargeGroup.Delete("/:id", authHandler, adminOnly, ca.deleteCharge)
+}
+
+// getCharges lists every charge, or those of one loan with
+// ?book_transaction_id=.
+func (ca *chargeApi) getCharges(ctx *fiber.Ctx) error {
+	var charges []dto.ChargeResponse
+	var err error
+	if value := ctx.Query("book_transaction_id"); value != "" {
+		bookTransactionID, parseErr := uuid.Parse(value)
+		if parseErr != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid book_transaction_id format"))
+		}
+		charges, err = ca.chargeService.GetChargesByBookTransactionID(bookTransactionID)
+	} else {
+		charges, err = ca.chargeService.GetAllCharges()
+	}
+	if err != nil {
+		return sendChargeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(charges))
+}
+
+func (ca *chargeApi) getChargeByID(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	charge, err := ca.chargeService.GetChargeByID(id)
+	if err != nil {
+		return sendChargeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(charge))
+}
+
+func (ca *chargeApi) createCharge(ctx *fiber.Ctx) error {
+	var req dto.ChargeCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	charge, err := ca.chargeService.CreateCharge(req)
+	if err != nil {
+		return sendChargeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(charge))
+}
+
+func (ca *chargeApi) updateCharge(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.ChargeUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	charge, err := ca.chargeService.UpdateCharge(id, req)
+	if err != nil {
+		return sendChargeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(charge))
+}
+
+func (ca *chargeApi) payCharge(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	charge, err := ca.chargeService.PayCharge(id)
+	if err != nil {
+		return sendChargeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(charge))
+}
+
+func (ca *chargeApi) deleteCharge(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := ca.chargeService.DeleteCharge(id); err != nil {
+		return sendChargeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Charge deleted successfully"))
+}
+
+func sendChargeError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, constants.ErrChargeNotFound), errors.Is(err, constants.ErrBookTransactionNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrChargePaid):
+		return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
@@ -1,13 +1,17 @@
 package api
 
 import (
+	"errors"
 	"go-rest-api/domain"
 	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
 	"go-rest-api/internal/utils"
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type CustomerApi struct {
@@ -20,10 +24,15 @@ func NewCustomerApi(app *fiber.App, authHandler fiber.Handler, customerService d
 	CustomerGroup := app.Group("/v1/customers")
 
 	CustomerGroup.Get("/", authHandler, ch.GetAllCustomers)
+	CustomerGroup.Get("/trash", authHandler, ch.GetTrashedCustomers)
 	CustomerGroup.Get("/:id", authHandler, ch.GetCustomerByID)
 	CustomerGroup.Post("/", authHandler, ch.CreateCustomer)
 	CustomerGroup.Put("/:id", authHandler, ch.UpdateCustomer)
 	CustomerGroup.Delete("/:id", authHandler, ch.DeleteCustomer)
+	CustomerGroup.Post("/:id/restore", authHandler, ch.RestoreCustomer)
+	CustomerGroup.Post("/:id/renew", authHandler, ch.RenewMembership)
+	CustomerGroup.Post("/:id/patron-claim", authHandler, ch.IssuePatronClaim)
+	CustomerGroup.Delete("/:id/purge", authHandler, middleware.RoleMiddleware(constants.RoleAdmin), ch.PurgeCustomer)
 }
 
 func (h *CustomerApi) GetAllCustomers(ctx *fiber.Ctx) error {
@@ -60,6 +69,9 @@ func (h *CustomerApi) CreateCustomer(ctx *fiber.Ctx) error {
 
 	customer, err := h.customerService.CreateCustomer(req)
 	if err != nil {
+		if isCustomerProfileError(err) {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
 	}
 
@@ -77,8 +89,16 @@ func (h *CustomerApi) UpdateCustomer(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
 	}
 
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
 	customer, err := h.customerService.UpdateCustomer(id, req)
 	if err != nil {
+		if isCustomerProfileError(err) {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
 	}
 
@@ -93,8 +113,110 @@ func (h *CustomerApi) DeleteCustomer(ctx *fiber.Ctx) error {
 
 	err = h.customerService.DeleteCustomer(id)
 	if err != nil {
+		if handled, err := sendDeleteBlocked(ctx, err); handled {
+			return err
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
 	}
 
 	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Customer deleted successfully"))
 }
+
+func (h *CustomerApi) GetTrashedCustomers(ctx *fiber.Ctx) error {
+	customers, err := h.customerService.GetTrashedCustomers()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(customers))
+}
+
+func (h *CustomerApi) RestoreCustomer(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer ID"))
+	}
+
+	customer, err := h.customerService.RestoreCustomer(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Customer not found in trash"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(customer))
+}
+
+func (h *CustomerApi) PurgeCustomer(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer ID"))
+	}
+
+	if err := h.customerService.PurgeCustomer(id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Customer not found in trash"))
+		case errors.Is(err, constants.ErrRetentionNotElapsed), errors.Is(err, constants.ErrHasDependents):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage(constants.MsgPurgeSuccess))
+}
+
+func (h *CustomerApi) RenewMembership(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer ID"))
+	}
+
+	var req dto.MembershipRenewRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+		}
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	customer, err := h.customerService.RenewMembership(id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Customer not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(customer))
+}
+
+func (h *CustomerApi) IssuePatronClaim(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer ID"))
+	}
+
+	claim, err := h.customerService.IssuePatronClaim(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Customer not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(claim))
+}
+
+// isCustomerProfileError reports whether err is invalid profile input the
+// validator cannot catch on its own.
+func isCustomerProfileError(err error) bool {
+	return errors.Is(err, constants.ErrInvalidDate) ||
+		errors.Is(err, constants.ErrMembershipTierNotFound) ||
+		errors.Is(err, constants.ErrMembershipDates) ||
+		errors.Is(err, constants.ErrNotificationContact)
+}
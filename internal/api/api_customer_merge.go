@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type customerMergeApi struct {
+	customerMergeService domain.CustomerMergeService
+}
+
+func NewCustomerMergeApi(app *fiber.App, authHandler fiber.Handler, customerMergeService domain.CustomerMergeService) {
+	cma := customerMergeApi{
+		customerMergeService: customerMergeService,
+	}
+
+	staffOnly := middleware.RoleMiddleware(constants.RoleAdmin, constants.RoleStaff)
+
+	mergeGroup := app.Group("/v1/customer-merges")
+
+	mergeGroup.Get("/duplicates", authHandler, staffOnly, cma.findDuplicates)
+	mergeGroup.Get("/", authHandler, staffOnly, cma.getMerges)
+	mergeGroup.Post("/", authHandler, staffOnly, cma.merge)
+}
+
+func (cma *customerMergeApi) findDuplicates(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var filter dto.DuplicateFilter
+	if value := ctx.Query("min_similarity"); value != "" {
+		similarity, err := strconv.ParseFloat(value, 64)
+		if err != nil || similarity <= 0 || similarity > 1 {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("min_similarity must be between 0 and 1"))
+		}
+		filter.MinNameSimilarity = similarity
+	}
+	if value := ctx.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("limit must be a positive number"))
+		}
+		filter.Limit = limit
+	}
+
+	candidates, err := cma.customerMergeService.FindDuplicates(c, filter)
+	if err != nil {
+		return sendCustomerMergeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(candidates))
+}
+
+func (cma *customerMergeApi) getMerges(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var customerID *uuid.UUID
+	if value := ctx.Query("customer_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer ID"))
+		}
+		customerID = &id
+	}
+
+	merges, err := cma.customerMergeService.GetMerges(c, customerID)
+	if err != nil {
+		return sendCustomerMergeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(merges))
+}
+
+func (cma *customerMergeApi) merge(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	var req dto.CustomerMergeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	merge, err := cma.customerMergeService.Merge(c, userID, req)
+	if err != nil {
+		return sendCustomerMergeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(merge))
+}
+
+func sendCustomerMergeError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, constants.ErrCustomerNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrMergeSameCustomer):
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
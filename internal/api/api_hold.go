@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type holdApi struct {
+	holdService domain.HoldService
+}
+
+func NewHoldApi(app *fiber.App, authHandler fiber.Handler, holdService domain.HoldService) {
+	ha := holdApi{
+		holdService: holdService,
+	}
+
+	holdGroup := app.Group("/v1/holds")
+
+	holdGroup.Get("/", authHandler, ha.getAllHolds)
+	holdGroup.Get("/:id", authHandler, ha.getHoldByID)
+	holdGroup.Post("/", authHandler, ha.placeHold)
+	holdGroup.Post("/:id/cancel", authHandler, ha.cancelHold)
+}
+
+func (ha *holdApi) getAllHolds(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	filter := dto.HoldFilter{Status: ctx.Query("status")}
+	for param, target := range map[string]**uuid.UUID{
+		"customer_id": &filter.CustomerID,
+		"book_id":     &filter.BookID,
+		"work_id":     &filter.WorkID,
+	} {
+		if ctx.Query(param) == "" {
+			continue
+		}
+		id, err := uuid.Parse(ctx.Query(param))
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid " + param + " format"))
+		}
+		*target = &id
+	}
+
+	holds, err := ha.holdService.GetHolds(c, filter)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(holds))
+}
+
+func (ha *holdApi) getHoldByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	hold, err := ha.holdService.GetHoldByID(c, id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Hold not found"))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(hold))
+}
+
+func (ha *holdApi) placeHold(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.HoldCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	hold, err := ha.holdService.PlaceHold(c, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrCustomerNotFound), errors.Is(err, constants.ErrBookNotFound), errors.Is(err, constants.ErrWorkNotFound),
+			errors.Is(err, constants.ErrBranchNotFound):
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrHoldExists):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrHoldLimitReached), errors.Is(err, constants.ErrMembershipTierNotFound):
+			return ctx.Status(http.StatusUnprocessableEntity).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrCustomerSuspended):
+			return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(hold))
+}
+
+func (ha *holdApi) cancelHold(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	hold, err := ha.holdService.CancelHold(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Hold not found"))
+		case errors.Is(err, constants.ErrHoldNotActive):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(hold))
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type labelApi struct {
+	labelService domain.LabelService
+}
+
+func NewLabelApi(app *fiber.App, authHandler fiber.Handler, labelService domain.LabelService) {
+	la := labelApi{
+		labelService: labelService,
+	}
+
+	app.Get("/v1/bookstocks/:code/barcode", authHandler, la.getBarcode)
+	app.Post("/v1/bookstocks/labels", authHandler, la.printLabels)
+}
+
+func (la *labelApi) getBarcode(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	opts := dto.BarcodeOptions{
+		Type:   ctx.Query("type", constants.BarcodeTypeCode128),
+		Format: ctx.Query("format", constants.BarcodeFormatPNG),
+	}
+
+	var err error
+	if opts.Width, err = parseBarcodeSize(ctx.Query("width")); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid width value"))
+	}
+	if opts.Height, err = parseBarcodeSize(ctx.Query("height")); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid height value"))
+	}
+
+	image, contentType, err := la.labelService.RenderBarcode(c, ctx.Params("code"), opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrBookstockNotFound):
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Bookstock not found"))
+		case errors.Is(err, constants.ErrUnsupportedBarcode):
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	ctx.Set(fiber.HeaderContentType, contentType)
+	return ctx.Status(http.StatusOK).Send(image)
+}
+
+func (la *labelApi) printLabels(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	var req dto.LabelSheetRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	pdf, err := la.labelService.RenderLabelSheet(c, req)
+	if err != nil {
+		if errors.Is(err, constants.ErrBookstockNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, `inline; filename="labels.pdf"`)
+	return ctx.Status(http.StatusOK).Send(pdf)
+}
+
+// parseBarcodeSize reads an optional pixel size; zero means the default.
+func parseBarcodeSize(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 1 || size > constants.BarcodeMaxSize {
+		return 0, errors.New("invalid size")
+	}
+	return size, nil
+}
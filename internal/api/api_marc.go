@@ -0,0 +1,97 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type marcApi struct {
+	marcService domain.MARCService
+}
+
+func NewMARCApi(app *fiber.App, authHandler fiber.Handler, marcService domain.MARCService) {
+	ma := marcApi{
+		marcService: marcService,
+	}
+
+	marcGroup := app.Group("/v1/books/marc")
+
+	marcGroup.Post("/import", authHandler, ma.importRecords)
+	marcGroup.Get("/export", authHandler, ma.exportBooks)
+}
+
+func (ma *marcApi) importRecords(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 5*time.Minute)
+	defer cancel()
+
+	dryRun, err := strconv.ParseBool(ctx.Query("dry_run", "false"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid dry_run value, use true or false"))
+	}
+
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("MARC file upload is required"))
+	}
+
+	format := ctx.Query("format")
+	if format == "" {
+		format = constants.MARCFormatBinary
+		if strings.EqualFold(filepath.Ext(file.Filename), ".xml") {
+			format = constants.MARCFormatXML
+		}
+	}
+	if format != constants.MARCFormatBinary && format != constants.MARCFormatXML {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid format, use binary or xml"))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage("Failed to open uploaded file"))
+	}
+	defer src.Close()
+
+	report, err := ma.marcService.ImportRecords(c, src, dto.MARCImportOptions{Format: format, DryRun: dryRun})
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(report))
+}
+
+func (ma *marcApi) exportBooks(ctx *fiber.Ctx) error {
+	var ids []uuid.UUID
+	if raw := ctx.Query("ids"); raw != "" {
+		for _, value := range strings.Split(raw, ",") {
+			id, err := uuid.Parse(strings.TrimSpace(value))
+			if err != nil {
+				return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/marcxml+xml; charset=utf-8")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="books.xml"`)
+
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := ma.marcService.ExportBooks(context.Background(), w, ids); err != nil {
+			slog.Error("failed to export MARCXML", "error", err)
+		}
+		w.Flush()
+	})
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type membershipTierApi struct {
+	tierService domain.MembershipTierService
+}
+
+func NewMembershipTierApi(app *fiber.App, authHandler fiber.Handler, tierService domain.MembershipTierService) {
+	ma := membershipTierApi{
+		tierService: tierService,
+	}
+
+	staffOnly := middleware.RoleMiddleware(constants.RoleAdmin, constants.RoleStaff)
+	adminOnly := middleware.RoleMiddleware(constants.RoleAdmin)
+
+	tierGroup := app.Group("/v1/membership-tiers")
+
+	tierGroup.Get("/", authHandler, staffOnly, ma.getAllTiers)
+	tierGroup.Get("/:id", authHandler, staffOnly, ma.getTierByID)
+	tierGroup.Post("/", authHandler, adminOnly, ma.createTier)
+	tierGroup.Put("/:id", authHandler, adminOnly, ma.updateTier)
+	tierGroup.Delete("/:id", authHandler, adminOnly, ma.deleteTier)
+}
+
+func (ma *membershipTierApi) getAllTiers(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	tiers, err := ma.tierService.GetTiers(c)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(tiers))
+}
+
+func (ma *membershipTierApi) getTierByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	tier, err := ma.tierService.GetTierByID(c, id)
+	if err != nil {
+		return sendMembershipTierError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(tier))
+}
+
+func (ma *membershipTierApi) createTier(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.MembershipTierRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	tier, err := ma.tierService.CreateTier(c, req)
+	if err != nil {
+		return sendMembershipTierError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(tier))
+}
+
+func (ma *membershipTierApi) updateTier(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.MembershipTierRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	tier, err := ma.tierService.UpdateTier(c, id, req)
+	if err != nil {
+		return sendMembershipTierError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(tier))
+}
+
+func (ma *membershipTierApi) deleteTier(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := ma.tierService.DeleteTier(c, id); err != nil {
+		return sendMembershipTierError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Membership tier deleted successfully"))
+}
+
+func sendMembershipTierError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, constants.ErrMembershipTierNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrMembershipTierExists), errors.Is(err, constants.ErrMembershipTierInUse):
+		return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/config"
+	"go-rest-api/internal/constants"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type opdsApi struct {
+	opdsService domain.OPDSService
+	cnf         *config.Config
+}
+
+// NewOPDSApi exposes the catalog as a public, read-only OPDS 1.2 feed.
+func NewOPDSApi(app *fiber.App, opdsService domain.OPDSService, cnf *config.Config) {
+	oa := opdsApi{
+		opdsService: opdsService,
+		cnf:         cnf,
+	}
+
+	opdsGroup := app.Group("/opds")
+
+	opdsGroup.Get("/", oa.root)
+	opdsGroup.Get("/opensearch.xml", oa.openSearch)
+	opdsGroup.Get("/new", oa.newArrivals)
+	opdsGroup.Get("/categories", oa.categories)
+	opdsGroup.Get("/categories/:category", oa.booksByCategory)
+	opdsGroup.Get("/authors", oa.authors)
+	opdsGroup.Get("/authors/:author", oa.booksByAuthor)
+	opdsGroup.Get("/search", oa.search)
+	opdsGroup.Get("/books/:id", oa.book)
+	opdsGroup.Get("/covers/:name", oa.cover)
+}
+
+func (oa *opdsApi) root(ctx *fiber.Ctx) error {
+	return sendXML(ctx, http.StatusOK, constants.OPDSNavigationType, oa.opdsService.Root(ctx.BaseURL()))
+}
+
+func (oa *opdsApi) openSearch(ctx *fiber.Ctx) error {
+	return sendXML(ctx, http.StatusOK, constants.OPDSOpenSearchType, oa.opdsService.OpenSearchDescription(ctx.BaseURL()))
+}
+
+func (oa *opdsApi) newArrivals(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	feed, err := oa.opdsService.NewArrivals(c, ctx.BaseURL(), queryPage(ctx))
+	if err != nil {
+		return sendOPDSError(ctx, c, err)
+	}
+
+	return sendXML(ctx, http.StatusOK, constants.OPDSAcquisitionType, feed)
+}
+
+func (oa *opdsApi) categories(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	feed, err := oa.opdsService.Categories(c, ctx.BaseURL())
+	if err != nil {
+		return sendOPDSError(ctx, c, err)
+	}
+
+	return sendXML(ctx, http.StatusOK, constants.OPDSNavigationType, feed)
+}
+
+func (oa *opdsApi) booksByCategory(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	category, err := url.PathUnescape(ctx.Params("category"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid category"))
+	}
+
+	feed, err := oa.opdsService.BooksByCategory(c, ctx.BaseURL(), category, queryPage(ctx))
+	if err != nil {
+		return sendOPDSError(ctx, c, err)
+	}
+
+	return sendXML(ctx, http.StatusOK, constants.OPDSAcquisitionType, feed)
+}
+
+func (oa *opdsApi) authors(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	feed, err := oa.opdsService.Authors(c, ctx.BaseURL())
+	if err != nil {
+		return sendOPDSError(ctx, c, err)
+	}
+
+	return sendXML(ctx, http.StatusOK, constants.OPDSNavigationType, feed)
+}
+
+func (oa *opdsApi) booksByAuthor(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	author, err := url.PathUnescape(ctx.Params("author"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid author"))
+	}
+
+	feed, err := oa.opdsService.BooksByAuthor(c, ctx.BaseURL(), author, queryPage(ctx))
+	if err != nil {
+		return sendOPDSError(ctx, c, err)
+	}
+
+	return sendXML(ctx, http.StatusOK, constants.OPDSAcquisitionType, feed)
+}
+
+func (oa *opdsApi) search(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	feed, err := oa.opdsService.Search(c, ctx.BaseURL(), ctx.Query("q"), queryPage(ctx))
+	if err != nil {
+		return sendOPDSError(ctx, c, err)
+	}
+
+	return sendXML(ctx, http.StatusOK, constants.OPDSAcquisitionType, feed)
+}
+
+func (oa *opdsApi) book(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	entry, err := oa.opdsService.Book(c, ctx.BaseURL(), id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Book not found"))
+	}
+
+	return sendXML(ctx, http.StatusOK, constants.OPDSEntryType, entry)
+}
+
+func (oa *opdsApi) cover(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	filePath, err := oa.opdsService.CoverFile(c, ctx.Params("name"))
+	if errors.Is(err, constants.ErrMediaNotFound) {
+		return ctx.Status(fiber.StatusNotFound).JSON(dto.NewResponseMessage("File not found"))
+	}
+	if err != nil {
+		return sendOPDSError(ctx, c, err)
+	}
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return ctx.Status(fiber.StatusNotFound).JSON(dto.NewResponseMessage("File not found"))
+	}
+
+	return ctx.SendFile(filePath)
+}
+
+// sendOPDSError logs err and answers with a generic message, since the
+// catalogue is public and must not leak internal errors.
+func sendOPDSError(ctx *fiber.Ctx, c context.Context, err error) error {
+	slog.ErrorContext(c, "failed to serve OPDS catalogue", "error", err)
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage("Internal server error"))
+}
+
+func queryPage(ctx *fiber.Ctx) int {
+	page, _ := strconv.Atoi(ctx.Query("page", "1"))
+	return page
+}
+
+func sendXML(ctx *fiber.Ctx, status int, contentType string, body any) error {
+	data, err := xml.MarshalIndent(body, "", "  ")
+	if err != nil {
+		return sendOPDSError(ctx, ctx.Context(), err)
+	}
+
+	ctx.Set(fiber.HeaderContentType, contentType+";charset=utf-8")
+	return ctx.Status(status).Send(append([]byte(xml.Header), data...))
+}
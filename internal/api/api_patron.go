@@ -0,0 +1,309 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type patronApi struct {
+	patronService domain.PatronService
+}
+
+// NewPatronApi mounts the self-service portal. The customer is always taken
+// from the token, never from the request.
+func NewPatronApi(app *fiber.App, authHandler fiber.Handler, patronService domain.PatronService) {
+	pa := patronApi{
+		patronService: patronService,
+	}
+
+	patronOnly := middleware.RoleMiddleware(constants.RolePatron)
+
+	meGroup := app.Group("/v1/me", authHandler, patronOnly)
+
+	meGroup.Get("/", pa.getProfile)
+	meGroup.Get("/loans", pa.getLoans)
+	meGroup.Put("/loans/:id/renew", pa.renewLoan)
+	meGroup.Get("/charges", pa.getCharges)
+	meGroup.Get("/holds", pa.getHolds)
+	meGroup.Post("/holds", pa.placeHold)
+	meGroup.Delete("/holds/:id", pa.cancelHold)
+	meGroup.Get("/reviews", pa.getReviews)
+	meGroup.Post("/reviews", pa.createReview)
+	meGroup.Put("/reviews/:id", pa.updateReview)
+	meGroup.Delete("/reviews/:id", pa.deleteReview)
+}
+
+func (pa *patronApi) getProfile(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	customer, err := pa.patronService.GetProfile(c, customerID)
+	if err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(customer))
+}
+
+func (pa *patronApi) getLoans(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	loans, err := pa.patronService.GetLoans(c, customerID, ctx.Query("status"))
+	if err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(loans))
+}
+
+func (pa *patronApi) renewLoan(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	loan, err := pa.patronService.RenewLoan(c, customerID, id)
+	if err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(loan))
+}
+
+func (pa *patronApi) getCharges(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	charges, err := pa.patronService.GetCharges(c, customerID)
+	if err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(charges))
+}
+
+func (pa *patronApi) getHolds(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	holds, err := pa.patronService.GetHolds(c, customerID)
+	if err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(holds))
+}
+
+func (pa *patronApi) placeHold(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	var req dto.PatronHoldRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	hold, err := pa.patronService.PlaceHold(c, customerID, req)
+	if err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(hold))
+}
+
+func (pa *patronApi) cancelHold(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	hold, err := pa.patronService.CancelHold(c, customerID, id)
+	if err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(hold))
+}
+
+func (pa *patronApi) getReviews(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	reviews, err := pa.patronService.GetReviews(c, customerID)
+	if err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(reviews))
+}
+
+func (pa *patronApi) createReview(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	var req dto.PatronReviewRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	review, err := pa.patronService.CreateReview(c, customerID, req)
+	if err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(review))
+}
+
+func (pa *patronApi) updateReview(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.ReviewUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	review, err := pa.patronService.UpdateReview(c, customerID, id, req)
+	if err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(review))
+}
+
+func (pa *patronApi) deleteReview(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := currentCustomerID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := pa.patronService.DeleteReview(c, customerID, id); err != nil {
+		return sendPatronError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Review deleted successfully"))
+}
+
+// currentCustomerID is the customer a patron token was issued for.
+func currentCustomerID(ctx *fiber.Ctx) (uuid.UUID, error) {
+	user, ok := ctx.Locals("x-user").(dto.UserData)
+	if !ok || user.CustomerID == "" {
+		return uuid.Nil, errors.New("missing customer")
+	}
+	return uuid.Parse(user.CustomerID)
+}
+
+func sendPatronError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, constants.ErrCustomerNotFound), errors.Is(err, constants.ErrBookTransactionNotFound),
+		errors.Is(err, constants.ErrHoldNotFound), errors.Is(err, constants.ErrBookNotFound),
+		errors.Is(err, constants.ErrWorkNotFound), errors.Is(err, constants.ErrBranchNotFound),
+		errors.Is(err, constants.ErrReviewNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrLoanNotActive), errors.Is(err, constants.ErrHoldExists),
+		errors.Is(err, constants.ErrHoldNotActive), errors.Is(err, constants.ErrReviewExists):
+		return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrRenewalLimitReached), errors.Is(err, constants.ErrHoldLimitReached),
+		errors.Is(err, constants.ErrMembershipTierNotFound):
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrMembershipExpired), errors.Is(err, constants.ErrCustomerSuspended),
+		errors.Is(err, constants.ErrReviewNotEligible):
+		return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
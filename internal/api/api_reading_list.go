@@ -0,0 +1,297 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type readingListApi struct {
+	readingListService domain.ReadingListService
+}
+
+func NewReadingListApi(app *fiber.App, authHandler fiber.Handler, readingListService domain.ReadingListService) {
+	ra := readingListApi{
+		readingListService: readingListService,
+	}
+
+	// Published lists can be browsed without an account.
+	publicGroup := app.Group("/v1/public/reading-lists")
+
+	publicGroup.Get("/", ra.getPublishedReadingLists)
+	publicGroup.Get("/:id", ra.getPublishedReadingListByID)
+
+	listGroup := app.Group("/v1/reading-lists")
+
+	listGroup.Get("/", authHandler, ra.getAllReadingLists)
+	listGroup.Get("/:id", authHandler, ra.getReadingListByID)
+	listGroup.Post("/", authHandler, ra.createReadingList)
+	listGroup.Put("/:id", authHandler, ra.updateReadingList)
+	listGroup.Delete("/:id", authHandler, ra.deleteReadingList)
+	listGroup.Post("/:id/items", authHandler, ra.addItem)
+	listGroup.Put("/:id/items/:bookId", authHandler, ra.updateItem)
+	listGroup.Delete("/:id/items/:bookId", authHandler, ra.removeItem)
+	listGroup.Put("/:id/order", authHandler, ra.reorder)
+}
+
+func (ra *readingListApi) getPublishedReadingLists(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	lists, err := ra.readingListService.GetPublishedReadingLists(c)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(lists))
+}
+
+func (ra *readingListApi) getPublishedReadingListByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	list, err := ra.readingListService.GetPublishedReadingListByID(c, id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Reading list not found"))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(list))
+}
+
+func (ra *readingListApi) getAllReadingLists(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	filter := dto.ReadingListFilter{
+		Kind:       ctx.Query("kind"),
+		Visibility: ctx.Query("visibility"),
+	}
+	if ctx.Query("customer_id") != "" {
+		customerID, err := uuid.Parse(ctx.Query("customer_id"))
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer_id format"))
+		}
+		filter.CustomerID = &customerID
+	}
+
+	lists, err := ra.readingListService.GetReadingLists(c, filter)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(lists))
+}
+
+func (ra *readingListApi) getReadingListByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	list, err := ra.readingListService.GetReadingListByID(c, id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Reading list not found"))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(list))
+}
+
+func (ra *readingListApi) createReadingList(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.ReadingListCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	list, err := ra.readingListService.CreateReadingList(c, req)
+	if err != nil {
+		return sendReadingListError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(list))
+}
+
+func (ra *readingListApi) updateReadingList(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.ReadingListUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	list, err := ra.readingListService.UpdateReadingList(c, id, req)
+	if err != nil {
+		return sendReadingListError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(list))
+}
+
+func (ra *readingListApi) deleteReadingList(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := ra.readingListService.DeleteReadingList(c, id); err != nil {
+		return sendReadingListError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Reading list deleted successfully"))
+}
+
+func (ra *readingListApi) addItem(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.ReadingListItemRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	list, err := ra.readingListService.AddItem(c, id, req)
+	if err != nil {
+		return sendReadingListError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(list))
+}
+
+func (ra *readingListApi) updateItem(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	bookID, err := uuid.Parse(ctx.Params("bookId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid book ID format"))
+	}
+
+	var req dto.ReadingListItemUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	list, err := ra.readingListService.UpdateItem(c, id, bookID, req)
+	if err != nil {
+		return sendReadingListError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(list))
+}
+
+func (ra *readingListApi) removeItem(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	bookID, err := uuid.Parse(ctx.Params("bookId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid book ID format"))
+	}
+
+	list, err := ra.readingListService.RemoveItem(c, id, bookID)
+	if err != nil {
+		return sendReadingListError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(list))
+}
+
+func (ra *readingListApi) reorder(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.ReadingListReorderRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	list, err := ra.readingListService.Reorder(c, id, req)
+	if err != nil {
+		return sendReadingListError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(list))
+}
+
+func sendReadingListError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Reading list not found"))
+	case errors.Is(err, constants.ErrBookNotFound), errors.Is(err, constants.ErrCustomerNotFound),
+		errors.Is(err, constants.ErrReadingListItemNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrReadingListItemExists):
+		return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrReadingListOrder), errors.Is(err, constants.ErrReadingListPublishRange):
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
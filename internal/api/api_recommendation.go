@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type recommendationApi struct {
+	recommendationService domain.RecommendationService
+}
+
+func NewRecommendationApi(app *fiber.App, authHandler fiber.Handler, recommendationService domain.RecommendationService) {
+	ra := recommendationApi{
+		recommendationService: recommendationService,
+	}
+
+	app.Get("/v1/books/:id/recommendations", authHandler, ra.getBookRecommendations)
+	app.Get("/v1/customers/:id/recommendations", authHandler, ra.getCustomerRecommendations)
+	app.Post("/v1/recommendations/rebuild", authHandler, middleware.RoleMiddleware(constants.RoleAdmin), ra.rebuild)
+}
+
+func (ra *recommendationApi) getBookRecommendations(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	recommendations, err := ra.recommendationService.GetBookRecommendations(c, id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Book not found"))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(recommendations))
+}
+
+func (ra *recommendationApi) getCustomerRecommendations(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer ID"))
+	}
+
+	recommendations, err := ra.recommendationService.GetCustomerRecommendations(c, id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Customer not found"))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(recommendations))
+}
+
+// rebuild runs the recommendation job now instead of waiting for the next
+// scheduled run.
+func (ra *recommendationApi) rebuild(ctx *fiber.Ctx) error {
+	report, err := ra.recommendationService.Rebuild(ctx.Context())
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(report))
+}
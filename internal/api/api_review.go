@@ -0,0 +1,232 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type reviewApi struct {
+	reviewService domain.ReviewService
+}
+
+func NewReviewApi(app *fiber.App, authHandler fiber.Handler, reviewService domain.ReviewService) {
+	ra := reviewApi{
+		reviewService: reviewService,
+	}
+
+	staffOnly := middleware.RoleMiddleware(constants.RoleAdmin, constants.RoleStaff)
+
+	app.Get("/v1/books/:id/reviews", authHandler, ra.getBookReviews)
+
+	reviewGroup := app.Group("/v1/reviews")
+
+	reviewGroup.Get("/", authHandler, staffOnly, ra.getAllReviews)
+	reviewGroup.Get("/queue", authHandler, staffOnly, ra.getModerationQueue)
+	reviewGroup.Get("/:id", authHandler, ra.getReviewByID)
+	// Staff write reviews on a customer's behalf here; patrons use /v1/me/reviews.
+	reviewGroup.Post("/", authHandler, staffOnly, ra.createReview)
+	reviewGroup.Put("/:id", authHandler, staffOnly, ra.updateReview)
+	reviewGroup.Post("/:id/moderate", authHandler, staffOnly, ra.moderateReview)
+	reviewGroup.Delete("/:id", authHandler, staffOnly, ra.deleteReview)
+}
+
+// getBookReviews lists the approved reviews of a book, newest first.
+func (ra *reviewApi) getBookReviews(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	bookID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	reviews, err := ra.reviewService.GetReviews(c, dto.ReviewFilter{BookID: &bookID, Status: constants.ReviewStatusApproved})
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(reviews))
+}
+
+func (ra *reviewApi) getAllReviews(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	filter := dto.ReviewFilter{Status: ctx.Query("status")}
+	if ctx.Query("book_id") != "" {
+		bookID, err := uuid.Parse(ctx.Query("book_id"))
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid book_id format"))
+		}
+		filter.BookID = &bookID
+	}
+	if ctx.Query("customer_id") != "" {
+		customerID, err := uuid.Parse(ctx.Query("customer_id"))
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer_id format"))
+		}
+		filter.CustomerID = &customerID
+	}
+
+	reviews, err := ra.reviewService.GetReviews(c, filter)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(reviews))
+}
+
+func (ra *reviewApi) getModerationQueue(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	reviews, err := ra.reviewService.GetReviews(c, dto.ReviewFilter{Status: constants.ReviewStatusPending})
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(reviews))
+}
+
+func (ra *reviewApi) getReviewByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	review, err := ra.reviewService.GetReviewByID(c, id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Review not found"))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(review))
+}
+
+func (ra *reviewApi) createReview(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.ReviewCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	review, err := ra.reviewService.CreateReview(c, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrBookNotFound), errors.Is(err, constants.ErrCustomerNotFound):
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrReviewNotEligible):
+			return ctx.Status(http.StatusForbidden).JSON(dto.NewResponseMessage(err.Error()))
+		case errors.Is(err, constants.ErrReviewExists):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(review))
+}
+
+func (ra *reviewApi) updateReview(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.ReviewUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	review, err := ra.reviewService.UpdateReview(c, id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Review not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(review))
+}
+
+func (ra *reviewApi) moderateReview(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	user := ctx.Locals("x-user").(dto.UserData)
+	moderatorID, err := uuid.Parse(user.Id)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	var req dto.ReviewModerationRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	review, err := ra.reviewService.ModerateReview(c, id, moderatorID, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Review not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(review))
+}
+
+func (ra *reviewApi) deleteReview(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := ra.reviewService.DeleteReview(c, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Review not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Review deleted successfully"))
+}
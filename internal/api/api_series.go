@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type seriesApi struct {
+	seriesService domain.SeriesService
+}
+
+func NewSeriesApi(app *fiber.App, authHandler fiber.Handler, seriesService domain.SeriesService) {
+	sa := seriesApi{
+		seriesService: seriesService,
+	}
+
+	seriesGroup := app.Group("/v1/series")
+
+	seriesGroup.Get("/", authHandler, sa.getAllSeries)
+	seriesGroup.Get("/:id", authHandler, sa.getSeriesByID)
+	seriesGroup.Post("/", authHandler, sa.createSeries)
+	seriesGroup.Put("/:id", authHandler, sa.updateSeries)
+	seriesGroup.Delete("/:id", authHandler, sa.deleteSeries)
+}
+
+func (sa *seriesApi) getAllSeries(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	series, err := sa.seriesService.GetSeries(c, ctx.Query("search"))
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(series))
+}
+
+func (sa *seriesApi) getSeriesByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	series, err := sa.seriesService.GetSeriesByID(c, id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Series not found"))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(series))
+}
+
+func (sa *seriesApi) createSeries(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.SeriesCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	series, err := sa.seriesService.CreateSeries(c, req)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage("Failed to create series"))
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(series))
+}
+
+func (sa *seriesApi) updateSeries(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.SeriesUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	series, err := sa.seriesService.UpdateSeries(c, id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Series not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(series))
+}
+
+func (sa *seriesApi) deleteSeries(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := sa.seriesService.DeleteSeries(c, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Series not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Series deleted successfully"))
+}
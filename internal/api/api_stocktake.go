@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type stocktakeApi struct {
+	stocktakeService domain.StocktakeService
+}
+
+func NewStocktakeApi(app *fiber.App, authHandler fiber.Handler, stocktakeService domain.StocktakeService) {
+	sa := stocktakeApi{
+		stocktakeService: stocktakeService,
+	}
+
+	staffOnly := middleware.RoleMiddleware(constants.RoleAdmin, constants.RoleStaff)
+
+	stocktakeGroup := app.Group("/v1/stocktakes")
+
+	stocktakeGroup.Get("/", authHandler, staffOnly, sa.getAllStocktakes)
+	stocktakeGroup.Get("/:id", authHandler, staffOnly, sa.getStocktakeByID)
+	stocktakeGroup.Get("/:id/report", authHandler, staffOnly, sa.getReport)
+	stocktakeGroup.Post("/", authHandler, staffOnly, sa.openStocktake)
+	stocktakeGroup.Post("/:id/scans", authHandler, staffOnly, sa.scan)
+	stocktakeGroup.Post("/:id/close", authHandler, staffOnly, sa.closeStocktake)
+	stocktakeGroup.Post("/:id/approve", authHandler, staffOnly, sa.approveStocktake)
+}
+
+func (sa *stocktakeApi) getAllStocktakes(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	stocktakes, err := sa.stocktakeService.GetStocktakes(c)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(stocktakes))
+}
+
+func (sa *stocktakeApi) getStocktakeByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	stocktake, err := sa.stocktakeService.GetStocktakeByID(c, id)
+	if err != nil {
+		return sendStocktakeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(stocktake))
+}
+
+func (sa *stocktakeApi) openStocktake(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.StocktakeCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	stocktake, err := sa.stocktakeService.OpenStocktake(c, userID, req)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(stocktake))
+}
+
+// scan accepts a JSON batch of codes, or a text/plain body with one code per
+// line as produced by handheld scanners.
+func (sa *stocktakeApi) scan(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var codes []string
+	if strings.HasPrefix(ctx.Get(fiber.HeaderContentType), fiber.MIMETextPlain) {
+		codes = strings.Split(strings.ReplaceAll(string(ctx.Body()), "\r\n", "\n"), "\n")
+	} else {
+		var req dto.StocktakeScanRequest
+		if err := ctx.BodyParser(&req); err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+		}
+
+		validationErrors := utils.Validate(req)
+		if len(validationErrors) > 0 {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+		}
+		codes = req.Codes
+	}
+
+	result, err := sa.stocktakeService.Scan(c, id, codes)
+	if err != nil {
+		return sendStocktakeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(result))
+}
+
+func (sa *stocktakeApi) closeStocktake(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	report, err := sa.stocktakeService.CloseStocktake(c, id)
+	if err != nil {
+		return sendStocktakeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(report))
+}
+
+func (sa *stocktakeApi) getReport(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	report, err := sa.stocktakeService.GetReport(c, id)
+	if err != nil {
+		return sendStocktakeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(report))
+}
+
+func (sa *stocktakeApi) approveStocktake(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	report, err := sa.stocktakeService.ApproveStocktake(c, id, userID)
+	if err != nil {
+		return sendStocktakeError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(report))
+}
+
+func sendStocktakeError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, constants.ErrStocktakeNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrStocktakeNotOpen), errors.Is(err, constants.ErrStocktakeNotClosed):
+		return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
+
+// currentUserID returns the ID of the authenticated user.
+func currentUserID(ctx *fiber.Ctx) (uuid.UUID, error) {
+	user, ok := ctx.Locals("x-user").(dto.UserData)
+	if !ok {
+		return uuid.Nil, errors.New("missing user")
+	}
+	return uuid.Parse(user.Id)
+}
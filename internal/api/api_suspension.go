@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type suspensionApi struct {
+	suspensionService domain.SuspensionService
+}
+
+func NewSuspensionApi(app *fiber.App, authHandler fiber.Handler, suspensionService domain.SuspensionService) {
+	sa := suspensionApi{
+		suspensionService: suspensionService,
+	}
+
+	staffOnly := middleware.RoleMiddleware(constants.RoleAdmin, constants.RoleStaff)
+
+	suspensionGroup := app.Group("/v1/customers/:id/suspensions")
+
+	suspensionGroup.Get("/", authHandler, staffOnly, sa.getSuspensions)
+	suspensionGroup.Post("/", authHandler, staffOnly, sa.suspend)
+	suspensionGroup.Put("/:suspensionId/lift", authHandler, staffOnly, sa.lift)
+}
+
+func (sa *suspensionApi) getSuspensions(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer ID"))
+	}
+
+	suspensions, err := sa.suspensionService.GetSuspensions(c, customerID)
+	if err != nil {
+		return sendSuspensionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(suspensions))
+}
+
+func (sa *suspensionApi) suspend(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer ID"))
+	}
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	var req dto.SuspensionCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	suspension, err := sa.suspensionService.Suspend(c, customerID, userID, req)
+	if err != nil {
+		return sendSuspensionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(suspension))
+}
+
+func (sa *suspensionApi) lift(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	customerID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid customer ID"))
+	}
+
+	id, err := uuid.Parse(ctx.Params("suspensionId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid suspension ID"))
+	}
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	suspension, err := sa.suspensionService.Lift(c, customerID, id, userID)
+	if err != nil {
+		return sendSuspensionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(suspension))
+}
+
+func sendSuspensionError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, constants.ErrCustomerNotFound), errors.Is(err, constants.ErrSuspensionNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrSuspensionNotActive):
+		return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrSuspensionExpiry):
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type transferApi struct {
+	transferService domain.TransferService
+}
+
+func NewTransferApi(app *fiber.App, authHandler fiber.Handler, transferService domain.TransferService) {
+	ta := transferApi{
+		transferService: transferService,
+	}
+
+	staffOnly := middleware.RoleMiddleware(constants.RoleAdmin, constants.RoleStaff)
+
+	app.Get("/v1/branches/:id/transfer-queue", authHandler, staffOnly, ta.getQueue)
+
+	transferGroup := app.Group("/v1/transfers")
+
+	transferGroup.Get("/", authHandler, staffOnly, ta.getAllTransfers)
+	transferGroup.Get("/:id", authHandler, staffOnly, ta.getTransferByID)
+	transferGroup.Post("/", authHandler, staffOnly, ta.requestTransfer)
+	transferGroup.Post("/:id/ship", authHandler, staffOnly, ta.shipTransfer)
+	transferGroup.Post("/:id/receive", authHandler, staffOnly, ta.receiveTransfer)
+	transferGroup.Post("/:id/cancel", authHandler, staffOnly, ta.cancelTransfer)
+}
+
+func (ta *transferApi) getAllTransfers(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	filter := dto.TransferFilter{
+		Direction: ctx.Query("direction"),
+		Status:    ctx.Query("status"),
+		StockCode: ctx.Query("stock_code"),
+	}
+
+	if filter.Direction != "" && filter.Direction != constants.TransferDirectionIncoming && filter.Direction != constants.TransferDirectionOutgoing {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid direction value, use incoming or outgoing"))
+	}
+
+	if ctx.Query("branch_id") != "" {
+		branchID, err := uuid.Parse(ctx.Query("branch_id"))
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid branch_id format"))
+		}
+		filter.BranchID = &branchID
+	}
+
+	transfers, err := ta.transferService.GetTransfers(c, filter)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(transfers))
+}
+
+// getQueue lists what a branch desk has to receive and to ship.
+func (ta *transferApi) getQueue(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	queue, err := ta.transferService.GetQueue(c, id)
+	if err != nil {
+		return sendTransferError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(queue))
+}
+
+func (ta *transferApi) getTransferByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	transfer, err := ta.transferService.GetTransferByID(c, id)
+	if err != nil {
+		return sendTransferError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(transfer))
+}
+
+func (ta *transferApi) requestTransfer(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.TransferCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	transfer, err := ta.transferService.RequestTransfer(c, userID, req)
+	if err != nil {
+		return sendTransferError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(transfer))
+}
+
+func (ta *transferApi) shipTransfer(ctx *fiber.Ctx) error {
+	return ta.changeStatus(ctx, ta.transferService.ShipTransfer)
+}
+
+func (ta *transferApi) receiveTransfer(ctx *fiber.Ctx) error {
+	return ta.changeStatus(ctx, ta.transferService.ReceiveTransfer)
+}
+
+func (ta *transferApi) cancelTransfer(ctx *fiber.Ctx) error {
+	return ta.changeStatus(ctx, ta.transferService.CancelTransfer)
+}
+
+func (ta *transferApi) changeStatus(ctx *fiber.Ctx, change func(context.Context, uuid.UUID) (*dto.TransferResponse, error)) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	transfer, err := change(c, id)
+	if err != nil {
+		return sendTransferError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(transfer))
+}
+
+func sendTransferError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, constants.ErrTransferNotFound), errors.Is(err, constants.ErrBookstockNotFound),
+		errors.Is(err, constants.ErrBranchNotFound), errors.Is(err, constants.ErrShelfNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrShelfNotInBranch), errors.Is(err, constants.ErrTransferNoLocation),
+		errors.Is(err, constants.ErrTransferSameBranch):
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrTransferExists), errors.Is(err, constants.ErrTransferNotRequested),
+		errors.Is(err, constants.ErrTransferNotInTransit), errors.Is(err, constants.ErrStockOnHold):
+		return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
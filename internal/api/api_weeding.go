@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/middleware"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type weedingApi struct {
+	weedingService domain.WeedingService
+}
+
+func NewWeedingApi(app *fiber.App, authHandler fiber.Handler, weedingService domain.WeedingService) {
+	wa := weedingApi{
+		weedingService: weedingService,
+	}
+
+	staffOnly := middleware.RoleMiddleware(constants.RoleAdmin, constants.RoleStaff)
+
+	weedingGroup := app.Group("/v1/weeding")
+
+	weedingGroup.Get("/candidates", authHandler, staffOnly, wa.getCandidates)
+	weedingGroup.Get("/deaccessions", authHandler, staffOnly, wa.getAllDeaccessions)
+	weedingGroup.Get("/deaccessions/:id", authHandler, staffOnly, wa.getDeaccessionByID)
+	weedingGroup.Post("/deaccessions", authHandler, staffOnly, wa.createDeaccession)
+	weedingGroup.Post("/deaccessions/:id/approve", authHandler, staffOnly, wa.approveDeaccession)
+	weedingGroup.Post("/deaccessions/:id/reject", authHandler, staffOnly, wa.rejectDeaccession)
+}
+
+func (wa *weedingApi) getCandidates(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	var filter dto.WeedingFilter
+	if ctx.Query("years") != "" {
+		years, err := strconv.Atoi(ctx.Query("years"))
+		if err != nil || years < 1 {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid years value"))
+		}
+		filter.Years = years
+	}
+	if ctx.Query("limit") != "" {
+		limit, err := strconv.Atoi(ctx.Query("limit"))
+		if err != nil || limit < 1 {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid limit value"))
+		}
+		filter.Limit = limit
+	}
+	if ctx.Query("branch_id") != "" {
+		branchID, err := uuid.Parse(ctx.Query("branch_id"))
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid branch_id format"))
+		}
+		filter.BranchID = &branchID
+	}
+	if ctx.Query("shelf_id") != "" {
+		shelfID, err := uuid.Parse(ctx.Query("shelf_id"))
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid shelf_id format"))
+		}
+		filter.ShelfID = &shelfID
+	}
+
+	candidates, err := wa.weedingService.GetCandidates(c, filter)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(candidates))
+}
+
+func (wa *weedingApi) getAllDeaccessions(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	deaccessions, err := wa.weedingService.GetDeaccessions(c, ctx.Query("status"))
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(deaccessions))
+}
+
+func (wa *weedingApi) getDeaccessionByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	deaccession, err := wa.weedingService.GetDeaccessionByID(c, id)
+	if err != nil {
+		return sendWeedingError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(deaccession))
+}
+
+func (wa *weedingApi) createDeaccession(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	var req dto.DeaccessionCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	deaccession, report, err := wa.weedingService.CreateDeaccession(c, userID, req)
+	if err != nil {
+		if errors.Is(err, constants.ErrBulkRejected) {
+			return ctx.Status(http.StatusUnprocessableEntity).JSON(dto.ResponseData[*dto.BookstockBulkReport]{
+				Timestamp: time.Now(),
+				Message:   err.Error(),
+				Data:      report,
+			})
+		}
+		return sendWeedingError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(deaccession))
+}
+
+func (wa *weedingApi) approveDeaccession(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	deaccession, err := wa.weedingService.ApproveDeaccession(c, id, userID)
+	if err != nil {
+		return sendWeedingError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(deaccession))
+}
+
+func (wa *weedingApi) rejectDeaccession(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
+	}
+
+	deaccession, err := wa.weedingService.RejectDeaccession(c, id, userID)
+	if err != nil {
+		return sendWeedingError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(deaccession))
+}
+
+func sendWeedingError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, constants.ErrDeaccessionNotFound):
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage(err.Error()))
+	case errors.Is(err, constants.ErrDeaccessionNotPending):
+		return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage(err.Error()))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+}
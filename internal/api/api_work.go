@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/utils"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type workApi struct {
+	workService domain.WorkService
+}
+
+func NewWorkApi(app *fiber.App, authHandler fiber.Handler, workService domain.WorkService) {
+	wa := workApi{
+		workService: workService,
+	}
+
+	workGroup := app.Group("/v1/works")
+
+	workGroup.Get("/", authHandler, wa.getAllWorks)
+	workGroup.Get("/:id", authHandler, wa.getWorkByID)
+	workGroup.Post("/", authHandler, wa.createWork)
+	workGroup.Put("/:id", authHandler, wa.updateWork)
+	workGroup.Delete("/:id", authHandler, wa.deleteWork)
+}
+
+func (wa *workApi) getAllWorks(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	works, err := wa.workService.GetWorks(c, ctx.Query("search"))
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(works))
+}
+
+func (wa *workApi) getWorkByID(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	work, err := wa.workService.GetWorkByID(c, id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Work not found"))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(work))
+}
+
+func (wa *workApi) createWork(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	var req dto.WorkCreateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	work, err := wa.workService.CreateWork(c, req)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage("Failed to create work"))
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(dto.NewResponseData(work))
+}
+
+func (wa *workApi) updateWork(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	var req dto.WorkUpdateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+	}
+
+	validationErrors := utils.Validate(req)
+	if len(validationErrors) > 0 {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage(validationErrors))
+	}
+
+	work, err := wa.workService.UpdateWork(c, id, req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Work not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseData(work))
+}
+
+func (wa *workApi) deleteWork(ctx *fiber.Ctx) error {
+	c, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid ID format"))
+	}
+
+	if err := wa.workService.DeleteWork(c, id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return ctx.Status(http.StatusNotFound).JSON(dto.NewResponseMessage("Work not found"))
+		case errors.Is(err, constants.ErrHasDependents):
+			return ctx.Status(http.StatusConflict).JSON(dto.NewResponseMessage("Work still has active holds"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(dto.NewResponseMessage(err.Error()))
+	}
+
+	return ctx.Status(http.StatusOK).JSON(dto.NewResponseMessage("Work deleted successfully"))
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// sendDeleteBlocked writes a 409 listing the dependent records when err is a
+// refused delete, and reports whether it handled the error.
+func sendDeleteBlocked(ctx *fiber.Ctx, err error) (bool, error) {
+	var blocked *domain.DeleteBlockedError
+	if !errors.As(err, &blocked) {
+		return false, nil
+	}
+
+	return true, ctx.Status(http.StatusConflict).JSON(dto.ResponseData[[]dto.DependentRecord]{
+		Timestamp: time.Now(),
+		Message:   blocked.Error(),
+		Data:      blocked.Dependents,
+	})
+}
@@ -2,22 +2,36 @@ package config
 
 import (
 	"flag"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/stockcode"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/lpernett/godotenv"
 )
 
 type Config struct {
-	Server   Server
-	Database Database
-	Secret   Secret
-	File     File
+	Server         Server
+	Database       Database
+	Secret         Secret
+	File           File
+	Trash          Trash
+	Delete         Delete
+	Recommendation Recommendation
+	StockCode      StockCode
+	Charge         Charge
+	Circulation    Circulation
+	Membership     Membership
 }
 
 type Server struct {
-	Host string
-	Port string
+	Host      string
+	Port      string
+	BodyLimit int
+	// ImportLimit caps streamed import uploads, which skip BodyLimit.
+	ImportLimit int
 }
 
 type Database struct {
@@ -33,6 +47,51 @@ type Secret struct {
 	Jwt string
 }
 
+// Delete holds the delete rule (block, cascade or archive) per entity.
+type Delete struct {
+	Book      string
+	Bookstock string
+	Customer  string
+}
+
+// Recommendation configures the batch job that precomputes "borrowed
+// together" suggestions. Pairs borrowed together by fewer than MinCoBorrow
+// distinct customers are never stored. Below two a suggestion could reveal
+// what a single customer borrowed, so smaller values stop startup.
+type Recommendation struct {
+	IntervalHours int
+	MinCoBorrow   int
+	Limit         int
+}
+
+// StockCode configures generated copy codes, e.g. BK-01-0000427.
+type StockCode struct {
+	Prefix string
+	Branch string
+	Digits int
+}
+
+// Charge configures lost-item charges. DefaultReplacementCost is charged for
+// copies without a recorded replacement cost.
+type Charge struct {
+	DefaultReplacementCost float64
+}
+
+// Circulation holds lending rules. RestrictedMinAge applies to restricted
+// items whose book sets no minimum age.
+type Circulation struct {
+	RestrictedMinAge int
+}
+
+// Membership sets how long new and renewed memberships last.
+type Membership struct {
+	Months int
+}
+
+type Trash struct {
+	RetentionDays int
+}
+
 type File struct {
 	MaxUploadSize string
 	UploadPath    string
@@ -56,8 +115,10 @@ func Get() *Config {
 
 	return &Config{
 		Server: Server{
-			Host: os.Getenv("SERVER_HOST"),
-			Port: os.Getenv("SERVER_PORT"),
+			Host:        os.Getenv("SERVER_HOST"),
+			Port:        os.Getenv("SERVER_PORT"),
+			BodyLimit:   envInt("BODY_LIMIT", 4) * 1024 * 1024,
+			ImportLimit: envInt("IMPORT_BODY_LIMIT", 64) * 1024 * 1024,
 		},
 		Database: Database{
 			Host: os.Getenv("DB_HOST"),
@@ -75,5 +136,96 @@ func Get() *Config {
 			LinkCover:     os.Getenv("LINK_COVER"),
 			UploadPath:    os.Getenv("UPLOAD_PATH"),
 		},
+		Trash: Trash{
+			RetentionDays: envInt("TRASH_RETENTION_DAYS", 30),
+		},
+		Delete: Delete{
+			Book:      envChoice("DELETE_RULE_BOOK", constants.DeleteRuleBlock, deleteRules...),
+			Bookstock: envChoice("DELETE_RULE_BOOKSTOCK", constants.DeleteRuleBlock, deleteRules...),
+			Customer:  envChoice("DELETE_RULE_CUSTOMER", constants.DeleteRuleBlock, deleteRules...),
+		},
+		Recommendation: Recommendation{
+			IntervalHours: envInt("RECOMMENDATION_INTERVAL_HOURS", 24),
+			MinCoBorrow:   envIntAtLeast("RECOMMENDATION_MIN_CO_BORROW", 3, 2),
+			Limit:         envInt("RECOMMENDATION_LIMIT", 20),
+		},
+		StockCode: StockCode{
+			Prefix: envCodePart("STOCK_CODE_PREFIX", "BK"),
+			Branch: envCodePart("STOCK_CODE_BRANCH", "01"),
+			Digits: envIntAtLeast("STOCK_CODE_DIGITS", 6, 1),
+		},
+		Circulation: Circulation{
+			RestrictedMinAge: envInt("CIRCULATION_RESTRICTED_MIN_AGE", 18),
+		},
+		Charge: Charge{
+			DefaultReplacementCost: envFloat("CHARGE_DEFAULT_REPLACEMENT_COST", 0),
+		},
+		Membership: Membership{
+			Months: envInt("MEMBERSHIP_MONTHS", 12),
+		},
+	}
+}
+
+// envInt reads an integer environment variable, falling back to def when it
+// is unset or not a number.
+func envInt(key string, def int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// envIntAtLeast reads a numeric environment variable like envInt, stopping
+// startup when the value is below minimum.
+func envIntAtLeast(key string, def int, minimum int) int {
+	value := envInt(key, def)
+	if value < minimum {
+		log.Fatalf("%s must be at least %d, got %d", key, minimum, value)
+	}
+	return value
+}
+
+// envFloat reads a decimal environment variable, falling back to def when it
+// is unset or not a number.
+func envFloat(key string, def float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+var deleteRules = []string{constants.DeleteRuleBlock, constants.DeleteRuleCascade, constants.DeleteRuleArchive}
+
+// envChoice reads an environment variable that must be one of allowed,
+// falling back to def when unset. Any other value stops startup, so a typo
+// never falls through to an unintended behaviour.
+func envChoice(key string, def string, allowed ...string) string {
+	value := envString(key, def)
+	for _, choice := range allowed {
+		if value == choice {
+			return value
+		}
+	}
+	log.Fatalf("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+	return ""
+}
+
+// envCodePart reads a stock code prefix or branch, stopping startup when it
+// would produce codes that cannot be read back.
+func envCodePart(key string, def string) string {
+	value := envString(key, def)
+	if !stockcode.ValidPart(value) {
+		log.Fatalf("%s must not contain \"-\", got %q", key, value)
+	}
+	return value
+}
+
+// envString reads an environment variable, falling back to def when unset.
+func envString(key string, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
 	}
+	return def
 }
@@ -2,9 +2,11 @@ package connection
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"go-rest-api/domain"
 	"go-rest-api/internal/config"
+	"go-rest-api/internal/constants"
 	"log"
 
 	_ "github.com/lib/pq"
@@ -42,9 +44,72 @@ func GetDatabase(conf config.Database) (*sql.DB, *gorm.DB) {
 }
 
 func autoMigrate(DB *gorm.DB) {
-	err := DB.AutoMigrate(&domain.User{}, &domain.Book{}, &domain.BookStock{}, &domain.Media{}, &domain.BookTransaction{}, &domain.Charge{}, &domain.Customer{})
+	err := DB.AutoMigrate(&domain.User{}, &domain.Branch{}, &domain.ShelfLocation{}, &domain.Work{}, &domain.Series{}, &domain.Book{}, &domain.BookStock{}, &domain.Media{}, &domain.BookTransaction{}, &domain.Charge{}, &domain.Customer{},
+		&domain.Hold{}, &domain.Transfer{}, &domain.Review{}, &domain.ReadingList{}, &domain.ReadingListItem{},
+		&domain.BookRecommendation{}, &domain.CustomerRecommendation{}, &domain.StockCodeSequence{}, &domain.BookStockStatusLog{},
+		&domain.Stocktake{}, &domain.StocktakeScan{}, &domain.StocktakeMissing{}, &domain.Vendor{}, &domain.Fund{}, &domain.PurchaseOrder{}, &domain.PurchaseOrderLine{},
+		&domain.Deaccession{}, &domain.DeaccessionItem{}, &domain.MembershipTier{}, &domain.Suspension{}, &domain.CustomerMerge{})
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
+	migrateLegacyRoles(DB)
+	seedMembershipTiers(DB)
 	fmt.Println("✅ Database migrated successfully!")
 }
+
+// migrateLegacyRoles moves accounts from before role checks, which were all
+// saved as "user", to STAFF. When no administrator exists the oldest staff
+// account is promoted, so an upgraded install keeps someone who can manage
+// accounts and the token-less first registration stays closed.
+func migrateLegacyRoles(DB *gorm.DB) {
+	err := DB.Model(&domain.User{}).
+		Where("role IN ?", []string{"user", constants.RoleUser}).
+		Update("role", constants.RoleStaff).Error
+	if err != nil {
+		log.Fatal("Failed to migrate user roles:", err)
+	}
+
+	var admins int64
+	if err := DB.Model(&domain.User{}).Where("role = ?", constants.RoleAdmin).Count(&admins).Error; err != nil {
+		log.Fatal("Failed to migrate user roles:", err)
+	}
+	if admins > 0 {
+		return
+	}
+
+	var oldest domain.User
+	err = DB.Where("role = ?", constants.RoleStaff).Order("created_at").First(&oldest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return
+	}
+	if err != nil {
+		log.Fatal("Failed to migrate user roles:", err)
+	}
+	if err := DB.Model(&domain.User{}).Where("id = ?", oldest.ID).Update("role", constants.RoleAdmin).Error; err != nil {
+		log.Fatal("Failed to migrate user roles:", err)
+	}
+	log.Printf("Promoted %s to %s, the oldest account, as no administrator existed", oldest.Email, constants.RoleAdmin)
+}
+
+// seedMembershipTiers creates the starting membership tiers on a fresh
+// database. Once any tier exists they are left to administrators.
+func seedMembershipTiers(DB *gorm.DB) {
+	var count int64
+	if err := DB.Model(&domain.MembershipTier{}).Count(&count).Error; err != nil {
+		log.Fatal("Failed to seed membership tiers:", err)
+	}
+	if count > 0 {
+		return
+	}
+
+	tiers := []domain.MembershipTier{
+		{Code: constants.MembershipTypeStandard, Name: "Adult", LoanLimit: 10, LoanPeriodDays: 21, RenewalLimit: 2, DailyFine: constants.DefaultDailyLateFee, HoldLimit: 5},
+		{Code: "student", Name: "Student", LoanLimit: 8, LoanPeriodDays: 28, RenewalLimit: 3, DailyFine: constants.DefaultDailyLateFee * 0.4, HoldLimit: 5},
+		{Code: "senior", Name: "Senior", LoanLimit: 10, LoanPeriodDays: 28, RenewalLimit: 3, DailyFine: 0, HoldLimit: 5},
+		{Code: "child", Name: "Child", LoanLimit: 5, LoanPeriodDays: 21, RenewalLimit: 2, DailyFine: 0, HoldLimit: 3},
+		{Code: "staff", Name: "Staff", LoanLimit: 25, LoanPeriodDays: 42, RenewalLimit: 5, DailyFine: 0, HoldLimit: 10},
+	}
+	if err := DB.Create(&tiers).Error; err != nil {
+		log.Fatal("Failed to seed membership tiers:", err)
+	}
+}
@@ -7,6 +7,8 @@ const (
 	RoleAdmin = "ADMIN"
 	RoleStaff = "STAFF"
 	RoleUser  = "USER"
+	// RolePatron accounts belong to a customer and may only use /v1/me.
+	RolePatron = "PATRON"
 )
 
 // BookStock status
@@ -15,6 +17,137 @@ const (
 	BookStockStatusBorrowed  = "BORROWED"
 	BookStockStatusDamaged   = "DAMAGED"
 	BookStockStatusLost      = "LOST"
+	BookStockStatusArchived  = "ARCHIVED"
+	BookStockStatusInTransit = "IN_TRANSIT"
+	BookStockStatusWithdrawn = "WITHDRAWN"
+)
+
+// Item types decide how a copy circulates. A copy without its own type
+// follows its book.
+const (
+	ItemTypeCirculating = "circulating"
+	ItemTypeReference   = "reference"
+	ItemTypeInLibrary   = "in_library"
+	ItemTypeRestricted  = "restricted"
+)
+
+// Copy condition grades
+const (
+	ConditionNew  = "new"
+	ConditionGood = "good"
+	ConditionWorn = "worn"
+	ConditionPoor = "poor"
+)
+
+// MembershipTypeStandard is the tier new customers join by default. Tiers
+// themselves are managed in the database.
+const MembershipTypeStandard = "standard"
+
+// Suspension reasons and statuses. A suspension is expired once its expiry
+// has passed, which lifts it without staff action.
+const (
+	SuspensionReasonUnpaidFines = "unpaid_fines"
+	SuspensionReasonBehaviour   = "behaviour"
+	SuspensionReasonLostCard    = "lost_card"
+	SuspensionReasonOther       = "other"
+
+	SuspensionStatusActive  = "ACTIVE"
+	SuspensionStatusExpired = "EXPIRED"
+	SuspensionStatusLifted  = "LIFTED"
+)
+
+// PatronClaimValidHours is how long a staff-issued claim code can be used to
+// register a patron account.
+const PatronClaimValidHours = 72
+
+// Duplicate detection. Pairs are compared only within buckets of customers
+// sharing a name word; words shared by more customers than the bucket limit
+// are too common to pair on.
+const (
+	DuplicateReasonEmail     = "same_email"
+	DuplicateReasonPhone     = "same_phone"
+	DuplicateReasonName      = "similar_name"
+	DuplicateReasonBirthDate = "same_birth_date"
+	DuplicateNameSimilarity  = 0.85
+	DuplicateCandidateLimit  = 200
+	DuplicateNameBucketLimit = 500
+)
+
+// Notification channels a customer can prefer
+const (
+	NotificationChannelEmail = "email"
+	NotificationChannelSMS   = "sms"
+	NotificationChannelPost  = "post"
+	NotificationChannelNone  = "none"
+)
+
+// Deaccession status
+const (
+	DeaccessionStatusPending  = "PENDING"
+	DeaccessionStatusApproved = "APPROVED"
+	DeaccessionStatusRejected = "REJECTED"
+)
+
+// Weeding candidate reasons and defaults
+const (
+	WeedingReasonNotBorrowed = "not_borrowed"
+	WeedingReasonDamaged     = "damaged"
+	WeedingReasonPoor        = "poor_condition"
+	WeedingDefaultYears      = 5
+	WeedingCandidateLimit    = 500
+)
+
+// Transfer status and reason
+const (
+	TransferStatusRequested = "REQUESTED"
+	TransferStatusInTransit = "IN_TRANSIT"
+	TransferStatusReceived  = "RECEIVED"
+	TransferStatusCancelled = "CANCELLED"
+
+	TransferReasonManual = "MANUAL"
+	TransferReasonHold   = "HOLD"
+
+	TransferDirectionIncoming = "incoming"
+	TransferDirectionOutgoing = "outgoing"
+)
+
+// Purchase order status
+const (
+	PurchaseOrderStatusDraft     = "DRAFT"
+	PurchaseOrderStatusOrdered   = "ORDERED"
+	PurchaseOrderStatusPartial   = "PARTIAL"
+	PurchaseOrderStatusReceived  = "RECEIVED"
+	PurchaseOrderStatusCancelled = "CANCELLED"
+)
+
+// Charge kinds
+const (
+	ChargeKindLateFee  = "LATE_FEE"
+	ChargeKindLostItem = "LOST_ITEM"
+)
+
+// Delete rules. Active loans and outstanding charges block a delete under
+// every rule; the rule decides what happens to the remaining dependents.
+const (
+	DeleteRuleBlock   = "block"   // Refuse while any dependent record exists
+	DeleteRuleCascade = "cascade" // Delete copies too, archiving those with loan history
+	DeleteRuleArchive = "archive" // Keep the record and its copies, marked ARCHIVED
+)
+
+// Status log reasons for copies archived and restored with their book
+const (
+	StatusReasonBookArchived = "Archived with its book"
+	StatusReasonBookRestored = "Restored with its book"
+)
+
+// Dependent record types
+const (
+	DependentActiveLoan        = "active_loan"
+	DependentOutstandingCharge = "outstanding_charge"
+	DependentCopy              = "copy"
+	DependentLoanHistory       = "loan_history"
+	DependentActiveTransfer    = "active_transfer"
+	DependentActiveHold        = "active_hold"
 )
 
 // BookTransaction status
@@ -22,24 +155,258 @@ const (
 	BookTransactionStatusAvailable = "AVAILABLE"
 	BookTransactionStatusBorrowed  = "BORROWED"
 	BookTransactionStatusOverdue   = "OVERDUE"
+	BookTransactionStatusLost      = "LOST"
+)
+
+// Hold status
+const (
+	HoldStatusWaiting   = "WAITING"
+	HoldStatusReady     = "READY"
+	HoldStatusFulfilled = "FULFILLED"
+	HoldStatusCancelled = "CANCELLED"
+	HoldStatusExpired   = "EXPIRED"
+)
+
+// HoldPickupDays is how long a ready hold keeps its copy set aside before it
+// expires and the copy goes to the next customer waiting.
+const HoldPickupDays = 7
+
+// Stocktake status
+const (
+	StocktakeStatusOpen     = "OPEN"
+	StocktakeStatusClosed   = "CLOSED"
+	StocktakeStatusApproved = "APPROVED"
+)
+
+// Review status
+const (
+	ReviewStatusPending  = "PENDING"
+	ReviewStatusApproved = "APPROVED"
+	ReviewStatusRejected = "REJECTED"
+)
+
+// Reading list kinds and visibility
+const (
+	ReadingListKindCurated  = "CURATED"
+	ReadingListKindWishlist = "WISHLIST"
+
+	ReadingListVisibilityPublic  = "public"
+	ReadingListVisibilityStaff   = "staff"
+	ReadingListVisibilityPrivate = "private" // Drafts and personal wishlists
+)
+
+// Book formats
+const (
+	BookFormatHardcover = "hardcover"
+	BookFormatPaperback = "paperback"
+	BookFormatEbook     = "ebook"
+	BookFormatAudiobook = "audiobook"
+	BookFormatOther     = "other"
+)
+
+// Copy location filters
+const (
+	LocationHome    = "home"
+	LocationCurrent = "current"
+)
+
+// Per-item results of bulk copy operations
+const (
+	BulkItemCreated   = "created"
+	BulkItemUpdated   = "updated"
+	BulkItemUnchanged = "unchanged"
+	BulkItemFailed    = "failed"
+)
+
+// Barcodes and label sheets
+const (
+	BarcodeTypeCode128 = "code128"
+	BarcodeTypeQR      = "qr"
+
+	BarcodeFormatPNG = "png"
+	BarcodeFormatSVG = "svg"
+
+	BarcodeDefaultWidth  = 400 // Pixels
+	BarcodeDefaultHeight = 120 // Pixels, QR codes use the width for both sides
+	BarcodeMaxSize       = 2000
+
+	LabelDefaultColumns = 3 // 70 x 37 mm labels on A4
+	LabelDefaultRows    = 8
+	LabelPageMargin     = 10.0 // Millimetres
+)
+
+// Book catalog facets
+const (
+	BookFacetAvailability    = "availability"
+	BookFacetCategory        = "category"
+	BookFacetAuthor          = "author"
+	BookFacetPublisher       = "publisher"
+	BookFacetLanguage        = "language"
+	BookFacetPublicationYear = "publication_year"
+
+	BookFacetValueAvailable   = "available"
+	BookFacetValueUnavailable = "unavailable"
+
+	BookFacetLimit      = 20 // Maximum values returned per facet
+	BookFacetYearBucket = 10 // Publication years are grouped per decade
+)
+
+// Book catalog sort orders
+const (
+	BookSortNewest = "newest"
+	BookSortTitle  = "title"
+	BookSortVolume = "volume" // Series volume order
+	BookSortRating = "rating" // Highest rated first
+
+	BookCollapseWork = "work" // Show one edition per work
+)
+
+// Book CSV import
+const (
+	BookImportModeAtomic  = "atomic"
+	BookImportModeChunked = "chunked"
+
+	BookImportErrorInvalid      = "invalid"
+	BookImportErrorDuplicate    = "duplicate"
+	BookImportErrorUnknownCover = "unknown_cover"
+	BookImportErrorDatabase     = "database"
+
+	BookImportChunkSize = 500 // Books validated and written per chunk
+	BookExportBatchSize = 500 // Books read per export batch
+)
+
+// MARC import
+const (
+	MARCFormatBinary = "binary"
+	MARCFormatXML    = "xml"
+
+	MARCRecordImported  = "imported"
+	MARCRecordValid     = "valid"
+	MARCRecordDuplicate = "duplicate"
+	MARCRecordInvalid   = "invalid"
+)
+
+// OPDS catalog
+const (
+	OPDSPageSize           = 25
+	OPDSNavigationType     = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	OPDSAcquisitionType    = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	OPDSEntryType          = "application/atom+xml;type=entry;profile=opds-catalog"
+	OPDSOpenSearchType     = "application/opensearchdescription+xml"
+	OPDSRelImage           = "http://opds-spec.org/image"
+	OPDSRelThumbnail       = "http://opds-spec.org/image/thumbnail"
+	OPDSRelBorrow          = "http://opds-spec.org/acquisition/borrow"
+	OPDSRelSortNew         = "http://opds-spec.org/sort/new"
+	OPDSRelSubsection      = "subsection"
+	OPDSRelSearch          = "search"
+	OPDSRelSelf            = "self"
+	OPDSRelStart           = "start"
+	OPDSRelNext            = "next"
+	OPDSRelPrevious        = "previous"
+	OPDSAtomNamespace      = "http://www.w3.org/2005/Atom"
+	OPDSNamespace          = "http://opds-spec.org/2010/catalog"
+	OPDSDublinCoreNS       = "http://purl.org/dc/terms/"
+	OPDSOpenSearchNS       = "http://a9.com/-/spec/opensearch/1.1/"
+	OPDSCatalogTitle       = "Library Catalog"
+	OPDSCatalogIDNamespace = "urn:library:opds"
 )
 
 // Error messages
 var (
-	ErrInvalidCredentials      = errors.New("invalid email or password")
-	ErrUserNotFound            = errors.New("user not found")
-	ErrCustomerNotFound        = errors.New("customer not found")
-	ErrBookNotFound            = errors.New("book not found")
-	ErrBookstockNotFound       = errors.New("book stock not found")
-	ErrBookTransactionNotFound = errors.New("book_transaction not found")
-	ErrMediaNotFound           = errors.New("media not found")
-	ErrChargeNotFound          = errors.New("charge not found")
-	ErrBookNotAvailable        = errors.New("book is not available")
-	ErrInternalServer          = errors.New("internal server error")
-	ErrUnauthorized            = errors.New("unauthorized access")
-	ErrForbidden               = errors.New("forbidden access")
-	ErrEmailAlreadyExists      = errors.New("email already exists")
-	ErrInvalidCredential       = errors.New("invalid credential")
+	ErrInvalidCredentials        = errors.New("invalid email or password")
+	ErrUserNotFound              = errors.New("user not found")
+	ErrCustomerNotFound          = errors.New("customer not found")
+	ErrBookNotFound              = errors.New("book not found")
+	ErrBookstockNotFound         = errors.New("book stock not found")
+	ErrBookTransactionNotFound   = errors.New("book_transaction not found")
+	ErrMediaNotFound             = errors.New("media not found")
+	ErrChargeNotFound            = errors.New("charge not found")
+	ErrChargePaid                = errors.New("charge has already been paid")
+	ErrBookNotAvailable          = errors.New("book is not available")
+	ErrInternalServer            = errors.New("internal server error")
+	ErrUnauthorized              = errors.New("unauthorized access")
+	ErrForbidden                 = errors.New("forbidden access")
+	ErrEmailAlreadyExists        = errors.New("email already exists")
+	ErrInvalidCredential         = errors.New("invalid credential")
+	ErrRetentionNotElapsed       = errors.New("retention period has not passed yet")
+	ErrHasDependents             = errors.New("record still has dependent transactions or copies")
+	ErrDeleteBlocked             = errors.New("delete blocked by dependent records")
+	ErrWorkNotFound              = errors.New("work not found")
+	ErrSeriesNotFound            = errors.New("series not found")
+	ErrHoldNotFound              = errors.New("hold not found")
+	ErrHoldExists                = errors.New("customer already has an active hold on this title")
+	ErrHoldNotActive             = errors.New("hold is no longer active")
+	ErrReviewNotFound            = errors.New("review not found")
+	ErrReviewExists              = errors.New("customer has already reviewed this book")
+	ErrReviewNotEligible         = errors.New("only customers who have returned this book can review it")
+	ErrReadingListNotFound       = errors.New("reading list not found")
+	ErrReadingListItemExists     = errors.New("book is already on this reading list")
+	ErrReadingListItemNotFound   = errors.New("book is not on this reading list")
+	ErrReadingListOrder          = errors.New("book_ids must list every book on the reading list exactly once")
+	ErrReadingListPublishRange   = errors.New("publish_until must be after publish_from")
+	ErrUnsupportedBarcode        = errors.New("unsupported barcode type or format")
+	ErrBulkRejected              = errors.New("some items failed, nothing was changed")
+	ErrBookstockOnLoan           = errors.New("book stock is on loan, return it first")
+	ErrBranchNotFound            = errors.New("branch not found")
+	ErrBranchExists              = errors.New("branch code already exists")
+	ErrBranchInUse               = errors.New("branch still has copies")
+	ErrShelfNotFound             = errors.New("shelf not found")
+	ErrShelfExists               = errors.New("shelf code already exists in this branch")
+	ErrShelfInUse                = errors.New("shelf still has copies")
+	ErrShelfNotInBranch          = errors.New("shelf does not belong to the branch")
+	ErrBookstockInTransit        = errors.New("book stock is in transit between branches")
+	ErrTransferNotFound          = errors.New("transfer not found")
+	ErrTransferExists            = errors.New("copy already has an active transfer")
+	ErrTransferNoLocation        = errors.New("copy has no current branch to transfer from")
+	ErrTransferSameBranch        = errors.New("copy is already at the destination branch")
+	ErrTransferNotRequested      = errors.New("transfer is not waiting to be shipped")
+	ErrTransferNotInTransit      = errors.New("transfer is not in transit")
+	ErrVendorNotFound            = errors.New("vendor not found")
+	ErrVendorInUse               = errors.New("vendor has purchase orders")
+	ErrFundNotFound              = errors.New("fund not found")
+	ErrFundExists                = errors.New("fund code already exists for this fiscal year")
+	ErrFundInUse                 = errors.New("fund is used by purchase order lines")
+	ErrPurchaseOrderNotFound     = errors.New("purchase order not found")
+	ErrPurchaseOrderExists       = errors.New("purchase order number already exists")
+	ErrPurchaseOrderNotDraft     = errors.New("only draft purchase orders can be changed")
+	ErrPurchaseOrderNotOpen      = errors.New("purchase order is not open for receiving")
+	ErrPurchaseOrderOverflow     = errors.New("received quantity exceeds the ordered quantity")
+	ErrPurchaseOrderLineNotFound = errors.New("purchase order line not found")
+	ErrLoanNotActive             = errors.New("book transaction is not in borrowed status")
+	ErrItemReferenceOnly         = errors.New("reference items cannot be lent")
+	ErrItemInLibraryOnly         = errors.New("item can only be used in the library")
+	ErrCustomerTooYoung          = errors.New("customer is below the minimum age for this item")
+	ErrCustomerBirthDateNeeded   = errors.New("customer birth date is required to borrow restricted items")
+	ErrCustomerSuspended         = errors.New("customer is suspended")
+	ErrStockOnHold               = errors.New("copy is set aside for another customer's hold")
+	ErrStockBookMismatch         = errors.New("stock code belongs to a different book")
+	ErrStockCodeMistyped         = errors.New("stock code check digit does not match, the code was probably mistyped")
+	ErrStockCodeBranch           = errors.New("branch code contains \"-\" and cannot be used in generated stock codes")
+	ErrSuspensionNotFound        = errors.New("suspension not found")
+	ErrSuspensionNotActive       = errors.New("suspension is no longer active")
+	ErrSuspensionExpiry          = errors.New("suspension expiry must be in the future")
+	ErrMergeSameCustomer         = errors.New("a customer cannot be merged into itself")
+	ErrPatronClaimMismatch       = errors.New("customer code and claim code do not match a customer record")
+	ErrCustomerAccountExists     = errors.New("customer already has an account")
+	ErrMembershipTierNotFound    = errors.New("membership tier not found")
+	ErrMembershipTierExists      = errors.New("membership tier code already exists")
+	ErrMembershipTierInUse       = errors.New("membership tier still has customers")
+	ErrLoanLimitReached          = errors.New("customer has reached the loan limit of their membership tier")
+	ErrRenewalLimitReached       = errors.New("loan has reached the renewal limit of the membership tier")
+	ErrHoldLimitReached          = errors.New("customer has reached the hold limit of their membership tier")
+	ErrInvalidDate               = errors.New("invalid date format: use YYYY-MM-DD")
+	ErrMembershipExpired         = errors.New("customer membership has expired")
+	ErrMembershipDates           = errors.New("membership expiry must be after its start")
+	ErrNotificationContact       = errors.New("preferred notification channel needs a matching email, phone or address")
+	ErrInvalidCondition          = errors.New("condition must be one of new, good, worn or poor")
+	ErrBookstockWithdrawn        = errors.New("book stock has been withdrawn")
+	ErrBookstockPendingWeeding   = errors.New("book stock is already in a pending deaccession")
+	ErrBookstockStatusChanged    = errors.New("book stock status changed while updating, nothing was changed")
+	ErrDeaccessionNotFound       = errors.New("deaccession not found")
+	ErrDeaccessionNotPending     = errors.New("deaccession has already been reviewed")
+	ErrStocktakeNotFound         = errors.New("stocktake not found")
+	ErrStocktakeNotOpen          = errors.New("stocktake is not open")
+	ErrStocktakeNotClosed        = errors.New("stocktake must be closed before approval")
 )
 
 // Success messages
@@ -51,6 +418,8 @@ const (
 	MsgCreateSuccess   = "Successfully created"
 	MsgBorrowSuccess   = "Book successfully borrowed"
 	MsgReturnSuccess   = "Book successfully returned"
+	MsgRestoreSuccess  = "Successfully restored"
+	MsgPurgeSuccess    = "Successfully purged"
 )
 
 // Default values
@@ -0,0 +1,34 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunPeriodically calls fn once right away and then every interval until ctx
+// is cancelled. Errors are logged and the next run goes ahead as scheduled.
+func RunPeriodically(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	run := func() {
+		started := time.Now()
+		if err := fn(ctx); err != nil {
+			slog.ErrorContext(ctx, "job failed", "job", name, "error", err.Error())
+			return
+		}
+		slog.InfoContext(ctx, "job finished", "job", name, "duration", time.Since(started).String())
+	}
+
+	run()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
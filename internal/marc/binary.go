@@ -0,0 +1,132 @@
+package marc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	recordTerminator = 0x1D
+	fieldTerminator  = 0x1E
+	subfieldDelim    = 0x1F
+	leaderLength     = 24
+	directoryEntry   = 12
+)
+
+var ErrInvalidRecord = errors.New("invalid MARC record")
+
+type binaryReader struct {
+	r *bufio.Reader
+}
+
+// NewBinaryReader reads ISO 2709 records. Character data is expected to be
+// UTF-8 (leader position 09 set to "a"); MARC-8 records are passed through
+// unconverted.
+func NewBinaryReader(r io.Reader) Reader {
+	return &binaryReader{r: bufio.NewReader(r)}
+}
+
+func (b *binaryReader) Read() (*Record, error) {
+	var data []byte
+	for len(data) == 0 {
+		chunk, err := b.r.ReadBytes(recordTerminator)
+		data = trimSpace(chunk)
+		if err == io.EOF {
+			if len(data) == 0 {
+				return nil, io.EOF
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return parseBinary(data)
+}
+
+func parseBinary(data []byte) (*Record, error) {
+	if len(data) < leaderLength {
+		return nil, fmt.Errorf("%w: record shorter than leader", ErrInvalidRecord)
+	}
+
+	record := &Record{Leader: string(data[:leaderLength])}
+
+	baseAddress, err := strconv.Atoi(string(data[12:17]))
+	if err != nil || baseAddress <= leaderLength || baseAddress > len(data) {
+		return nil, fmt.Errorf("%w: bad base address", ErrInvalidRecord)
+	}
+
+	directory := data[leaderLength : baseAddress-1]
+	for i := 0; i+directoryEntry <= len(directory); i += directoryEntry {
+		entry := directory[i : i+directoryEntry]
+		tag := string(entry[0:3])
+		length, err := strconv.Atoi(string(entry[3:7]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad field length for tag %s", ErrInvalidRecord, tag)
+		}
+		start, err := strconv.Atoi(string(entry[7:12]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad field start for tag %s", ErrInvalidRecord, tag)
+		}
+
+		// Atoi accepts a sign, so a "-001" entry must not reach the slice below.
+		if length < 0 || start < 0 {
+			return nil, fmt.Errorf("%w: negative directory entry for tag %s", ErrInvalidRecord, tag)
+		}
+
+		begin := baseAddress + start
+		end := begin + length
+		if begin < baseAddress || end < begin || end > len(data) {
+			return nil, fmt.Errorf("%w: field %s runs past end of record", ErrInvalidRecord, tag)
+		}
+		value := data[begin:end]
+		if n := len(value); n > 0 && value[n-1] == fieldTerminator {
+			value = value[:n-1]
+		}
+
+		if tag < "010" {
+			record.AddControlField(tag, string(value))
+			continue
+		}
+
+		record.DataFields = append(record.DataFields, parseDataField(tag, value))
+	}
+
+	return record, nil
+}
+
+func parseDataField(tag string, value []byte) DataField {
+	field := DataField{Tag: tag, Ind1: " ", Ind2: " "}
+	if len(value) >= 2 {
+		field.Ind1 = string(value[0])
+		field.Ind2 = string(value[1])
+		value = value[2:]
+	}
+
+	start := -1
+	for i := 0; i <= len(value); i++ {
+		if i < len(value) && value[i] != subfieldDelim {
+			continue
+		}
+		if start >= 0 && i-start > 1 {
+			field.Subfields = append(field.Subfields, Subfield{
+				Code:  string(value[start+1]),
+				Value: string(value[start+2 : i]),
+			})
+		}
+		start = i
+	}
+
+	return field
+}
+
+func trimSpace(data []byte) []byte {
+	for len(data) > 0 && (data[0] == '\n' || data[0] == '\r' || data[0] == ' ') {
+		data = data[1:]
+	}
+	return data
+}
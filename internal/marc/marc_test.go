@@ -0,0 +1,126 @@
+package marc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func openFixture(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func readAll(t *testing.T, reader Reader) []*Record {
+	t.Helper()
+	var records []*Record
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return records
+		}
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		records = append(records, record)
+	}
+}
+
+func TestBinaryReader(t *testing.T) {
+	records := readAll(t, NewBinaryReader(openFixture(t, "records.mrc")))
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	record := records[0]
+	if got := record.ControlField("001"); got != "rec-0001" {
+		t.Errorf("001 = %q", got)
+	}
+	title := record.Fields("245")
+	if len(title) != 1 || title[0].Ind1 != "1" || title[0].Ind2 != "0" {
+		t.Fatalf("245 = %+v", title)
+	}
+	if got := Clean(title[0].Subfield("a")); got != "Pride and prejudice" {
+		t.Errorf("245$a = %q", got)
+	}
+	if got := record.Fields("264")[0].Subfield("b"); got != "Penguin," {
+		t.Errorf("264$b = %q", got)
+	}
+	if got := record.Fields("650")[0].Subfield("v"); got != "Fiction." {
+		t.Errorf("650$v = %q", got)
+	}
+
+	if got := Clean(records[1].Fields("245")[0].Subfield("a")); got != "Second record" {
+		t.Errorf("second 245$a = %q", got)
+	}
+}
+
+func TestBinaryReaderRejectsBadDirectory(t *testing.T) {
+	for _, name := range []string{"negative_length.mrc", "negative_start.mrc"} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewBinaryReader(openFixture(t, name)).Read()
+			if !errors.Is(err, ErrInvalidRecord) {
+				t.Fatalf("err = %v, want ErrInvalidRecord", err)
+			}
+		})
+	}
+}
+
+func TestBinaryReaderRejectsShortRecord(t *testing.T) {
+	_, err := NewBinaryReader(bytes.NewReader([]byte("00010nam\x1d"))).Read()
+	if !errors.Is(err, ErrInvalidRecord) {
+		t.Fatalf("err = %v, want ErrInvalidRecord", err)
+	}
+}
+
+func TestXMLReader(t *testing.T) {
+	records := readAll(t, NewXMLReader(openFixture(t, "records.xml")))
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	if got := records[0].Fields("020")[0].Subfield("a"); got != "9780141439518 (pbk.)" {
+		t.Errorf("020$a = %q", got)
+	}
+	if got := records[0].Tags(); len(got) != 4 {
+		t.Errorf("tags = %v", got)
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	source := readAll(t, NewBinaryReader(openFixture(t, "records.mrc")))
+
+	var buf bytes.Buffer
+	writer, err := NewXMLWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range source {
+		if err := writer.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records := readAll(t, NewXMLReader(&buf))
+	if len(records) != len(source) {
+		t.Fatalf("got %d records, want %d", len(records), len(source))
+	}
+	for i := range source {
+		if got, want := records[i].Tags(), source[i].Tags(); len(got) != len(want) {
+			t.Errorf("record %d tags = %v, want %v", i, got, want)
+		}
+	}
+	if got := records[0].Fields("100")[0].Subfield("a"); got != "Austen, Jane," {
+		t.Errorf("100$a = %q", got)
+	}
+}
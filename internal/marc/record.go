@@ -0,0 +1,97 @@
+// Package marc reads MARC21 records in binary (ISO 2709) and MARCXML form and
+// writes them back out as MARCXML.
+package marc
+
+import "strings"
+
+type Record struct {
+	Leader        string
+	ControlFields []ControlField
+	DataFields    []DataField
+}
+
+type ControlField struct {
+	Tag   string
+	Value string
+}
+
+type DataField struct {
+	Tag       string
+	Ind1      string
+	Ind2      string
+	Subfields []Subfield
+}
+
+type Subfield struct {
+	Code  string
+	Value string
+}
+
+// Reader returns one record per call and io.EOF once the input is exhausted.
+type Reader interface {
+	Read() (*Record, error)
+}
+
+func (r *Record) ControlField(tag string) string {
+	for _, field := range r.ControlFields {
+		if field.Tag == tag {
+			return field.Value
+		}
+	}
+	return ""
+}
+
+func (r *Record) Fields(tag string) []DataField {
+	var fields []DataField
+	for _, field := range r.DataFields {
+		if field.Tag == tag {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Tags lists every tag in the record in the order it appears.
+func (r *Record) Tags() []string {
+	tags := make([]string, 0, len(r.ControlFields)+len(r.DataFields))
+	for _, field := range r.ControlFields {
+		tags = append(tags, field.Tag)
+	}
+	for _, field := range r.DataFields {
+		tags = append(tags, field.Tag)
+	}
+	return tags
+}
+
+func (r *Record) AddControlField(tag, value string) {
+	r.ControlFields = append(r.ControlFields, ControlField{Tag: tag, Value: value})
+}
+
+// AddDataField appends a data field built from code/value pairs, skipping
+// subfields with an empty value. Nothing is added when every value is empty.
+func (r *Record) AddDataField(tag, ind1, ind2 string, codeValues ...string) {
+	field := DataField{Tag: tag, Ind1: ind1, Ind2: ind2}
+	for i := 0; i+1 < len(codeValues); i += 2 {
+		if codeValues[i+1] != "" {
+			field.Subfields = append(field.Subfields, Subfield{Code: codeValues[i], Value: codeValues[i+1]})
+		}
+	}
+	if len(field.Subfields) > 0 {
+		r.DataFields = append(r.DataFields, field)
+	}
+}
+
+func (f DataField) Subfield(code string) string {
+	for _, subfield := range f.Subfields {
+		if subfield.Code == code {
+			return subfield.Value
+		}
+	}
+	return ""
+}
+
+// Clean strips the ISBD punctuation cataloguers leave at the end of
+// subfields, such as "Title /" or "Publisher,".
+func Clean(value string) string {
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(value), " /:;,."))
+}
@@ -0,0 +1,128 @@
+package marc
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+type xmlRecord struct {
+	XMLName       xml.Name          `xml:"record"`
+	Leader        string            `xml:"leader"`
+	ControlFields []xmlControlField `xml:"controlfield"`
+	DataFields    []xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlReader struct {
+	decoder *xml.Decoder
+}
+
+// NewXMLReader reads <record> elements one at a time, whether they are
+// wrapped in a <collection> or not.
+func NewXMLReader(r io.Reader) Reader {
+	return &xmlReader{decoder: xml.NewDecoder(r)}
+}
+
+func (x *xmlReader) Read() (*Record, error) {
+	for {
+		token, err := x.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		var raw xmlRecord
+		if err := x.decoder.DecodeElement(&raw, &start); err != nil {
+			return nil, err
+		}
+
+		record := &Record{Leader: raw.Leader}
+		for _, field := range raw.ControlFields {
+			record.AddControlField(field.Tag, field.Value)
+		}
+		for _, field := range raw.DataFields {
+			dataField := DataField{Tag: field.Tag, Ind1: field.Ind1, Ind2: field.Ind2}
+			for _, subfield := range field.Subfields {
+				dataField.Subfields = append(dataField.Subfields, Subfield{Code: subfield.Code, Value: subfield.Value})
+			}
+			record.DataFields = append(record.DataFields, dataField)
+		}
+
+		return record, nil
+	}
+}
+
+// XMLWriter streams records into a MARCXML <collection>. Close must be
+// called to end the document.
+type XMLWriter struct {
+	w       io.Writer
+	encoder *xml.Encoder
+}
+
+func NewXMLWriter(w io.Writer) (*XMLWriter, error) {
+	if _, err := io.WriteString(w, xml.Header+`<collection xmlns="`+Namespace+`">`+"\n"); err != nil {
+		return nil, err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("  ", "  ")
+	return &XMLWriter{w: w, encoder: encoder}, nil
+}
+
+func (x *XMLWriter) Write(record *Record) error {
+	raw := xmlRecord{Leader: record.Leader}
+	for _, field := range record.ControlFields {
+		raw.ControlFields = append(raw.ControlFields, xmlControlField{Tag: field.Tag, Value: field.Value})
+	}
+	for _, field := range record.DataFields {
+		dataField := xmlDataField{Tag: field.Tag, Ind1: blank(field.Ind1), Ind2: blank(field.Ind2)}
+		for _, subfield := range field.Subfields {
+			dataField.Subfields = append(dataField.Subfields, xmlSubfield{Code: subfield.Code, Value: subfield.Value})
+		}
+		raw.DataFields = append(raw.DataFields, dataField)
+	}
+
+	if err := x.encoder.Encode(raw); err != nil {
+		return err
+	}
+	_, err := io.WriteString(x.w, "\n")
+	return err
+}
+
+func (x *XMLWriter) Close() error {
+	if err := x.encoder.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(x.w, "</collection>\n")
+	return err
+}
+
+func blank(indicator string) string {
+	if strings.TrimSpace(indicator) == "" {
+		return " "
+	}
+	return indicator
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"go-rest-api/domain"
 	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
 	"net/http"
 	"strings"
 
@@ -11,6 +12,16 @@ import (
 )
 
 func Authenticate(authService domain.AuthService) fiber.Handler {
+	return authenticate(authService, false)
+}
+
+// AuthenticateStaff authenticates like Authenticate but only lets admin and
+// staff accounts through. Patrons may only use the /v1/me endpoints.
+func AuthenticateStaff(authService domain.AuthService) fiber.Handler {
+	return authenticate(authService, true)
+}
+
+func authenticate(authService domain.AuthService, staffOnly bool) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		token := strings.Split(c.Get("Authorization"), " ")
 		if len(token) < 2 {
@@ -21,6 +32,10 @@ func Authenticate(authService domain.AuthService) fiber.Handler {
 			return c.Status(http.StatusUnauthorized).JSON(dto.NewResponseMessage("Sorry, the token you entered is invalid. Please check your token and try again or contact customer support for further assistance. Thank you."))
 		}
 
+		if staffOnly && user.Role != constants.RoleAdmin && user.Role != constants.RoleStaff {
+			return c.Status(http.StatusForbidden).JSON(dto.NewResponseMessage("Unauthorized access"))
+		}
+
 		c.Locals("x-user", user)
 		return c.Next()
 	}
@@ -28,13 +43,12 @@ func Authenticate(authService domain.AuthService) fiber.Handler {
 
 func RoleMiddleware(roles ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		user := c.Locals("x-user").(map[string]interface{})
-
-		if user == nil {
+		user, ok := c.Locals("x-user").(dto.UserData)
+		if !ok {
 			return c.Status(fiber.StatusUnauthorized).JSON(dto.NewResponseMessage("Unauthorized access"))
 		}
 
-		userRole := user["role"].(string)
+		userRole := user.Role
 
 		isAllowed := false
 		for _, role := range roles {
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"go-rest-api/dto"
+	"io"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit enforces limit on request bodies once the server streams them.
+// Routes in streamed read the body themselves and apply their own limit.
+func BodyLimit(limit int, streamed ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, path := range streamed {
+			if c.Path() == path {
+				return c.Next()
+			}
+		}
+
+		req := c.Request()
+		if req.Header.ContentLength() > limit {
+			return bodyTooLarge(c)
+		}
+
+		// A chunked body has no length up front, so read it in under the limit.
+		if req.Header.ContentLength() < 0 && req.IsBodyStream() {
+			body, err := io.ReadAll(io.LimitReader(req.BodyStream(), int64(limit)+1))
+			if err != nil {
+				c.Context().SetConnectionClose()
+				return c.Status(http.StatusBadRequest).JSON(dto.NewResponseMessage("Invalid request body"))
+			}
+			if len(body) > limit {
+				return bodyTooLarge(c)
+			}
+			req.SetBody(body)
+		}
+
+		return c.Next()
+	}
+}
+
+// bodyTooLarge closes the connection, since the rest of the body is never
+// read and would otherwise be parsed as the next request.
+func bodyTooLarge(c *fiber.Ctx) error {
+	c.Context().SetConnectionClose()
+	return c.Status(http.StatusRequestEntityTooLarge).JSON(dto.NewResponseMessage("Request body too large"))
+}
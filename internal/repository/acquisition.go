@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AcquisitionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAcquisitionRepositoryImpl(db *gorm.DB) domain.AcquisitionRepository {
+	return &AcquisitionRepositoryImpl{db: db}
+}
+
+func (r *AcquisitionRepositoryImpl) FindVendors(ctx context.Context, search string) ([]domain.Vendor, error) {
+	var vendors []domain.Vendor
+	query := r.db.WithContext(ctx)
+	if search != "" {
+		query = query.Where("name ILIKE ?", "%"+search+"%")
+	}
+	err := query.Order("name").Find(&vendors).Error
+	return vendors, err
+}
+
+func (r *AcquisitionRepositoryImpl) FindVendorByID(ctx context.Context, id uuid.UUID) (*domain.Vendor, error) {
+	var vendor domain.Vendor
+	err := r.db.WithContext(ctx).First(&vendor, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &vendor, nil
+}
+
+func (r *AcquisitionRepositoryImpl) SaveVendor(ctx context.Context, vendor *domain.Vendor) error {
+	return r.db.WithContext(ctx).Save(vendor).Error
+}
+
+func (r *AcquisitionRepositoryImpl) DeleteVendor(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.Vendor{}, id).Error
+}
+
+func (r *AcquisitionRepositoryImpl) CountVendorOrders(ctx context.Context, id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.PurchaseOrder{}).Where("vendor_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *AcquisitionRepositoryImpl) FindFunds(ctx context.Context, fiscalYear int) ([]domain.Fund, error) {
+	var funds []domain.Fund
+	query := r.db.WithContext(ctx)
+	if fiscalYear != 0 {
+		query = query.Where("fiscal_year = ?", fiscalYear)
+	}
+	err := query.Order("fiscal_year, code").Find(&funds).Error
+	return funds, err
+}
+
+func (r *AcquisitionRepositoryImpl) FindFundByID(ctx context.Context, id uuid.UUID) (*domain.Fund, error) {
+	var fund domain.Fund
+	err := r.db.WithContext(ctx).First(&fund, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &fund, nil
+}
+
+func (r *AcquisitionRepositoryImpl) FindFundByCode(ctx context.Context, code string, fiscalYear int) (*domain.Fund, error) {
+	var fund domain.Fund
+	err := r.db.WithContext(ctx).Where("code = ? AND fiscal_year = ?", code, fiscalYear).First(&fund).Error
+	if err != nil {
+		return nil, err
+	}
+	return &fund, nil
+}
+
+func (r *AcquisitionRepositoryImpl) SaveFund(ctx context.Context, fund *domain.Fund) error {
+	return r.db.WithContext(ctx).Save(fund).Error
+}
+
+func (r *AcquisitionRepositoryImpl) DeleteFund(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.Fund{}, id).Error
+}
+
+func (r *AcquisitionRepositoryImpl) CountFundLines(ctx context.Context, id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.PurchaseOrderLine{}).Where("fund_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// FundSpending counts received copies as spent and the unreceived rest of
+// placed orders as committed. Drafts and cancelled remainders count for
+// neither.
+func (r *AcquisitionRepositoryImpl) FundSpending(ctx context.Context, fiscalYear int) ([]dto.FundReportRow, error) {
+	var rows []dto.FundReportRow
+	query := r.db.WithContext(ctx).Table("funds").
+		Select(`funds.id AS fund_id, funds.code, funds.name, funds.fiscal_year, funds.budget,
+			COALESCE(SUM(purchase_order_lines.received * purchase_order_lines.unit_price), 0) AS spent,
+			COALESCE(SUM(CASE WHEN purchase_orders.status IN ? THEN (purchase_order_lines.quantity - purchase_order_lines.received) * purchase_order_lines.unit_price ELSE 0 END), 0) AS committed`,
+			[]string{constants.PurchaseOrderStatusOrdered, constants.PurchaseOrderStatusPartial}).
+		Joins("LEFT JOIN purchase_order_lines ON purchase_order_lines.fund_id = funds.id").
+		Joins("LEFT JOIN purchase_orders ON purchase_orders.id = purchase_order_lines.purchase_order_id")
+	if fiscalYear != 0 {
+		query = query.Where("funds.fiscal_year = ?", fiscalYear)
+	}
+	err := query.Group("funds.id").Order("funds.fiscal_year, funds.code").Scan(&rows).Error
+	return rows, err
+}
+
+func (r *AcquisitionRepositoryImpl) FindOrders(ctx context.Context, filter dto.PurchaseOrderFilter) ([]domain.PurchaseOrder, error) {
+	var orders []domain.PurchaseOrder
+	query := preloadOrder(r.db.WithContext(ctx))
+	if filter.VendorID != nil {
+		query = query.Where("vendor_id = ?", *filter.VendorID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Order("created_at DESC").Find(&orders).Error
+	return orders, err
+}
+
+func (r *AcquisitionRepositoryImpl) FindOrderByID(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error) {
+	var order domain.PurchaseOrder
+	err := preloadOrder(r.db.WithContext(ctx)).First(&order, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *AcquisitionRepositoryImpl) FindOrderByNumber(ctx context.Context, number string) (*domain.PurchaseOrder, error) {
+	var order domain.PurchaseOrder
+	err := r.db.WithContext(ctx).Where("number = ?", number).First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *AcquisitionRepositoryImpl) CreateOrder(ctx context.Context, order *domain.PurchaseOrder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Vendor", "Lines").Create(order).Error; err != nil {
+			return err
+		}
+		return createOrderLines(tx, order)
+	})
+}
+
+func (r *AcquisitionRepositoryImpl) ReplaceOrder(ctx context.Context, order *domain.PurchaseOrder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Vendor", "Lines").Save(order).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("purchase_order_id = ?", order.ID).Delete(&domain.PurchaseOrderLine{}).Error; err != nil {
+			return err
+		}
+		return createOrderLines(tx, order)
+	})
+}
+
+func (r *AcquisitionRepositoryImpl) UpdateOrderStatus(ctx context.Context, order *domain.PurchaseOrder) error {
+	return r.db.WithContext(ctx).Model(&domain.PurchaseOrder{}).Where("id = ?", order.ID).
+		Updates(map[string]interface{}{"status": order.Status, "ordered_at": order.OrderedAt}).Error
+}
+
+func (r *AcquisitionRepositoryImpl) DeleteOrder(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("purchase_order_id = ?", id).Delete(&domain.PurchaseOrderLine{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.PurchaseOrder{}, id).Error
+	})
+}
+
+// ReceiveCopies refuses to push a line past its ordered quantity, so two
+// receipts racing on the same line cannot both succeed.
+func (r *AcquisitionRepositoryImpl) ReceiveCopies(ctx context.Context, order *domain.PurchaseOrder, received map[uuid.UUID]int, copies []domain.BookStock) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for lineID, quantity := range received {
+			result := tx.Model(&domain.PurchaseOrderLine{}).
+				Where("id = ? AND received + ? <= quantity", lineID, quantity).
+				Update("received", gorm.Expr("received + ?", quantity))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return constants.ErrPurchaseOrderOverflow
+			}
+		}
+
+		if err := tx.Omit("Book", "HomeBranch", "HomeShelf", "CurrentBranch", "CurrentShelf").CreateInBatches(copies, 100).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&domain.PurchaseOrder{}).Where("id = ?", order.ID).Update("status", order.Status).Error
+	})
+}
+
+func createOrderLines(tx *gorm.DB, order *domain.PurchaseOrder) error {
+	if len(order.Lines) == 0 {
+		return nil
+	}
+	for i := range order.Lines {
+		order.Lines[i].PurchaseOrderID = order.ID
+	}
+	return tx.Omit("Book", "Fund").Create(&order.Lines).Error
+}
+
+func preloadOrder(query *gorm.DB) *gorm.DB {
+	return query.Preload("Vendor").Preload("Lines").Preload("Lines.Book").Preload("Lines.Fund")
+}
@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -16,18 +19,18 @@ func NewBookRepository(db *gorm.DB) domain.BookRepository {
 	return &BookRepositoryImpl{db: db}
 }
 
-func (r *BookRepositoryImpl) FindBooks(ctx context.Context, page, perPage int, search string, cover_id *uuid.UUID) ([]domain.Book, int64, error) {
+func (r *BookRepositoryImpl) FindBooks(ctx context.Context, page, perPage int, filter dto.BookFilter) ([]domain.Book, int64, error) {
 	var books []domain.Book
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Book{})
+	query := applyBookFilter(r.db.WithContext(ctx).Model(&domain.Book{}), filter, "")
 
-	if search != "" {
-		query = query.Where("title LIKE ? OR description LIKE ?", "%"+search+"%", "%"+search+"%")
-	}
-
-	if cover_id != nil && *cover_id != uuid.Nil {
-		query = query.Where("cover_id = ?", *cover_id)
+	// Collapsed results keep the newest matching edition of every work.
+	if filter.Collapse == constants.BookCollapseWork {
+		editions := applyBookFilter(r.db.WithContext(ctx).Model(&domain.Book{}), filter, "").
+			Select("DISTINCT ON (" + bookWorkKey + ") books.id").
+			Order(bookWorkKey + ", publication_year DESC, created_at DESC")
+		query = query.Where("books.id IN (?)", editions)
 	}
 
 	err := query.Count(&total).Error
@@ -35,6 +38,17 @@ func (r *BookRepositoryImpl) FindBooks(ctx context.Context, page, perPage int, s
 		return nil, 0, err
 	}
 
+	switch filter.Sort {
+	case constants.BookSortNewest:
+		query = query.Order("created_at DESC")
+	case constants.BookSortTitle:
+		query = query.Order("title")
+	case constants.BookSortVolume:
+		query = query.Order("series_volume NULLS LAST, title")
+	case constants.BookSortRating:
+		query = query.Order("rating_average DESC, rating_count DESC, title")
+	}
+
 	if page > 0 && perPage > 0 {
 		query = query.Offset((page - 1) * perPage).Limit(perPage)
 	}
@@ -44,6 +58,79 @@ func (r *BookRepositoryImpl) FindBooks(ctx context.Context, page, perPage int, s
 	return books, total, err
 }
 
+func (r *BookRepositoryImpl) FindBookFacets(ctx context.Context, filter dto.BookFilter) (*dto.BookFacets, error) {
+	facets := &dto.BookFacets{}
+
+	// Each facet is counted with every filter applied except its own, so the
+	// counts show what selecting another value of that facet would return.
+	availableExpr := fmt.Sprintf("CASE WHEN %s THEN '%s' ELSE '%s' END", bookAvailableExpr, constants.BookFacetValueAvailable, constants.BookFacetValueUnavailable)
+	err := applyBookFilter(r.db.WithContext(ctx).Model(&domain.Book{}), filter, constants.BookFacetAvailability).
+		Select(availableExpr+" AS value, "+bookCountExpr(filter)+" AS count", constants.BookStockStatusAvailable).
+		Group("value").
+		Order("value").
+		Scan(&facets.Availability).Error
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []struct {
+		name   string
+		target *[]dto.FacetCount
+	}{
+		{constants.BookFacetCategory, &facets.Category},
+		{constants.BookFacetAuthor, &facets.Author},
+		{constants.BookFacetPublisher, &facets.Publisher},
+		{constants.BookFacetLanguage, &facets.Language},
+	}
+	for _, column := range columns {
+		err := applyBookFilter(r.db.WithContext(ctx).Model(&domain.Book{}), filter, column.name).
+			Select(column.name + " AS value, " + bookCountExpr(filter) + " AS count").
+			Where(column.name + " <> ''").
+			Group(column.name).
+			Order("count DESC, value").
+			Limit(constants.BookFacetLimit).
+			Scan(column.target).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var years []struct {
+		Bucket int
+		Count  int64
+	}
+	err = applyBookFilter(r.db.WithContext(ctx).Model(&domain.Book{}), filter, constants.BookFacetPublicationYear).
+		Select("(publication_year / ?) * ? AS bucket, "+bookCountExpr(filter)+" AS count", constants.BookFacetYearBucket, constants.BookFacetYearBucket).
+		Where("publication_year > 0").
+		Group("bucket").
+		Order("bucket DESC").
+		Scan(&years).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, year := range years {
+		facets.PublicationYear = append(facets.PublicationYear, dto.YearRangeFacet{
+			From:  year.Bucket,
+			To:    year.Bucket + constants.BookFacetYearBucket - 1,
+			Count: year.Count,
+		})
+	}
+
+	return facets, nil
+}
+
+func (r *BookRepositoryImpl) CountBooksBy(ctx context.Context, column string) ([]dto.FacetCount, error) {
+	var counts []dto.FacetCount
+	err := r.db.WithContext(ctx).Model(&domain.Book{}).
+		Select(column + " AS value, COUNT(*) AS count").
+		Where(column + " <> ''").
+		Group(column).
+		Order(column).
+		Scan(&counts).Error
+	return counts, err
+}
+
 func (r *BookRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Book, error) {
 	var book domain.Book
 	err := r.db.WithContext(ctx).Preload("Cover").First(&book, id).Error
@@ -53,6 +140,108 @@ func (r *BookRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domai
 	return &book, nil
 }
 
+// retiredStatuses are copies kept for history only; they never count towards
+// availability.
+var retiredStatuses = []string{constants.BookStockStatusArchived, constants.BookStockStatusWithdrawn}
+
+// FindAvailability counts copies per status for all given books in a single
+// aggregate query, together with the earliest due date of an open loan.
+func (r *BookRepositoryImpl) FindAvailability(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]dto.BookAvailability, error) {
+	availability := make(map[uuid.UUID]dto.BookAvailability, len(ids))
+	if len(ids) == 0 {
+		return availability, nil
+	}
+
+	var rows []struct {
+		BookID uuid.UUID
+		dto.BookAvailability
+	}
+	err := r.db.WithContext(ctx).Table("book_stocks").
+		Select(`book_stocks.book_id,
+			COUNT(DISTINCT book_stocks.code) AS total,
+			COUNT(DISTINCT book_stocks.code) FILTER (WHERE book_stocks.status = ? AND NOT `+CopyHeldExpr+`) AS available,
+			COUNT(DISTINCT book_stocks.code) FILTER (WHERE book_stocks.status = ? AND `+CopyHeldExpr+`) AS on_hold,
+			COUNT(DISTINCT book_stocks.code) FILTER (WHERE book_stocks.status = ?) AS borrowed,
+			COUNT(DISTINCT book_stocks.code) FILTER (WHERE book_stocks.status = ?) AS damaged,
+			COUNT(DISTINCT book_stocks.code) FILTER (WHERE book_stocks.status = ?) AS lost,
+			COUNT(DISTINCT book_stocks.code) FILTER (WHERE book_stocks.status = ?) AS in_transit,
+			MIN(book_transactions.due_date) AS next_due_date`,
+			constants.BookStockStatusAvailable,
+			constants.BookStockStatusAvailable,
+			constants.BookStockStatusBorrowed,
+			constants.BookStockStatusDamaged,
+			constants.BookStockStatusLost,
+			constants.BookStockStatusInTransit,
+		).
+		Joins("LEFT JOIN book_transactions ON book_transactions.stock_code = book_stocks.code AND book_transactions.return_at IS NULL AND book_transactions.status IN ?",
+			[]string{constants.BookTransactionStatusBorrowed, constants.BookTransactionStatusOverdue}).
+		Where("book_stocks.book_id IN ? AND book_stocks.status NOT IN ?", ids, retiredStatuses).
+		Group("book_stocks.book_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		availability[row.BookID] = row.BookAvailability
+	}
+
+	return availability, nil
+}
+
+func (r *BookRepositoryImpl) FindBranchAvailability(ctx context.Context, id uuid.UUID) ([]dto.BranchAvailability, error) {
+	availability := make([]dto.BranchAvailability, 0)
+	err := r.db.WithContext(ctx).Table("book_stocks").
+		Select(`book_stocks.current_branch_id AS branch_id,
+			COALESCE(branches.code, '') AS branch_code,
+			COALESCE(branches.name, '') AS branch_name,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE book_stocks.status = ? AND NOT `+CopyHeldExpr+`) AS available`,
+			constants.BookStockStatusAvailable,
+		).
+		Joins("LEFT JOIN branches ON branches.id = book_stocks.current_branch_id").
+		Where("book_stocks.book_id = ? AND book_stocks.status NOT IN ?", id, retiredStatuses).
+		Group("book_stocks.current_branch_id, branches.code, branches.name").
+		Order("branches.code NULLS LAST").
+		Scan(&availability).Error
+	return availability, err
+}
+
+func (r *BookRepositoryImpl) FindByTitleAuthor(ctx context.Context, pairs [][]interface{}) ([]domain.Book, error) {
+	var books []domain.Book
+	if len(pairs) == 0 {
+		return books, nil
+	}
+
+	err := r.db.WithContext(ctx).Where("(LOWER(title), LOWER(author)) IN ?", pairs).Find(&books).Error
+	return books, err
+}
+
+func (r *BookRepositoryImpl) FindByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	var book domain.Book
+	err := r.db.WithContext(ctx).Where("isbn = ?", isbn).First(&book).Error
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+func (r *BookRepositoryImpl) HasCover(ctx context.Context, coverPath string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Book{}).
+		Where("cover_id IN (?)", r.db.Model(&domain.Media{}).Select("id").Where("path = ?", coverPath)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *BookRepositoryImpl) FindInBatches(ctx context.Context, batchSize int, fn func(books []domain.Book) error) error {
+	var books []domain.Book
+	return r.db.WithContext(ctx).Preload("Cover").Preload("BookStocks").
+		FindInBatches(&books, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(books)
+		}).Error
+}
+
 func (r *BookRepositoryImpl) Create(ctx context.Context, book *domain.Book) error {
 	return r.db.WithContext(ctx).Create(book).Error
 }
@@ -64,3 +253,128 @@ func (r *BookRepositoryImpl) Update(ctx context.Context, book *domain.Book) erro
 func (r *BookRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&domain.Book{}, id).Error
 }
+
+func (r *BookRepositoryImpl) FindDeleted(ctx context.Context) ([]domain.Book, error) {
+	var books []domain.Book
+	err := r.db.WithContext(ctx).Unscoped().Preload("Cover").
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&books).Error
+	return books, err
+}
+
+func (r *BookRepositoryImpl) FindDeletedByID(ctx context.Context, id uuid.UUID) (*domain.Book, error) {
+	var book domain.Book
+	err := r.db.WithContext(ctx).Unscoped().Preload("Cover").
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&book).Error
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// Purge drops the book's precomputed recommendations along with the book.
+func (r *BookRepositoryImpl) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("book_id = ? OR recommended_book_id = ?", id, id).Delete(&domain.BookRecommendation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("book_id = ?", id).Delete(&domain.CustomerRecommendation{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&domain.Book{}, id).Error
+	})
+}
+
+// bookHistory lists the records that keep a book from being purged.
+var bookHistory = []interface{}{
+	&domain.BookStock{}, &domain.BookTransaction{}, &domain.ReadingListItem{},
+}
+
+// CountDependents counts the copies, transactions and reading list items that
+// still reference the book, deleted or not.
+func (r *BookRepositoryImpl) CountDependents(ctx context.Context, id uuid.UUID) (int64, error) {
+	var total int64
+	for _, model := range bookHistory {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(model).Where("book_id = ?", id).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+func (r *BookRepositoryImpl) GetDB() *gorm.DB {
+	return r.db
+}
+
+var bookAvailableExpr = "EXISTS (SELECT 1 FROM book_stocks WHERE book_stocks.book_id = books.id AND book_stocks.status = ? AND NOT " + CopyHeldExpr + ")"
+
+// bookWorkKey identifies the work a book belongs to; books without a work
+// count as a work of their own.
+const bookWorkKey = "COALESCE(books.work_id, books.id)"
+
+// bookCountExpr counts works instead of editions when results are collapsed.
+func bookCountExpr(filter dto.BookFilter) string {
+	if filter.Collapse == constants.BookCollapseWork {
+		return "COUNT(DISTINCT " + bookWorkKey + ")"
+	}
+	return "COUNT(*)"
+}
+
+// applyBookFilter adds the catalog filters to query. The filter belonging to
+// the skip facet is left out, which is how facet counts are computed.
+func applyBookFilter(query *gorm.DB, filter dto.BookFilter, skip string) *gorm.DB {
+	if filter.Search != "" {
+		query = query.Where("title LIKE ? OR description LIKE ?", "%"+filter.Search+"%", "%"+filter.Search+"%")
+	}
+
+	if filter.CoverID != nil && *filter.CoverID != uuid.Nil {
+		query = query.Where("cover_id = ?", *filter.CoverID)
+	}
+
+	if filter.Available != nil && skip != constants.BookFacetAvailability {
+		if *filter.Available {
+			query = query.Where(bookAvailableExpr, constants.BookStockStatusAvailable)
+		} else {
+			query = query.Where("NOT "+bookAvailableExpr, constants.BookStockStatusAvailable)
+		}
+	}
+
+	if filter.Category != "" && skip != constants.BookFacetCategory {
+		query = query.Where("category = ?", filter.Category)
+	}
+
+	if filter.Author != "" && skip != constants.BookFacetAuthor {
+		query = query.Where("author = ?", filter.Author)
+	}
+
+	if filter.Publisher != "" && skip != constants.BookFacetPublisher {
+		query = query.Where("publisher = ?", filter.Publisher)
+	}
+
+	if filter.Language != "" && skip != constants.BookFacetLanguage {
+		query = query.Where("language = ?", filter.Language)
+	}
+
+	if filter.WorkID != nil {
+		query = query.Where("work_id = ?", *filter.WorkID)
+	}
+
+	if filter.SeriesID != nil {
+		query = query.Where("series_id = ?", *filter.SeriesID)
+	}
+
+	if skip != constants.BookFacetPublicationYear {
+		if filter.YearFrom > 0 {
+			query = query.Where("publication_year >= ?", filter.YearFrom)
+		}
+		if filter.YearTo > 0 {
+			query = query.Where("publication_year <= ?", filter.YearTo)
+		}
+	}
+
+	return query
+}
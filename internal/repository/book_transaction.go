@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"go-rest-api/domain"
 	"time"
 
@@ -71,6 +72,14 @@ func (r *BookTransactionRepositoryImpl) Delete(id uuid.UUID) error {
 	return r.db.Delete(&domain.BookTransaction{}, id).Error
 }
 
+func (r *BookTransactionRepositoryImpl) CountActiveByCustomer(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.BookTransaction{}).
+		Where("customer_id = ? AND status IN ?", customerID, openLoanStatuses).
+		Count(&count).Error
+	return count, err
+}
+
 func (r *BookTransactionRepositoryImpl) GetDB() *gorm.DB {
 	return r.db
 }
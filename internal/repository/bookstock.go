@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"go-rest-api/domain"
+	"go-rest-api/dto"
 	"go-rest-api/internal/constants"
 
 	"github.com/google/uuid"
@@ -17,43 +19,120 @@ func NewBookstockRepositoryImpl(db *gorm.DB) domain.BookstockRepository {
 	return &BookstockRepositoryImpl{db: db}
 }
 
-func (r *BookstockRepositoryImpl) FindAll() ([]domain.BookStock, error) {
+func (r *BookstockRepositoryImpl) FindAll(filter dto.BookstockFilter) ([]domain.BookStock, error) {
 	var bookstocks []domain.BookStock
-	err := r.db.Preload("Book").Preload("Book.Cover").Find(&bookstocks).Error
+	err := applyBookstockFilter(preloadLocations(r.db.Preload("Book").Preload("Book.Cover")), filter).
+		Order("code").Find(&bookstocks).Error
 	return bookstocks, err
 }
 
 func (r *BookstockRepositoryImpl) FindByCode(code string) (*domain.BookStock, error) {
 	var bookstock domain.BookStock
-	err := r.db.Preload("Book").Preload("Book.Cover").Where("code = ?", code).First(&bookstock).Error
+	err := preloadLocations(r.db.Preload("Book").Preload("Book.Cover")).Where("code = ?", code).First(&bookstock).Error
 	if err != nil {
 		return nil, err
 	}
 	return &bookstock, nil
 }
 
+func (r *BookstockRepositoryImpl) FindByCodes(codes []string) ([]domain.BookStock, error) {
+	var bookstocks []domain.BookStock
+	err := r.db.Preload("Book").Where("code IN ?", codes).Find(&bookstocks).Error
+	return bookstocks, err
+}
+
 func (r *BookstockRepositoryImpl) FindByBookID(bookID uuid.UUID) ([]domain.BookStock, error) {
 	var bookstocks []domain.BookStock
-	err := r.db.Preload("Book").Preload("Book.Cover").Where("book_id = ?", bookID).Find(&bookstocks).Error
+	err := preloadLocations(r.db.Preload("Book").Preload("Book.Cover")).Where("book_id = ?", bookID).Find(&bookstocks).Error
 	return bookstocks, err
 }
 
-func (r *BookstockRepositoryImpl) FindAvailableByBookID(bookID uuid.UUID) ([]domain.BookStock, error) {
+func (r *BookstockRepositoryImpl) FindAvailableByBookID(bookID uuid.UUID, filter dto.BookstockFilter) ([]domain.BookStock, error) {
 	var bookstocks []domain.BookStock
-	err := r.db.Preload("Book").Preload("Book.Cover").
+	err := applyBookstockFilter(preloadLocations(r.db.Preload("Book").Preload("Book.Cover")), filter).
 		Where("book_id = ? AND status = ?", bookID, constants.BookStockStatusAvailable).
+		Where("NOT " + CopyHeldExpr).
 		Find(&bookstocks).Error
 	return bookstocks, err
 }
 
 func (r *BookstockRepositoryImpl) Create(bookstock *domain.BookStock) error {
-	return r.db.Create(bookstock).Error
+	return r.db.Omit("HomeBranch", "HomeShelf", "CurrentBranch", "CurrentShelf").Create(bookstock).Error
 }
 
 func (r *BookstockRepositoryImpl) Update(bookstock *domain.BookStock) error {
-	return r.db.Save(bookstock).Error
+	return r.db.Omit("HomeBranch", "HomeShelf", "CurrentBranch", "CurrentShelf").Save(bookstock).Error
 }
 
 func (r *BookstockRepositoryImpl) Delete(code string) error {
 	return r.db.Delete(&domain.BookStock{}, "code = ?", code).Error
 }
+
+func (r *BookstockRepositoryImpl) CreateMany(ctx context.Context, bookstocks []domain.BookStock) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Omit("Book", "HomeBranch", "HomeShelf", "CurrentBranch", "CurrentShelf").CreateInBatches(bookstocks, 100).Error
+	})
+}
+
+func (r *BookstockRepositoryImpl) ChangeStatuses(ctx context.Context, logs []domain.BookStockStatusLog, onChange domain.StatusChangeHook) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, log := range logs {
+			result := tx.Model(&domain.BookStock{}).
+				Where("code = ? AND status = ?", log.StockCode, log.FromStatus).
+				Update("status", log.ToStatus)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return constants.ErrBookstockStatusChanged
+			}
+			if err := onChange(tx, log); err != nil {
+				return err
+			}
+		}
+
+		if len(logs) == 0 {
+			return nil
+		}
+		return tx.Create(&logs).Error
+	})
+}
+
+func (r *BookstockRepositoryImpl) GetDB() *gorm.DB {
+	return r.db
+}
+
+func (r *BookstockRepositoryImpl) FindStatusLogs(ctx context.Context, code string) ([]domain.BookStockStatusLog, error) {
+	var logs []domain.BookStockStatusLog
+	err := r.db.WithContext(ctx).Where("stock_code = ?", code).Order("created_at DESC").Find(&logs).Error
+	return logs, err
+}
+
+func preloadLocations(query *gorm.DB) *gorm.DB {
+	return query.Preload("HomeBranch").Preload("HomeShelf").Preload("CurrentBranch").Preload("CurrentShelf")
+}
+
+func applyBookstockFilter(query *gorm.DB, filter dto.BookstockFilter) *gorm.DB {
+	if filter.BookID != nil {
+		query = query.Where("book_id = ?", *filter.BookID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Condition != "" {
+		query = query.Where("condition = ?", filter.Condition)
+	}
+
+	branchColumn, shelfColumn := "current_branch_id", "current_shelf_id"
+	if filter.Location == constants.LocationHome {
+		branchColumn, shelfColumn = "home_branch_id", "home_shelf_id"
+	}
+	if filter.BranchID != nil {
+		query = query.Where(branchColumn+" = ?", *filter.BranchID)
+	}
+	if filter.ShelfID != nil {
+		query = query.Where(shelfColumn+" = ?", *filter.ShelfID)
+	}
+
+	return query
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BranchRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewBranchRepositoryImpl(db *gorm.DB) domain.BranchRepository {
+	return &BranchRepositoryImpl{db: db}
+}
+
+func (r *BranchRepositoryImpl) FindAll(ctx context.Context) ([]domain.Branch, error) {
+	var branches []domain.Branch
+	err := r.db.WithContext(ctx).Order("code").Find(&branches).Error
+	return branches, err
+}
+
+func (r *BranchRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Branch, error) {
+	var branch domain.Branch
+	err := r.db.WithContext(ctx).Preload("Shelves", func(db *gorm.DB) *gorm.DB {
+		return db.Order("code")
+	}).First(&branch, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &branch, nil
+}
+
+func (r *BranchRepositoryImpl) FindByCode(ctx context.Context, code string) (*domain.Branch, error) {
+	var branch domain.Branch
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&branch).Error
+	if err != nil {
+		return nil, err
+	}
+	return &branch, nil
+}
+
+func (r *BranchRepositoryImpl) Create(ctx context.Context, branch *domain.Branch) error {
+	return r.db.WithContext(ctx).Create(branch).Error
+}
+
+func (r *BranchRepositoryImpl) Update(ctx context.Context, branch *domain.Branch) error {
+	return r.db.WithContext(ctx).Omit("Shelves").Save(branch).Error
+}
+
+// Delete removes the branch together with its shelves.
+func (r *BranchRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("branch_id = ?", id).Delete(&domain.ShelfLocation{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Branch{}, id).Error
+	})
+}
+
+func (r *BranchRepositoryImpl) FindShelves(ctx context.Context, branchID uuid.UUID) ([]domain.ShelfLocation, error) {
+	var shelves []domain.ShelfLocation
+	err := r.db.WithContext(ctx).Where("branch_id = ?", branchID).Order("code").Find(&shelves).Error
+	return shelves, err
+}
+
+func (r *BranchRepositoryImpl) FindShelfByID(ctx context.Context, id uuid.UUID) (*domain.ShelfLocation, error) {
+	var shelf domain.ShelfLocation
+	err := r.db.WithContext(ctx).First(&shelf, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &shelf, nil
+}
+
+func (r *BranchRepositoryImpl) CreateShelf(ctx context.Context, shelf *domain.ShelfLocation) error {
+	return r.db.WithContext(ctx).Omit("Branch").Create(shelf).Error
+}
+
+func (r *BranchRepositoryImpl) UpdateShelf(ctx context.Context, shelf *domain.ShelfLocation) error {
+	return r.db.WithContext(ctx).Omit("Branch").Save(shelf).Error
+}
+
+func (r *BranchRepositoryImpl) DeleteShelf(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.ShelfLocation{}, id).Error
+}
+
+func (r *BranchRepositoryImpl) CountBranchCopies(ctx context.Context, id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.BookStock{}).
+		Where("home_branch_id = ? OR current_branch_id = ?", id, id).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *BranchRepositoryImpl) CountShelfCopies(ctx context.Context, id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.BookStock{}).
+		Where("home_shelf_id = ? OR current_shelf_id = ?", id, id).
+		Count(&count).Error
+	return count, err
+}
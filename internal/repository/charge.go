@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ChargeRepositoryImpl struct {
@@ -57,8 +58,9 @@ func (r *ChargeRepositoryImpl) Create(charge *domain.Charge) error {
 	return r.db.Create(charge).Error
 }
 
+// Update saves the charge alone, not the loan and user it was loaded with.
 func (r *ChargeRepositoryImpl) Update(charge *domain.Charge) error {
-	return r.db.Save(charge).Error
+	return r.db.Omit(clause.Associations).Save(charge).Error
 }
 
 func (r *ChargeRepositoryImpl) Delete(id uuid.UUID) error {
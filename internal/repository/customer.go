@@ -50,3 +50,56 @@ func (r *CustomerRepositoryImpl) Update(customer *domain.Customer) error {
 func (r *CustomerRepositoryImpl) Delete(id uuid.UUID) error {
 	return r.db.Delete(&domain.Customer{}, id).Error
 }
+
+func (r *CustomerRepositoryImpl) FindDeleted() ([]domain.Customer, error) {
+	var customers []domain.Customer
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&customers).Error
+	return customers, err
+}
+
+func (r *CustomerRepositoryImpl) FindDeletedByID(id uuid.UUID) (*domain.Customer, error) {
+	var customer domain.Customer
+	err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&customer).Error
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (r *CustomerRepositoryImpl) Restore(id uuid.UUID) error {
+	return r.db.Unscoped().Model(&domain.Customer{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// Purge removes the customer with their precomputed recommendations, which
+// are rebuilt from loans anyway.
+func (r *CustomerRepositoryImpl) Purge(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("customer_id = ?", id).Delete(&domain.CustomerRecommendation{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&domain.Customer{}, id).Error
+	})
+}
+
+// customerHistory lists the records that keep a customer from being purged.
+var customerHistory = []interface{}{
+	&domain.BookTransaction{}, &domain.Review{}, &domain.Hold{}, &domain.Suspension{}, &domain.ReadingList{},
+}
+
+// CountDependents counts the loans, reviews, holds, suspensions and reading
+// lists that still reference the customer.
+func (r *CustomerRepositoryImpl) CountDependents(id uuid.UUID) (int64, error) {
+	var total int64
+	for _, model := range customerHistory {
+		var count int64
+		if err := r.db.Model(model).Where("customer_id = ?", id).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+func (r *CustomerRepositoryImpl) GetDB() *gorm.DB {
+	return r.db
+}
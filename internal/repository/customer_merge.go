@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/internal/constants"
+	"sort"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CustomerMergeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCustomerMergeRepositoryImpl(db *gorm.DB) domain.CustomerMergeRepository {
+	return &CustomerMergeRepositoryImpl{db: db}
+}
+
+// Merge reassigns the duplicate's loans (and with them their charges), holds,
+// suspensions, reviews, reading lists, borrowed copies and patron account.
+// Where the survivor already reviewed a book the duplicate's review of it is
+// dropped, the duplicate's wishlists are folded into the survivor's, and the
+// duplicate's recommendations are dropped to be rebuilt.
+func (r *CustomerMergeRepositoryImpl) Merge(ctx context.Context, survivor *domain.Customer, duplicate *domain.Customer, merge *domain.CustomerMerge, cancelHold domain.HoldCancelFunc) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var charges int64
+		if err := tx.Model(&domain.Charge{}).
+			Joins("JOIN book_transactions ON book_transactions.id = charges.book_transaction_id").
+			Where("book_transactions.customer_id = ?", duplicate.ID).
+			Count(&charges).Error; err != nil {
+			return err
+		}
+		merge.Charges = int(charges)
+
+		moved, err := reassign(tx, &domain.BookTransaction{}, "customer_id", duplicate.ID, survivor.ID)
+		if err != nil {
+			return err
+		}
+		merge.Loans = moved
+
+		if merge.Holds, err = reassign(tx, &domain.Hold{}, "customer_id", duplicate.ID, survivor.ID); err != nil {
+			return err
+		}
+		if merge.HoldsCancelled, err = cancelRepeatedHolds(tx, survivor.ID, cancelHold); err != nil {
+			return err
+		}
+		if merge.Suspensions, err = reassign(tx, &domain.Suspension{}, "customer_id", duplicate.ID, survivor.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Where("customer_id = ? AND book_id IN (?)", duplicate.ID,
+			tx.Model(&domain.Review{}).Select("book_id").Where("customer_id = ?", survivor.ID)).
+			Delete(&domain.Review{}).Error; err != nil {
+			return err
+		}
+		if merge.Reviews, err = reassign(tx, &domain.Review{}, "customer_id", duplicate.ID, survivor.ID); err != nil {
+			return err
+		}
+
+		if merge.WishlistItems, err = mergeWishlists(tx, survivor.ID, duplicate.ID); err != nil {
+			return err
+		}
+		if merge.ReadingLists, err = reassign(tx, &domain.ReadingList{}, "customer_id", duplicate.ID, survivor.ID); err != nil {
+			return err
+		}
+		if _, err := reassign(tx, &domain.BookStock{}, "borrowed_id", duplicate.ID, survivor.ID); err != nil {
+			return err
+		}
+		if err := tx.Where("customer_id = ?", duplicate.ID).Delete(&domain.CustomerRecommendation{}).Error; err != nil {
+			return err
+		}
+
+		// The survivor keeps its own patron account; a second one is unlinked.
+		var accounts int64
+		if err := tx.Model(&domain.User{}).Where("customer_id = ?", survivor.ID).Count(&accounts).Error; err != nil {
+			return err
+		}
+		if accounts == 0 {
+			moved, err := reassign(tx, &domain.User{}, "customer_id", duplicate.ID, survivor.ID)
+			if err != nil {
+				return err
+			}
+			merge.AccountMoved = moved > 0
+		} else if err := tx.Model(&domain.User{}).Where("customer_id = ?", duplicate.ID).
+			Update("customer_id", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Omit("BookTransactions").Save(survivor).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.Customer{}, duplicate.ID).Error; err != nil {
+			return err
+		}
+		return tx.Create(merge).Error
+	})
+}
+
+func (r *CustomerMergeRepositoryImpl) FindMerges(ctx context.Context, customerID *uuid.UUID) ([]domain.CustomerMerge, error) {
+	var merges []domain.CustomerMerge
+	query := r.db.WithContext(ctx).Order("created_at DESC")
+	if customerID != nil {
+		query = query.Where("survivor_id = ? OR duplicate_id = ?", *customerID, *customerID)
+	}
+	err := query.Find(&merges).Error
+	return merges, err
+}
+
+func reassign(tx *gorm.DB, model interface{}, column string, from, to uuid.UUID) (int, error) {
+	result := tx.Model(model).Where(column+" = ?", from).Update(column, to)
+	return int(result.RowsAffected), result.Error
+}
+
+// cancelRepeatedHolds cancels the customer's holds that repeat another of
+// their active holds, by the same rule PlaceHold uses to refuse a second
+// hold. Ready holds are kept over waiting ones, then the oldest.
+func cancelRepeatedHolds(tx *gorm.DB, customerID uuid.UUID, cancelHold domain.HoldCancelFunc) (int, error) {
+	var holds []domain.Hold
+	if err := tx.Where("customer_id = ? AND status IN ?", customerID, []string{constants.HoldStatusWaiting, constants.HoldStatusReady}).
+		Order("created_at").Find(&holds).Error; err != nil {
+		return 0, err
+	}
+	sort.SliceStable(holds, func(i, j int) bool {
+		return holds[i].Status == constants.HoldStatusReady && holds[j].Status != constants.HoldStatusReady
+	})
+
+	var bookIDs []uuid.UUID
+	for _, hold := range holds {
+		if hold.BookID != nil {
+			bookIDs = append(bookIDs, *hold.BookID)
+		}
+	}
+	var books []domain.Book
+	if len(bookIDs) > 0 {
+		if err := tx.Unscoped().Select("id", "work_id").Where("id IN ?", bookIDs).Find(&books).Error; err != nil {
+			return 0, err
+		}
+	}
+	works := make(map[uuid.UUID]uuid.UUID)
+	for _, book := range books {
+		if book.WorkID != nil {
+			works[book.ID] = *book.WorkID
+		}
+	}
+
+	repeats := func(a, b domain.Hold) bool {
+		switch {
+		case a.BookID != nil && b.BookID != nil:
+			return *a.BookID == *b.BookID
+		case a.WorkID != nil && b.WorkID != nil:
+			return *a.WorkID == *b.WorkID
+		case a.BookID != nil && b.WorkID != nil:
+			work, ok := works[*a.BookID]
+			return ok && work == *b.WorkID
+		case a.WorkID != nil && b.BookID != nil:
+			work, ok := works[*b.BookID]
+			return ok && work == *a.WorkID
+		}
+		return false
+	}
+
+	var kept []domain.Hold
+	cancelled := 0
+	for i := range holds {
+		repeated := false
+		for _, other := range kept {
+			if repeats(holds[i], other) {
+				repeated = true
+				break
+			}
+		}
+		if !repeated {
+			kept = append(kept, holds[i])
+			continue
+		}
+		if err := cancelHold(tx, &holds[i]); err != nil {
+			return 0, err
+		}
+		cancelled++
+	}
+	return cancelled, nil
+}
+
+// mergeWishlists appends the items of the duplicate's wishlists to the
+// survivor's oldest wishlist, skipping books already on it, and deletes the
+// emptied lists. A survivor without a wishlist simply takes the duplicate's.
+func mergeWishlists(tx *gorm.DB, survivorID, duplicateID uuid.UUID) (int, error) {
+	var target domain.ReadingList
+	err := tx.Where("customer_id = ? AND kind = ?", survivorID, constants.ReadingListKindWishlist).
+		Order("created_at").First(&target).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var lists []domain.ReadingList
+	if err := tx.Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Where("customer_id = ? AND kind = ?", duplicateID, constants.ReadingListKindWishlist).
+		Order("created_at").Find(&lists).Error; err != nil {
+		return 0, err
+	}
+	if len(lists) == 0 {
+		return 0, nil
+	}
+
+	var last int
+	if err := tx.Model(&domain.ReadingListItem{}).Select("COALESCE(MAX(position), 0)").
+		Where("reading_list_id = ?", target.ID).Scan(&last).Error; err != nil {
+		return 0, err
+	}
+	var bookIDs []uuid.UUID
+	if err := tx.Model(&domain.ReadingListItem{}).Where("reading_list_id = ?", target.ID).
+		Pluck("book_id", &bookIDs).Error; err != nil {
+		return 0, err
+	}
+	onList := make(map[uuid.UUID]bool, len(bookIDs))
+	for _, id := range bookIDs {
+		onList[id] = true
+	}
+
+	added := 0
+	for _, list := range lists {
+		for _, item := range list.Items {
+			if onList[item.BookID] {
+				continue
+			}
+			onList[item.BookID] = true
+			last++
+			if err := tx.Model(&domain.ReadingListItem{}).Where("id = ?", item.ID).
+				Updates(map[string]interface{}{"reading_list_id": target.ID, "position": last}).Error; err != nil {
+				return 0, err
+			}
+			added++
+		}
+		if err := tx.Where("reading_list_id = ?", list.ID).Delete(&domain.ReadingListItem{}).Error; err != nil {
+			return 0, err
+		}
+		if err := tx.Delete(&domain.ReadingList{}, list.ID).Error; err != nil {
+			return 0, err
+		}
+	}
+	return added, nil
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/internal/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DependencyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewDependencyRepositoryImpl(db *gorm.DB) domain.DependencyRepository {
+	return &DependencyRepositoryImpl{db: db}
+}
+
+var openLoanStatuses = []string{constants.BookTransactionStatusBorrowed, constants.BookTransactionStatusOverdue}
+
+var activeTransferStatuses = []string{constants.TransferStatusRequested, constants.TransferStatusInTransit}
+
+func (r *DependencyRepositoryImpl) FindBookDependents(ctx context.Context, bookID uuid.UUID) (*domain.Dependents, error) {
+	dependents := &domain.Dependents{}
+	db := r.db.WithContext(ctx)
+
+	if err := db.Where("book_id = ? AND return_at IS NULL AND status IN ?", bookID, openLoanStatuses).
+		Find(&dependents.ActiveLoans).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Joins("JOIN book_transactions ON book_transactions.id = charges.book_transaction_id").
+		Where("book_transactions.book_id = ? AND charges.paid_at IS NULL", bookID).
+		Find(&dependents.OutstandingCharges).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Where("book_id = ? AND status <> ?", bookID, constants.BookStockStatusArchived).
+		Find(&dependents.Copies).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Where("stock_code IN (?) AND status IN ?", db.Model(&domain.BookStock{}).Select("code").Where("book_id = ?", bookID), activeTransferStatuses).
+		Find(&dependents.ActiveTransfers).Error; err != nil {
+		return nil, err
+	}
+
+	return dependents, nil
+}
+
+func (r *DependencyRepositoryImpl) FindBookstockDependents(ctx context.Context, code string) (*domain.Dependents, error) {
+	dependents := &domain.Dependents{}
+	db := r.db.WithContext(ctx)
+
+	var transactions []domain.BookTransaction
+	if err := db.Where("stock_code = ?", code).Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	for _, transaction := range transactions {
+		if transaction.ReturnAt == nil && (transaction.Status == constants.BookTransactionStatusBorrowed || transaction.Status == constants.BookTransactionStatusOverdue) {
+			dependents.ActiveLoans = append(dependents.ActiveLoans, transaction)
+		} else {
+			dependents.LoanHistory = append(dependents.LoanHistory, transaction)
+		}
+	}
+
+	if err := db.Joins("JOIN book_transactions ON book_transactions.id = charges.book_transaction_id").
+		Where("book_transactions.stock_code = ? AND charges.paid_at IS NULL", code).
+		Find(&dependents.OutstandingCharges).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Where("stock_code = ? AND status IN ?", code, activeTransferStatuses).
+		Find(&dependents.ActiveTransfers).Error; err != nil {
+		return nil, err
+	}
+
+	return dependents, nil
+}
+
+func (r *DependencyRepositoryImpl) FindCustomerDependents(ctx context.Context, customerID uuid.UUID) (*domain.Dependents, error) {
+	dependents := &domain.Dependents{}
+	db := r.db.WithContext(ctx)
+
+	if err := db.Where("customer_id = ? AND return_at IS NULL AND status IN ?", customerID, openLoanStatuses).
+		Find(&dependents.ActiveLoans).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Joins("JOIN book_transactions ON book_transactions.id = charges.book_transaction_id").
+		Where("book_transactions.customer_id = ? AND charges.paid_at IS NULL", customerID).
+		Find(&dependents.OutstandingCharges).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Where("customer_id = ? AND status IN ?", customerID, []string{constants.HoldStatusWaiting, constants.HoldStatusReady}).
+		Find(&dependents.ActiveHolds).Error; err != nil {
+		return nil, err
+	}
+
+	return dependents, nil
+}
+
+// CascadeDeleteBook soft-deletes the book and removes its copies. Copies
+// with loan or transfer history are archived instead, so circulation history
+// is never lost.
+func (r *DependencyRepositoryImpl) CascadeDeleteBook(ctx context.Context, bookID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var codes []string
+		if err := tx.Model(&domain.BookStock{}).Where("book_id = ?", bookID).Pluck("code", &codes).Error; err != nil {
+			return err
+		}
+
+		for _, code := range codes {
+			if err := removeCopy(tx, code); err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&domain.Book{}, bookID).Error
+	})
+}
+
+// ArchiveBook soft-deletes the book and keeps its copies for history with
+// the ARCHIVED status. Each copy's status log records what it was, so
+// UnarchiveBook can put it back.
+func (r *DependencyRepositoryImpl) ArchiveBook(ctx context.Context, bookID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var copies []domain.BookStock
+		if err := tx.Where("book_id = ? AND status <> ?", bookID, constants.BookStockStatusArchived).Find(&copies).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, stock := range copies {
+			if err := setCopyStatus(tx, stock.Code, stock.Status, constants.BookStockStatusArchived, constants.StatusReasonBookArchived, now); err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&domain.Book{}, bookID).Error
+	})
+}
+
+// UnarchiveBook restores a deleted book and returns the copies ArchiveBook
+// archived to the status they had. Copies archived on their own stay archived.
+func (r *DependencyRepositoryImpl) UnarchiveBook(ctx context.Context, bookID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&domain.Book{}).Where("id = ?", bookID).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		var codes []string
+		if err := tx.Model(&domain.BookStock{}).Where("book_id = ? AND status = ?", bookID, constants.BookStockStatusArchived).
+			Pluck("code", &codes).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, code := range codes {
+			var last domain.BookStockStatusLog
+			err := tx.Where("stock_code = ?", code).Order("created_at DESC").First(&last).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if last.Reason != constants.StatusReasonBookArchived || last.ToStatus != constants.BookStockStatusArchived {
+				continue
+			}
+			if err := setCopyStatus(tx, code, constants.BookStockStatusArchived, last.FromStatus, constants.StatusReasonBookRestored, now); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// setCopyStatus moves a copy from one status to another and logs the change.
+func setCopyStatus(tx *gorm.DB, code, from, to, reason string, now time.Time) error {
+	if err := tx.Model(&domain.BookStock{}).Where("code = ?", code).
+		Updates(map[string]interface{}{"status": to, "borrowed_id": nil, "borrowed_at": nil}).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&domain.BookStockStatusLog{
+		ID:         uuid.New(),
+		StockCode:  code,
+		FromStatus: from,
+		ToStatus:   to,
+		Reason:     reason,
+		CreatedAt:  now,
+	}).Error
+}
+
+func (r *DependencyRepositoryImpl) CascadeDeleteBookstock(ctx context.Context, code string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return removeCopy(tx, code)
+	})
+}
+
+func (r *DependencyRepositoryImpl) ArchiveBookstock(ctx context.Context, code string) error {
+	return r.db.WithContext(ctx).Model(&domain.BookStock{}).Where("code = ?", code).
+		Updates(map[string]interface{}{"status": constants.BookStockStatusArchived, "borrowed_id": nil, "borrowed_at": nil}).Error
+}
+
+// copyHistory lists the records that keep a copy's code in history. A copy
+// referenced by any of them is archived rather than deleted.
+var copyHistory = []struct {
+	model  interface{}
+	column string
+}{
+	{&domain.BookTransaction{}, "stock_code"},
+	{&domain.Transfer{}, "stock_code"},
+	{&domain.DeaccessionItem{}, "stock_code"},
+	{&domain.StocktakeMissing{}, "code"},
+}
+
+// removeCopy deletes a copy that has never circulated and archives one with
+// loan, transfer or inventory history. Callers have already checked none of
+// that history is still open.
+func removeCopy(tx *gorm.DB, code string) error {
+	for _, history := range copyHistory {
+		var count int64
+		if err := tx.Model(history.model).Where(history.column+" = ?", code).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return tx.Model(&domain.BookStock{}).Where("code = ?", code).
+				Updates(map[string]interface{}{"status": constants.BookStockStatusArchived, "borrowed_id": nil, "borrowed_at": nil}).Error
+		}
+	}
+	return tx.Delete(&domain.BookStock{}, "code = ?", code).Error
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CopyHeldExpr matches book_stocks rows set aside for a READY hold. Such a
+// copy stays AVAILABLE on the shelf but can only go to the hold's customer.
+var CopyHeldExpr = fmt.Sprintf("EXISTS (SELECT 1 FROM holds WHERE holds.stock_code = book_stocks.code AND holds.status = '%s')", constants.HoldStatusReady)
+
+type HoldRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewHoldRepositoryImpl(db *gorm.DB) domain.HoldRepository {
+	return &HoldRepositoryImpl{db: db}
+}
+
+func (r *HoldRepositoryImpl) Find(ctx context.Context, filter dto.HoldFilter) ([]domain.Hold, error) {
+	var holds []domain.Hold
+	err := applyHoldFilter(r.db.WithContext(ctx), filter).Order("created_at").Find(&holds).Error
+	return holds, err
+}
+
+func (r *HoldRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Hold, error) {
+	var hold domain.Hold
+	err := r.db.WithContext(ctx).First(&hold, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// CountActive counts the waiting and ready holds matching filter.
+func (r *HoldRepositoryImpl) CountActive(ctx context.Context, filter dto.HoldFilter) (int64, error) {
+	var count int64
+	err := applyHoldFilter(r.db.WithContext(ctx).Model(&domain.Hold{}), filter).
+		Where("status IN ?", []string{constants.HoldStatusWaiting, constants.HoldStatusReady}).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *HoldRepositoryImpl) FindExpired(ctx context.Context, at time.Time) ([]domain.Hold, error) {
+	var holds []domain.Hold
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND pickup_by < ?", constants.HoldStatusReady, at).
+		Order("pickup_by").
+		Find(&holds).Error
+	return holds, err
+}
+
+func (r *HoldRepositoryImpl) Create(ctx context.Context, hold *domain.Hold) error {
+	return r.db.WithContext(ctx).Create(hold).Error
+}
+
+func (r *HoldRepositoryImpl) Update(ctx context.Context, hold *domain.Hold) error {
+	return r.db.WithContext(ctx).Save(hold).Error
+}
+
+func (r *HoldRepositoryImpl) GetDB() *gorm.DB {
+	return r.db
+}
+
+func applyHoldFilter(query *gorm.DB, filter dto.HoldFilter) *gorm.DB {
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filter.CustomerID)
+	}
+	if filter.BookID != nil {
+		query = query.Where("book_id = ?", *filter.BookID)
+	}
+	if filter.WorkID != nil {
+		query = query.Where("work_id = ?", *filter.WorkID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	return query
+}
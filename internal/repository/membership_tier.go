@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MembershipTierRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMembershipTierRepositoryImpl(db *gorm.DB) domain.MembershipTierRepository {
+	return &MembershipTierRepositoryImpl{db: db}
+}
+
+func (r *MembershipTierRepositoryImpl) FindAll(ctx context.Context) ([]domain.MembershipTier, error) {
+	var tiers []domain.MembershipTier
+	err := r.db.WithContext(ctx).Order("code").Find(&tiers).Error
+	return tiers, err
+}
+
+func (r *MembershipTierRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.MembershipTier, error) {
+	var tier domain.MembershipTier
+	err := r.db.WithContext(ctx).First(&tier, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+func (r *MembershipTierRepositoryImpl) FindByCode(ctx context.Context, code string) (*domain.MembershipTier, error) {
+	var tier domain.MembershipTier
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&tier).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+func (r *MembershipTierRepositoryImpl) Create(ctx context.Context, tier *domain.MembershipTier) error {
+	return r.db.WithContext(ctx).Create(tier).Error
+}
+
+func (r *MembershipTierRepositoryImpl) Update(ctx context.Context, tier *domain.MembershipTier) error {
+	return r.db.WithContext(ctx).Save(tier).Error
+}
+
+func (r *MembershipTierRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.MembershipTier{}, id).Error
+}
+
+// CountCustomers counts customers, trashed ones included, on the tier.
+func (r *MembershipTierRepositoryImpl) CountCustomers(ctx context.Context, code string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&domain.Customer{}).Where("membership_type = ?", code).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReadingListRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReadingListRepositoryImpl(db *gorm.DB) domain.ReadingListRepository {
+	return &ReadingListRepositoryImpl{db: db}
+}
+
+func (r *ReadingListRepositoryImpl) FindAll(ctx context.Context, filter dto.ReadingListFilter) ([]domain.ReadingList, error) {
+	var lists []domain.ReadingList
+	query := r.db.WithContext(ctx).Preload("Cover").Preload("Items").Preload("Items.Book")
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Visibility != "" {
+		query = query.Where("visibility = ?", filter.Visibility)
+	}
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filter.CustomerID)
+	}
+	err := query.Order("updated_at DESC").Find(&lists).Error
+	return lists, err
+}
+
+// FindPublished returns the public lists whose publish window contains at.
+func (r *ReadingListRepositoryImpl) FindPublished(ctx context.Context, at time.Time) ([]domain.ReadingList, error) {
+	var lists []domain.ReadingList
+	err := r.db.WithContext(ctx).Preload("Cover").Preload("Items").Preload("Items.Book").
+		Where("visibility = ?", constants.ReadingListVisibilityPublic).
+		Where("(publish_from IS NULL OR publish_from <= ?) AND (publish_until IS NULL OR publish_until > ?)", at, at).
+		Order("COALESCE(publish_from, created_at) DESC").
+		Find(&lists).Error
+	return lists, err
+}
+
+func (r *ReadingListRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.ReadingList, error) {
+	var list domain.ReadingList
+	err := r.db.WithContext(ctx).Preload("Cover").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Preload("Items.Book").
+		First(&list, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (r *ReadingListRepositoryImpl) Create(ctx context.Context, list *domain.ReadingList) error {
+	return r.db.WithContext(ctx).Omit("Cover", "Items").Create(list).Error
+}
+
+func (r *ReadingListRepositoryImpl) Update(ctx context.Context, list *domain.ReadingList) error {
+	return r.db.WithContext(ctx).Omit("Cover", "Items").Save(list).Error
+}
+
+func (r *ReadingListRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("reading_list_id = ?", id).Delete(&domain.ReadingListItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.ReadingList{}, id).Error
+	})
+}
+
+// AddItem appends the item, or inserts it at item.Position and moves the
+// items from that position down by one.
+func (r *ReadingListRepositoryImpl) AddItem(ctx context.Context, item *domain.ReadingListItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var last int
+		err := tx.Model(&domain.ReadingListItem{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("reading_list_id = ?", item.ReadingListID).
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		if item.Position <= 0 || item.Position > last {
+			item.Position = last + 1
+		} else {
+			err := tx.Model(&domain.ReadingListItem{}).
+				Where("reading_list_id = ? AND position >= ?", item.ReadingListID, item.Position).
+				Update("position", gorm.Expr("position + 1")).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit("Book").Create(item).Error
+	})
+}
+
+func (r *ReadingListRepositoryImpl) UpdateItem(ctx context.Context, item *domain.ReadingListItem) error {
+	return r.db.WithContext(ctx).Omit("Book").Save(item).Error
+}
+
+// RemoveItem deletes the item and closes the gap it leaves in the order.
+func (r *ReadingListRepositoryImpl) RemoveItem(ctx context.Context, listID, bookID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item domain.ReadingListItem
+		if err := tx.Where("reading_list_id = ? AND book_id = ?", listID, bookID).First(&item).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&item).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.ReadingListItem{}).
+			Where("reading_list_id = ? AND position > ?", listID, item.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+}
+
+// Reorder numbers the items of the list in the order of bookIDs, starting
+// at one.
+func (r *ReadingListRepositoryImpl) Reorder(ctx context.Context, listID uuid.UUID, bookIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, bookID := range bookIDs {
+			err := tx.Model(&domain.ReadingListItem{}).
+				Where("reading_list_id = ? AND book_id = ?", listID, bookID).
+				Updates(map[string]interface{}{"position": i + 1, "updated_at": time.Now()}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
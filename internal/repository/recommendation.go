@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// loanPairsCTE lists each customer and book borrowed at least once, ignoring
+// books in the trash.
+const loanPairsCTE = `loans AS (
+	SELECT DISTINCT book_transactions.customer_id, book_transactions.book_id
+	FROM book_transactions
+	JOIN books ON books.id = book_transactions.book_id AND books.deleted_at IS NULL
+)`
+
+type RecommendationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRecommendationRepositoryImpl(db *gorm.DB) domain.RecommendationRepository {
+	return &RecommendationRepositoryImpl{db: db}
+}
+
+// Rebuild replaces both recommendation tables in one transaction, so readers
+// see either the previous run or the new one.
+func (r *RecommendationRepositoryImpl) Rebuild(ctx context.Context, minCoBorrow, limit int, computedAt time.Time) (*dto.RecommendationRunReport, error) {
+	report := &dto.RecommendationRunReport{ComputedAt: computedAt}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM customer_recommendations").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM book_recommendations").Error; err != nil {
+			return err
+		}
+
+		pairs := tx.Exec(`WITH `+loanPairsCTE+`,
+			scored AS (
+				SELECT a.book_id, b.book_id AS recommended_book_id, COUNT(*) AS score
+				FROM loans a
+				JOIN loans b ON b.customer_id = a.customer_id AND b.book_id <> a.book_id
+				GROUP BY a.book_id, b.book_id
+				HAVING COUNT(*) >= ?
+			),
+			ranked AS (
+				SELECT *, ROW_NUMBER() OVER (PARTITION BY book_id ORDER BY score DESC, recommended_book_id) AS rank
+				FROM scored
+			)
+			INSERT INTO book_recommendations (book_id, recommended_book_id, score, computed_at)
+			SELECT book_id, recommended_book_id, score, ? FROM ranked WHERE rank <= ?`,
+			minCoBorrow, computedAt, limit)
+		if pairs.Error != nil {
+			return pairs.Error
+		}
+		report.BookPairs = pairs.RowsAffected
+
+		// Personal suggestions are built only from the stored pairs, so they
+		// inherit the co-borrow threshold.
+		suggestions := tx.Exec(`WITH `+loanPairsCTE+`,
+			scored AS (
+				SELECT loans.customer_id, book_recommendations.recommended_book_id AS book_id, SUM(book_recommendations.score) AS score
+				FROM loans
+				JOIN book_recommendations ON book_recommendations.book_id = loans.book_id
+				WHERE NOT EXISTS (
+					SELECT 1 FROM loans seen
+					WHERE seen.customer_id = loans.customer_id AND seen.book_id = book_recommendations.recommended_book_id
+				)
+				GROUP BY loans.customer_id, book_recommendations.recommended_book_id
+			),
+			ranked AS (
+				SELECT *, ROW_NUMBER() OVER (PARTITION BY customer_id ORDER BY score DESC, book_id) AS rank
+				FROM scored
+			)
+			INSERT INTO customer_recommendations (customer_id, book_id, score, computed_at)
+			SELECT customer_id, book_id, score, ? FROM ranked WHERE rank <= ?`,
+			computedAt, limit)
+		if suggestions.Error != nil {
+			return suggestions.Error
+		}
+		report.CustomerSuggestions = suggestions.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (r *RecommendationRepositoryImpl) FindForBook(ctx context.Context, bookID uuid.UUID) ([]domain.BookRecommendation, error) {
+	var recommendations []domain.BookRecommendation
+	err := r.db.WithContext(ctx).
+		Joins("RecommendedBook").
+		Where("book_recommendations.book_id = ?", bookID).
+		Order("score DESC").
+		Find(&recommendations).Error
+	return recommendations, err
+}
+
+func (r *RecommendationRepositoryImpl) FindForCustomer(ctx context.Context, customerID uuid.UUID) ([]domain.CustomerRecommendation, error) {
+	var recommendations []domain.CustomerRecommendation
+	err := r.db.WithContext(ctx).
+		Joins("Book").
+		Where("customer_recommendations.customer_id = ?", customerID).
+		Order("score DESC").
+		Find(&recommendations).Error
+	return recommendations, err
+}
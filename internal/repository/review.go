@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReviewRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReviewRepositoryImpl(db *gorm.DB) domain.ReviewRepository {
+	return &ReviewRepositoryImpl{db: db}
+}
+
+func (r *ReviewRepositoryImpl) Find(ctx context.Context, filter dto.ReviewFilter) ([]domain.Review, error) {
+	var reviews []domain.Review
+	query := r.db.WithContext(ctx).Preload("Customer")
+	if filter.BookID != nil {
+		query = query.Where("book_id = ?", *filter.BookID)
+	}
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filter.CustomerID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Order("created_at DESC").Find(&reviews).Error
+	return reviews, err
+}
+
+func (r *ReviewRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Review, error) {
+	var review domain.Review
+	err := r.db.WithContext(ctx).Preload("Customer").First(&review, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepositoryImpl) ExistsForBook(ctx context.Context, bookID, customerID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Review{}).
+		Where("book_id = ? AND customer_id = ?", bookID, customerID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// HasCompletedLoan reports whether the customer has borrowed and returned
+// a copy of the book.
+func (r *ReviewRepositoryImpl) HasCompletedLoan(ctx context.Context, bookID, customerID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.BookTransaction{}).
+		Where("book_id = ? AND customer_id = ? AND return_at IS NOT NULL", bookID, customerID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ReviewRepositoryImpl) Create(ctx context.Context, review *domain.Review) error {
+	return r.db.WithContext(ctx).Omit("Customer").Create(review).Error
+}
+
+func (r *ReviewRepositoryImpl) Update(ctx context.Context, review *domain.Review) error {
+	return r.db.WithContext(ctx).Omit("Customer").Save(review).Error
+}
+
+func (r *ReviewRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&domain.Review{}, id).Error
+}
+
+// RefreshBookRating recomputes the stored rating of a book from its approved
+// reviews. The values live on the book so the catalog can sort by them.
+func (r *ReviewRepositoryImpl) RefreshBookRating(ctx context.Context, bookID uuid.UUID) error {
+	return r.db.WithContext(ctx).Exec(`UPDATE books SET
+			rating_average = COALESCE((SELECT ROUND(AVG(rating)::numeric, 2) FROM reviews WHERE book_id = ? AND status = ?), 0),
+			rating_count = (SELECT COUNT(*) FROM reviews WHERE book_id = ? AND status = ?)
+		WHERE id = ?`,
+		bookID, constants.ReviewStatusApproved, bookID, constants.ReviewStatusApproved, bookID).Error
+}
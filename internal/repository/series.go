@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SeriesRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSeriesRepositoryImpl(db *gorm.DB) domain.SeriesRepository {
+	return &SeriesRepositoryImpl{db: db}
+}
+
+func (r *SeriesRepositoryImpl) FindAll(ctx context.Context, search string) ([]domain.Series, error) {
+	var series []domain.Series
+	query := r.db.WithContext(ctx)
+	if search != "" {
+		query = query.Where("title LIKE ?", "%"+search+"%")
+	}
+	err := query.Order("title").Find(&series).Error
+	return series, err
+}
+
+func (r *SeriesRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Series, error) {
+	var series domain.Series
+	err := r.db.WithContext(ctx).
+		Preload("Books", func(db *gorm.DB) *gorm.DB {
+			return db.Order("series_volume NULLS LAST, title")
+		}).
+		First(&series, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *SeriesRepositoryImpl) Create(ctx context.Context, series *domain.Series) error {
+	return r.db.WithContext(ctx).Create(series).Error
+}
+
+func (r *SeriesRepositoryImpl) Update(ctx context.Context, series *domain.Series) error {
+	return r.db.WithContext(ctx).Omit("Books").Save(series).Error
+}
+
+// Delete removes the series and clears the membership of its books.
+func (r *SeriesRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&domain.Book{}).Where("series_id = ?", id).
+			Updates(map[string]interface{}{"series_id": nil, "series_volume": nil}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Series{}, id).Error
+	})
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StockCodeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewStockCodeRepositoryImpl(db *gorm.DB) domain.StockCodeRepository {
+	return &StockCodeRepositoryImpl{db: db}
+}
+
+// Reserve bumps the counter with a single upsert so concurrent callers never
+// receive the same numbers.
+func (r *StockCodeRepositoryImpl) Reserve(ctx context.Context, scope string, count int) (int64, error) {
+	var last int64
+	err := r.db.WithContext(ctx).Raw(`INSERT INTO stock_code_sequences (scope, value, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (scope) DO UPDATE SET value = stock_code_sequences.value + EXCLUDED.value, updated_at = EXCLUDED.updated_at
+		RETURNING value`, scope, count, time.Now()).Scan(&last).Error
+	if err != nil {
+		return 0, err
+	}
+	return last - int64(count) + 1, nil
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/internal/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StocktakeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewStocktakeRepositoryImpl(db *gorm.DB) domain.StocktakeRepository {
+	return &StocktakeRepositoryImpl{db: db}
+}
+
+// shelvedStatuses are the copy statuses a stocktake expects to find.
+var shelvedStatuses = []string{constants.BookStockStatusAvailable, constants.BookStockStatusDamaged}
+
+func (r *StocktakeRepositoryImpl) FindAll(ctx context.Context) ([]domain.Stocktake, error) {
+	var stocktakes []domain.Stocktake
+	err := r.db.WithContext(ctx).Order("created_at DESC").Find(&stocktakes).Error
+	return stocktakes, err
+}
+
+func (r *StocktakeRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Stocktake, error) {
+	var stocktake domain.Stocktake
+	err := r.db.WithContext(ctx).First(&stocktake, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stocktake, nil
+}
+
+func (r *StocktakeRepositoryImpl) Create(ctx context.Context, stocktake *domain.Stocktake) error {
+	return r.db.WithContext(ctx).Create(stocktake).Error
+}
+
+func (r *StocktakeRepositoryImpl) Update(ctx context.Context, stocktake *domain.Stocktake) error {
+	return r.db.WithContext(ctx).Save(stocktake).Error
+}
+
+func (r *StocktakeRepositoryImpl) AddScans(ctx context.Context, id uuid.UUID, codes []string, scannedAt time.Time) (int64, error) {
+	scans := make([]domain.StocktakeScan, 0, len(codes))
+	for _, code := range codes {
+		scans = append(scans, domain.StocktakeScan{StocktakeID: id, Code: code, ScannedAt: scannedAt})
+	}
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(scans, 500)
+	return result.RowsAffected, result.Error
+}
+
+func (r *StocktakeRepositoryImpl) CountScans(ctx context.Context, id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.StocktakeScan{}).Where("stocktake_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *StocktakeRepositoryImpl) CountExpected(ctx context.Context, stocktake *domain.Stocktake) (int64, error) {
+	var count int64
+	err := applyStocktakeScope(r.db.WithContext(ctx).Model(&domain.BookStock{}), stocktake).
+		Where("book_stocks.status IN ?", shelvedStatuses).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *StocktakeRepositoryImpl) FindMissing(ctx context.Context, stocktake *domain.Stocktake) ([]domain.BookStock, error) {
+	var bookstocks []domain.BookStock
+	err := applyStocktakeScope(r.db.WithContext(ctx).Preload("Book"), stocktake).
+		Where("book_stocks.status IN ?", shelvedStatuses).
+		Where("NOT EXISTS (SELECT 1 FROM stocktake_scans WHERE stocktake_scans.stocktake_id = ? AND stocktake_scans.code = book_stocks.code)", stocktake.ID).
+		Order("book_stocks.code").
+		Find(&bookstocks).Error
+	return bookstocks, err
+}
+
+func (r *StocktakeRepositoryImpl) Close(ctx context.Context, stocktake *domain.Stocktake) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		missing, err := (&StocktakeRepositoryImpl{db: tx}).FindMissing(ctx, stocktake)
+		if err != nil {
+			return err
+		}
+
+		snapshot := make([]domain.StocktakeMissing, 0, len(missing))
+		for _, stock := range missing {
+			snapshot = append(snapshot, domain.StocktakeMissing{StocktakeID: stocktake.ID, Code: stock.Code, Status: stock.Status})
+		}
+		if len(snapshot) > 0 {
+			if err := tx.Omit(clause.Associations).CreateInBatches(snapshot, 500).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(stocktake).Error
+	})
+}
+
+func (r *StocktakeRepositoryImpl) FindMissingSnapshot(ctx context.Context, id uuid.UUID) ([]domain.StocktakeMissing, error) {
+	var missing []domain.StocktakeMissing
+	err := r.db.WithContext(ctx).Preload("BookStock").Preload("BookStock.Book").
+		Where("stocktake_id = ?", id).
+		Order("code").
+		Find(&missing).Error
+	return missing, err
+}
+
+func (r *StocktakeRepositoryImpl) FindScannedWithStatus(ctx context.Context, id uuid.UUID, statuses []string) ([]domain.BookStock, error) {
+	var bookstocks []domain.BookStock
+	err := r.db.WithContext(ctx).Preload("Book").
+		Joins("JOIN stocktake_scans ON stocktake_scans.code = book_stocks.code AND stocktake_scans.stocktake_id = ?", id).
+		Where("book_stocks.status IN ?", statuses).
+		Order("book_stocks.code").
+		Find(&bookstocks).Error
+	return bookstocks, err
+}
+
+func (r *StocktakeRepositoryImpl) FindUnknownScans(ctx context.Context, id uuid.UUID) ([]string, error) {
+	codes := []string{}
+	err := r.db.WithContext(ctx).Model(&domain.StocktakeScan{}).
+		Joins("LEFT JOIN book_stocks ON book_stocks.code = stocktake_scans.code").
+		Where("stocktake_scans.stocktake_id = ? AND book_stocks.code IS NULL", id).
+		Order("stocktake_scans.code").
+		Pluck("stocktake_scans.code", &codes).Error
+	return codes, err
+}
+
+func (r *StocktakeRepositoryImpl) FindOutOfScopeScans(ctx context.Context, stocktake *domain.Stocktake) ([]string, error) {
+	inScope := applyStocktakeScope(r.db.Model(&domain.BookStock{}).Select("book_stocks.code"), stocktake)
+
+	codes := []string{}
+	err := r.db.WithContext(ctx).Model(&domain.StocktakeScan{}).
+		Joins("JOIN book_stocks ON book_stocks.code = stocktake_scans.code").
+		Where("stocktake_scans.stocktake_id = ?", stocktake.ID).
+		Where("stocktake_scans.code NOT IN (?)", inScope).
+		Order("stocktake_scans.code").
+		Pluck("stocktake_scans.code", &codes).Error
+	return codes, err
+}
+
+// Approve only touches copies still in the status the log recorded, so a
+// copy borrowed since the report was built keeps its loan.
+func (r *StocktakeRepositoryImpl) Approve(ctx context.Context, stocktake *domain.Stocktake, logs []domain.BookStockStatusLog, onChange domain.StatusChangeHook) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		applied := make([]domain.BookStockStatusLog, 0, len(logs))
+		for _, log := range logs {
+			result := tx.Model(&domain.BookStock{}).Where("code = ? AND status = ?", log.StockCode, log.FromStatus).
+				Update("status", log.ToStatus)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+			if err := onChange(tx, log); err != nil {
+				return err
+			}
+			applied = append(applied, log)
+		}
+
+		if len(applied) > 0 {
+			if err := tx.Create(&applied).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(stocktake).Error
+	})
+}
+
+// applyStocktakeScope limits book_stocks to the session's location, prefix
+// and range, leaving archived and withdrawn copies out.
+func applyStocktakeScope(query *gorm.DB, stocktake *domain.Stocktake) *gorm.DB {
+	query = query.Where("book_stocks.status NOT IN ?", retiredStatuses)
+	if stocktake.BranchID != nil {
+		query = query.Where("book_stocks.current_branch_id = ?", *stocktake.BranchID)
+	}
+	if stocktake.ShelfID != nil {
+		query = query.Where("book_stocks.current_shelf_id = ?", *stocktake.ShelfID)
+	}
+	if stocktake.Prefix != "" {
+		query = query.Where("book_stocks.code LIKE ?", stocktake.Prefix+"%")
+	}
+	if stocktake.CodeFrom != "" {
+		query = query.Where("book_stocks.code BETWEEN ? AND ?", stocktake.CodeFrom, stocktake.CodeTo)
+	}
+	return query
+}
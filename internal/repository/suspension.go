@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SuspensionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSuspensionRepositoryImpl(db *gorm.DB) domain.SuspensionRepository {
+	return &SuspensionRepositoryImpl{db: db}
+}
+
+func (r *SuspensionRepositoryImpl) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]domain.Suspension, error) {
+	var suspensions []domain.Suspension
+	err := r.db.WithContext(ctx).Where("customer_id = ?", customerID).Order("created_at DESC").Find(&suspensions).Error
+	return suspensions, err
+}
+
+func (r *SuspensionRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Suspension, error) {
+	var suspension domain.Suspension
+	err := r.db.WithContext(ctx).First(&suspension, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &suspension, nil
+}
+
+func (r *SuspensionRepositoryImpl) Create(ctx context.Context, suspension *domain.Suspension) error {
+	return r.db.WithContext(ctx).Create(suspension).Error
+}
+
+func (r *SuspensionRepositoryImpl) Update(ctx context.Context, suspension *domain.Suspension) error {
+	return r.db.WithContext(ctx).Save(suspension).Error
+}
+
+func (r *SuspensionRepositoryImpl) HasActive(ctx context.Context, customerID uuid.UUID, now time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Suspension{}).
+		Where("customer_id = ? AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", customerID, now).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TransferRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTransferRepositoryImpl(db *gorm.DB) domain.TransferRepository {
+	return &TransferRepositoryImpl{db: db}
+}
+
+func (r *TransferRepositoryImpl) Find(ctx context.Context, filter dto.TransferFilter) ([]domain.Transfer, error) {
+	var transfers []domain.Transfer
+	err := applyTransferFilter(preloadTransfer(r.db.WithContext(ctx)), filter).
+		Order("created_at").
+		Find(&transfers).Error
+	return transfers, err
+}
+
+func (r *TransferRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Transfer, error) {
+	var transfer domain.Transfer
+	err := preloadTransfer(r.db.WithContext(ctx)).First(&transfer, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r *TransferRepositoryImpl) GetDB() *gorm.DB {
+	return r.db
+}
+
+func preloadTransfer(query *gorm.DB) *gorm.DB {
+	return query.Preload("BookStock").Preload("BookStock.Book").Preload("FromBranch").Preload("ToBranch")
+}
+
+func applyTransferFilter(query *gorm.DB, filter dto.TransferFilter) *gorm.DB {
+	if filter.BranchID != nil {
+		switch filter.Direction {
+		case constants.TransferDirectionIncoming:
+			query = query.Where("to_branch_id = ?", *filter.BranchID)
+		case constants.TransferDirectionOutgoing:
+			query = query.Where("from_branch_id = ?", *filter.BranchID)
+		default:
+			query = query.Where("to_branch_id = ? OR from_branch_id = ?", *filter.BranchID, *filter.BranchID)
+		}
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.StockCode != "" {
+		query = query.Where("stock_code = ?", filter.StockCode)
+	}
+	if filter.HoldID != nil {
+		query = query.Where("hold_id = ?", *filter.HoldID)
+	}
+	return query
+}
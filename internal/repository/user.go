@@ -6,6 +6,7 @@ import (
 	"go-rest-api/domain"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
 )
 
 type userRepository struct {
@@ -45,3 +46,15 @@ func (u userRepository) FindByEmail(ctx context.Context, email string) (user dom
 	_, err = dataset.ScanStructContext(ctx, &user)
 	return
 }
+
+func (u userRepository) FindByCustomerID(ctx context.Context, customerID uuid.UUID) (user domain.User, err error) {
+	dataset := u.db.From("users").Where(goqu.Ex{
+		"customer_id": customerID,
+	})
+	_, err = dataset.ScanStructContext(ctx, &user)
+	return
+}
+
+func (u userRepository) Count(ctx context.Context) (int64, error) {
+	return u.db.From("users").CountContext(ctx)
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WeedingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWeedingRepositoryImpl(db *gorm.DB) domain.WeedingRepository {
+	return &WeedingRepositoryImpl{db: db}
+}
+
+// FindCandidates treats a never-borrowed copy as unused once it has been in
+// stock since before cutoff; copies without a creation date predate the
+// column and always qualify.
+func (r *WeedingRepositoryImpl) FindCandidates(ctx context.Context, filter dto.WeedingFilter, cutoff time.Time) ([]dto.WeedingCandidate, error) {
+	pending := r.db.Model(&domain.DeaccessionItem{}).Select("deaccession_items.stock_code").
+		Joins("JOIN deaccessions ON deaccessions.id = deaccession_items.deaccession_id").
+		Where("deaccessions.status = ?", constants.DeaccessionStatusPending)
+
+	query := r.db.WithContext(ctx).Table("book_stocks").
+		Select(`book_stocks.code, book_stocks.book_id, books.title, book_stocks.status, book_stocks.condition,
+			book_stocks.current_branch_id AS branch_id, book_stocks.current_shelf_id AS shelf_id,
+			COUNT(book_transactions.id) AS loan_count,
+			MAX(book_transactions.borrowed_at) AS last_borrowed_at,
+			book_stocks.created_at AS added_at`).
+		Joins("JOIN books ON books.id = book_stocks.book_id").
+		Joins("LEFT JOIN book_transactions ON book_transactions.stock_code = book_stocks.code").
+		Where("book_stocks.status IN ?", shelvedStatuses).
+		Where("book_stocks.code NOT IN (?)", pending)
+	if filter.BranchID != nil {
+		query = query.Where("book_stocks.current_branch_id = ?", *filter.BranchID)
+	}
+	if filter.ShelfID != nil {
+		query = query.Where("book_stocks.current_shelf_id = ?", *filter.ShelfID)
+	}
+
+	candidates := []dto.WeedingCandidate{}
+	err := query.Group("book_stocks.code, books.id").
+		Having(`book_stocks.status = ? OR book_stocks.condition = ?
+			OR MAX(book_transactions.borrowed_at) < ?
+			OR (MAX(book_transactions.borrowed_at) IS NULL AND (book_stocks.created_at IS NULL OR book_stocks.created_at < ?))`,
+			constants.BookStockStatusDamaged, constants.ConditionPoor, cutoff, cutoff).
+		Order("last_borrowed_at NULLS FIRST, book_stocks.code").
+		Limit(filter.Limit).
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+func (r *WeedingRepositoryImpl) FindPendingCodes(ctx context.Context, codes []string) ([]string, error) {
+	pending := []string{}
+	if len(codes) == 0 {
+		return pending, nil
+	}
+	err := r.db.WithContext(ctx).Model(&domain.DeaccessionItem{}).
+		Joins("JOIN deaccessions ON deaccessions.id = deaccession_items.deaccession_id").
+		Where("deaccessions.status = ? AND deaccession_items.stock_code IN ?", constants.DeaccessionStatusPending, codes).
+		Pluck("deaccession_items.stock_code", &pending).Error
+	return pending, err
+}
+
+func (r *WeedingRepositoryImpl) FindAll(ctx context.Context, status string) ([]domain.Deaccession, error) {
+	var deaccessions []domain.Deaccession
+	query := preloadDeaccession(r.db.WithContext(ctx))
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at DESC").Find(&deaccessions).Error
+	return deaccessions, err
+}
+
+func (r *WeedingRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Deaccession, error) {
+	var deaccession domain.Deaccession
+	err := preloadDeaccession(r.db.WithContext(ctx)).First(&deaccession, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &deaccession, nil
+}
+
+func (r *WeedingRepositoryImpl) Create(ctx context.Context, deaccession *domain.Deaccession) error {
+	return r.db.WithContext(ctx).Omit("Items.BookStock").Create(deaccession).Error
+}
+
+func (r *WeedingRepositoryImpl) Update(ctx context.Context, deaccession *domain.Deaccession) error {
+	return r.db.WithContext(ctx).Omit("Items").Save(deaccession).Error
+}
+
+// Approve only withdraws copies still in the status they were proposed in,
+// so a copy borrowed since then keeps its loan.
+func (r *WeedingRepositoryImpl) Approve(ctx context.Context, deaccession *domain.Deaccession, logs []domain.BookStockStatusLog, onChange domain.StatusChangeHook) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		applied := make([]domain.BookStockStatusLog, 0, len(logs))
+		for _, log := range logs {
+			result := tx.Model(&domain.BookStock{}).Where("code = ? AND status = ?", log.StockCode, log.FromStatus).
+				Updates(map[string]interface{}{
+					"status":           log.ToStatus,
+					"withdrawn_at":     log.CreatedAt,
+					"withdrawn_reason": deaccession.Reason,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+
+			if err := tx.Model(&domain.DeaccessionItem{}).
+				Where("deaccession_id = ? AND stock_code = ?", deaccession.ID, log.StockCode).
+				Update("withdrawn", true).Error; err != nil {
+				return err
+			}
+			if err := onChange(tx, log); err != nil {
+				return err
+			}
+			applied = append(applied, log)
+		}
+
+		if len(applied) > 0 {
+			if err := tx.Create(&applied).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit("Items").Save(deaccession).Error
+	})
+}
+
+func preloadDeaccession(query *gorm.DB) *gorm.DB {
+	return query.Preload("Items").Preload("Items.BookStock").Preload("Items.BookStock.Book")
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"go-rest-api/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WorkRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWorkRepositoryImpl(db *gorm.DB) domain.WorkRepository {
+	return &WorkRepositoryImpl{db: db}
+}
+
+func (r *WorkRepositoryImpl) FindAll(ctx context.Context, search string) ([]domain.Work, error) {
+	var works []domain.Work
+	query := r.db.WithContext(ctx)
+	if search != "" {
+		query = query.Where("title LIKE ? OR author LIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	err := query.Order("title").Find(&works).Error
+	return works, err
+}
+
+func (r *WorkRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Work, error) {
+	var work domain.Work
+	err := r.db.WithContext(ctx).
+		Preload("Editions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("publication_year DESC, created_at DESC")
+		}).
+		First(&work, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &work, nil
+}
+
+func (r *WorkRepositoryImpl) CountEditions(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(ids))
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		WorkID uuid.UUID
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).Model(&domain.Book{}).
+		Select("work_id, COUNT(*) AS count").
+		Where("work_id IN ?", ids).
+		Group("work_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.WorkID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *WorkRepositoryImpl) Create(ctx context.Context, work *domain.Work) error {
+	return r.db.WithContext(ctx).Create(work).Error
+}
+
+func (r *WorkRepositoryImpl) Update(ctx context.Context, work *domain.Work) error {
+	return r.db.WithContext(ctx).Omit("Editions").Save(work).Error
+}
+
+// Delete removes the work and detaches its editions, which stay in the
+// catalog as standalone books.
+func (r *WorkRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&domain.Book{}).Where("work_id = ?", id).Update("work_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Work{}, id).Error
+	})
+}
@@ -0,0 +1,593 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/config"
+	"go-rest-api/internal/constants"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type acquisitionService struct {
+	acquisitionRepo domain.AcquisitionRepository
+	bookRepo        domain.BookRepository
+	branchRepo      domain.BranchRepository
+	codes           *stockCodeGenerator
+}
+
+func NewAcquisitionService(
+	acquisitionRepo domain.AcquisitionRepository,
+	bookRepo domain.BookRepository,
+	bookstockRepo domain.BookstockRepository,
+	branchRepo domain.BranchRepository,
+	stockCodeRepo domain.StockCodeRepository,
+	config *config.Config,
+) domain.AcquisitionService {
+	return &acquisitionService{
+		acquisitionRepo: acquisitionRepo,
+		bookRepo:        bookRepo,
+		branchRepo:      branchRepo,
+		codes: &stockCodeGenerator{
+			stockCodeRepo: stockCodeRepo,
+			bookstockRepo: bookstockRepo,
+			config:        config,
+		},
+	}
+}
+
+func (s *acquisitionService) GetVendors(ctx context.Context, search string) ([]dto.VendorResponse, error) {
+	vendors, err := s.acquisitionRepo.FindVendors(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.VendorResponse, 0, len(vendors))
+	for _, vendor := range vendors {
+		responses = append(responses, toVendorResponse(&vendor))
+	}
+
+	return responses, nil
+}
+
+func (s *acquisitionService) GetVendorByID(ctx context.Context, id uuid.UUID) (*dto.VendorResponse, error) {
+	vendor, err := s.findVendor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toVendorResponse(vendor)
+	return &response, nil
+}
+
+func (s *acquisitionService) CreateVendor(ctx context.Context, req dto.VendorRequest) (*dto.VendorResponse, error) {
+	vendor := &domain.Vendor{ID: uuid.New()}
+	return s.saveVendor(ctx, vendor, req)
+}
+
+func (s *acquisitionService) UpdateVendor(ctx context.Context, id uuid.UUID, req dto.VendorRequest) (*dto.VendorResponse, error) {
+	vendor, err := s.findVendor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveVendor(ctx, vendor, req)
+}
+
+func (s *acquisitionService) DeleteVendor(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.findVendor(ctx, id); err != nil {
+		return err
+	}
+
+	orders, err := s.acquisitionRepo.CountVendorOrders(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if orders > 0 {
+		return constants.ErrVendorInUse
+	}
+
+	if err := s.acquisitionRepo.DeleteVendor(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *acquisitionService) GetFunds(ctx context.Context, fiscalYear int) ([]dto.FundResponse, error) {
+	funds, err := s.acquisitionRepo.FindFunds(ctx, fiscalYear)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.FundResponse, 0, len(funds))
+	for _, fund := range funds {
+		responses = append(responses, toFundResponse(&fund))
+	}
+
+	return responses, nil
+}
+
+func (s *acquisitionService) GetFundByID(ctx context.Context, id uuid.UUID) (*dto.FundResponse, error) {
+	fund, err := s.findFund(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toFundResponse(fund)
+	return &response, nil
+}
+
+func (s *acquisitionService) CreateFund(ctx context.Context, req dto.FundRequest) (*dto.FundResponse, error) {
+	fund := &domain.Fund{ID: uuid.New()}
+	return s.saveFund(ctx, fund, req)
+}
+
+func (s *acquisitionService) UpdateFund(ctx context.Context, id uuid.UUID, req dto.FundRequest) (*dto.FundResponse, error) {
+	fund, err := s.findFund(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveFund(ctx, fund, req)
+}
+
+func (s *acquisitionService) DeleteFund(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.findFund(ctx, id); err != nil {
+		return err
+	}
+
+	lines, err := s.acquisitionRepo.CountFundLines(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if lines > 0 {
+		return constants.ErrFundInUse
+	}
+
+	if err := s.acquisitionRepo.DeleteFund(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *acquisitionService) GetFundReport(ctx context.Context, fiscalYear int) (*dto.FundReport, error) {
+	rows, err := s.acquisitionRepo.FundSpending(ctx, fiscalYear)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	report := &dto.FundReport{FiscalYear: fiscalYear, Funds: make([]dto.FundReportRow, 0, len(rows))}
+	for _, row := range rows {
+		row.Remaining = row.Budget - row.Spent - row.Committed
+		report.Budget += row.Budget
+		report.Spent += row.Spent
+		report.Committed += row.Committed
+		report.Remaining += row.Remaining
+		report.Funds = append(report.Funds, row)
+	}
+
+	return report, nil
+}
+
+func (s *acquisitionService) GetPurchaseOrders(ctx context.Context, filter dto.PurchaseOrderFilter) ([]dto.PurchaseOrderResponse, error) {
+	orders, err := s.acquisitionRepo.FindOrders(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.PurchaseOrderResponse, 0, len(orders))
+	for _, order := range orders {
+		responses = append(responses, toPurchaseOrderResponse(&order))
+	}
+
+	return responses, nil
+}
+
+func (s *acquisitionService) GetPurchaseOrderByID(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error) {
+	order, err := s.findOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPurchaseOrderResponse(order)
+	return &response, nil
+}
+
+func (s *acquisitionService) CreatePurchaseOrder(ctx context.Context, createdBy uuid.UUID, req dto.PurchaseOrderRequest) (*dto.PurchaseOrderResponse, error) {
+	if _, err := s.acquisitionRepo.FindOrderByNumber(ctx, req.Number); err == nil {
+		return nil, constants.ErrPurchaseOrderExists
+	}
+
+	order := &domain.PurchaseOrder{
+		ID:        uuid.New(),
+		Status:    constants.PurchaseOrderStatusDraft,
+		CreatedBy: &createdBy,
+	}
+	if err := s.applyOrderRequest(ctx, order, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.acquisitionRepo.CreateOrder(ctx, order); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.GetPurchaseOrderByID(ctx, order.ID)
+}
+
+// UpdatePurchaseOrder replaces the header and lines of a draft. Placed
+// orders are fixed; cancel and reorder instead.
+func (s *acquisitionService) UpdatePurchaseOrder(ctx context.Context, id uuid.UUID, req dto.PurchaseOrderRequest) (*dto.PurchaseOrderResponse, error) {
+	order, err := s.findOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != constants.PurchaseOrderStatusDraft {
+		return nil, constants.ErrPurchaseOrderNotDraft
+	}
+
+	if existing, err := s.acquisitionRepo.FindOrderByNumber(ctx, req.Number); err == nil && existing.ID != id {
+		return nil, constants.ErrPurchaseOrderExists
+	}
+
+	if err := s.applyOrderRequest(ctx, order, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.acquisitionRepo.ReplaceOrder(ctx, order); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.GetPurchaseOrderByID(ctx, order.ID)
+}
+
+func (s *acquisitionService) DeletePurchaseOrder(ctx context.Context, id uuid.UUID) error {
+	order, err := s.findOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+	if order.Status != constants.PurchaseOrderStatusDraft {
+		return constants.ErrPurchaseOrderNotDraft
+	}
+
+	if err := s.acquisitionRepo.DeleteOrder(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *acquisitionService) PlacePurchaseOrder(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error) {
+	order, err := s.findOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != constants.PurchaseOrderStatusDraft {
+		return nil, constants.ErrPurchaseOrderNotDraft
+	}
+
+	now := time.Now()
+	order.Status = constants.PurchaseOrderStatusOrdered
+	order.OrderedAt = &now
+
+	if err := s.acquisitionRepo.UpdateOrderStatus(ctx, order); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toPurchaseOrderResponse(order)
+	return &response, nil
+}
+
+// CancelPurchaseOrder closes an order. Copies already received stay in
+// stock and keep counting as spent; only the outstanding rest is released.
+func (s *acquisitionService) CancelPurchaseOrder(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error) {
+	order, err := s.findOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == constants.PurchaseOrderStatusReceived || order.Status == constants.PurchaseOrderStatusCancelled {
+		return nil, constants.ErrPurchaseOrderNotOpen
+	}
+
+	order.Status = constants.PurchaseOrderStatusCancelled
+
+	if err := s.acquisitionRepo.UpdateOrderStatus(ctx, order); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toPurchaseOrderResponse(order)
+	return &response, nil
+}
+
+// ReceivePurchaseOrder creates a copy for every item received, priced at
+// the line's unit price and shelved at its home location.
+func (s *acquisitionService) ReceivePurchaseOrder(ctx context.Context, id uuid.UUID, req dto.PurchaseOrderReceiveRequest) (*dto.PurchaseOrderReceipt, error) {
+	order, err := s.findOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != constants.PurchaseOrderStatusOrdered && order.Status != constants.PurchaseOrderStatusPartial {
+		return nil, constants.ErrPurchaseOrderNotOpen
+	}
+
+	lines := make(map[uuid.UUID]*domain.PurchaseOrderLine, len(order.Lines))
+	for i := range order.Lines {
+		lines[order.Lines[i].ID] = &order.Lines[i]
+	}
+
+	received := make(map[uuid.UUID]int, len(req.Lines))
+	for _, item := range req.Lines {
+		line, ok := lines[item.LineID]
+		if !ok {
+			return nil, constants.ErrPurchaseOrderLineNotFound
+		}
+		received[item.LineID] += item.Quantity
+		if line.Received+received[item.LineID] > line.Quantity {
+			return nil, constants.ErrPurchaseOrderOverflow
+		}
+	}
+
+	codes := make(map[uuid.UUID][]string, len(received))
+	var copies []domain.BookStock
+	for lineID, quantity := range received {
+		line := lines[lineID]
+
+		var branch *domain.Branch
+		if line.HomeBranchID != nil {
+			if branch, err = s.branchRepo.FindByID(ctx, *line.HomeBranchID); err != nil {
+				slog.ErrorContext(ctx, err.Error())
+				return nil, constants.ErrBranchNotFound
+			}
+		}
+
+		lineCodes, err := s.codes.Generate(ctx, branchCode(branch), quantity)
+		if err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, err
+		}
+		codes[lineID] = lineCodes
+
+		for _, code := range lineCodes {
+			price := line.UnitPrice
+			copies = append(copies, domain.BookStock{
+				Code:                code,
+				BookID:              line.BookID,
+				Status:              constants.BookStockStatusAvailable,
+				Condition:           constants.ConditionNew,
+				HomeBranchID:        line.HomeBranchID,
+				HomeShelfID:         line.HomeShelfID,
+				CurrentBranchID:     line.HomeBranchID,
+				CurrentShelfID:      line.HomeShelfID,
+				UnitPrice:           &price,
+				ReplacementCost:     &price,
+				PurchaseOrderLineID: &line.ID,
+			})
+		}
+		line.Received += quantity
+	}
+
+	order.Status = constants.PurchaseOrderStatusReceived
+	for _, line := range order.Lines {
+		if line.Received < line.Quantity {
+			order.Status = constants.PurchaseOrderStatusPartial
+			break
+		}
+	}
+
+	if err := s.acquisitionRepo.ReceiveCopies(ctx, order, received, copies); err != nil {
+		if !errors.Is(err, constants.ErrPurchaseOrderOverflow) {
+			slog.ErrorContext(ctx, err.Error())
+		}
+		return nil, err
+	}
+
+	return &dto.PurchaseOrderReceipt{
+		Order:  toPurchaseOrderResponse(order),
+		Copies: codes,
+	}, nil
+}
+
+// applyOrderRequest checks the vendor, books, funds and locations of req
+// and copies them onto order.
+func (s *acquisitionService) applyOrderRequest(ctx context.Context, order *domain.PurchaseOrder, req dto.PurchaseOrderRequest) error {
+	vendor, err := s.findVendor(ctx, req.VendorID)
+	if err != nil {
+		return err
+	}
+
+	funds := make(map[uuid.UUID]*domain.Fund)
+	lines := make([]domain.PurchaseOrderLine, 0, len(req.Lines))
+	for _, item := range req.Lines {
+		book, err := s.bookRepo.FindByID(ctx, item.BookID)
+		if err != nil {
+			return constants.ErrBookNotFound
+		}
+
+		fund, ok := funds[item.FundID]
+		if !ok {
+			if fund, err = s.findFund(ctx, item.FundID); err != nil {
+				return err
+			}
+			funds[item.FundID] = fund
+		}
+
+		branchID, _, err := resolveLocation(ctx, s.branchRepo, item.HomeBranchID, item.HomeShelfID)
+		if err != nil {
+			return err
+		}
+
+		lines = append(lines, domain.PurchaseOrderLine{
+			ID:           uuid.New(),
+			BookID:       item.BookID,
+			Book:         book,
+			FundID:       item.FundID,
+			Fund:         fund,
+			Quantity:     item.Quantity,
+			UnitPrice:    item.UnitPrice,
+			HomeBranchID: branchID,
+			HomeShelfID:  item.HomeShelfID,
+		})
+	}
+
+	order.Number = req.Number
+	order.VendorID = vendor.ID
+	order.Vendor = vendor
+	order.Note = req.Note
+	order.Lines = lines
+	return nil
+}
+
+func (s *acquisitionService) saveVendor(ctx context.Context, vendor *domain.Vendor, req dto.VendorRequest) (*dto.VendorResponse, error) {
+	vendor.Name = req.Name
+	vendor.Contact = req.Contact
+	vendor.Email = req.Email
+	vendor.Phone = req.Phone
+	vendor.Notes = req.Notes
+
+	if err := s.acquisitionRepo.SaveVendor(ctx, vendor); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toVendorResponse(vendor)
+	return &response, nil
+}
+
+func (s *acquisitionService) saveFund(ctx context.Context, fund *domain.Fund, req dto.FundRequest) (*dto.FundResponse, error) {
+	if existing, err := s.acquisitionRepo.FindFundByCode(ctx, req.Code, req.FiscalYear); err == nil && existing.ID != fund.ID {
+		return nil, constants.ErrFundExists
+	}
+
+	fund.Code = req.Code
+	fund.FiscalYear = req.FiscalYear
+	fund.Name = req.Name
+	fund.Budget = req.Budget
+
+	if err := s.acquisitionRepo.SaveFund(ctx, fund); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toFundResponse(fund)
+	return &response, nil
+}
+
+func (s *acquisitionService) findVendor(ctx context.Context, id uuid.UUID) (*domain.Vendor, error) {
+	vendor, err := s.acquisitionRepo.FindVendorByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrVendorNotFound
+		}
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	return vendor, nil
+}
+
+func (s *acquisitionService) findFund(ctx context.Context, id uuid.UUID) (*domain.Fund, error) {
+	fund, err := s.acquisitionRepo.FindFundByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrFundNotFound
+		}
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	return fund, nil
+}
+
+func (s *acquisitionService) findOrder(ctx context.Context, id uuid.UUID) (*domain.PurchaseOrder, error) {
+	order, err := s.acquisitionRepo.FindOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrPurchaseOrderNotFound
+		}
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	return order, nil
+}
+
+func toVendorResponse(vendor *domain.Vendor) dto.VendorResponse {
+	return dto.VendorResponse{
+		ID:        vendor.ID,
+		Name:      vendor.Name,
+		Contact:   vendor.Contact,
+		Email:     vendor.Email,
+		Phone:     vendor.Phone,
+		Notes:     vendor.Notes,
+		CreatedAt: vendor.CreatedAt,
+		UpdatedAt: vendor.UpdatedAt,
+	}
+}
+
+func toFundResponse(fund *domain.Fund) dto.FundResponse {
+	return dto.FundResponse{
+		ID:         fund.ID,
+		Code:       fund.Code,
+		FiscalYear: fund.FiscalYear,
+		Name:       fund.Name,
+		Budget:     fund.Budget,
+		CreatedAt:  fund.CreatedAt,
+		UpdatedAt:  fund.UpdatedAt,
+	}
+}
+
+func toPurchaseOrderResponse(order *domain.PurchaseOrder) dto.PurchaseOrderResponse {
+	response := dto.PurchaseOrderResponse{
+		ID:        order.ID,
+		Number:    order.Number,
+		VendorID:  order.VendorID,
+		Status:    order.Status,
+		Note:      order.Note,
+		CreatedBy: order.CreatedBy,
+		OrderedAt: order.OrderedAt,
+		Lines:     make([]dto.PurchaseOrderLineResponse, 0, len(order.Lines)),
+		CreatedAt: order.CreatedAt,
+		UpdatedAt: order.UpdatedAt,
+	}
+	if order.Vendor != nil {
+		response.VendorName = order.Vendor.Name
+	}
+
+	for _, line := range order.Lines {
+		item := dto.PurchaseOrderLineResponse{
+			ID:           line.ID,
+			BookID:       line.BookID,
+			FundID:       line.FundID,
+			Quantity:     line.Quantity,
+			Received:     line.Received,
+			UnitPrice:    line.UnitPrice,
+			Total:        float64(line.Quantity) * line.UnitPrice,
+			HomeBranchID: line.HomeBranchID,
+			HomeShelfID:  line.HomeShelfID,
+		}
+		if line.Book != nil {
+			item.Title = line.Book.Title
+		}
+		if line.Fund != nil {
+			item.FundCode = line.Fund.Code
+		}
+		response.Total += item.Total
+		response.Lines = append(response.Lines, item)
+	}
+
+	return response
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
 	"go-rest-api/domain"
 	"go-rest-api/dto"
 	"go-rest-api/internal/config"
@@ -15,15 +16,18 @@ import (
 )
 
 type authService struct {
-	cnf            *config.Config
-	userRepository domain.UserRepository
+	cnf                *config.Config
+	userRepository     domain.UserRepository
+	customerRepository domain.CustomerRepository
 }
 
 func NewAuth(cnf *config.Config,
-	userRepository domain.UserRepository) domain.AuthService {
+	userRepository domain.UserRepository,
+	customerRepository domain.CustomerRepository) domain.AuthService {
 	return &authService{
-		cnf:            cnf,
-		userRepository: userRepository,
+		cnf:                cnf,
+		userRepository:     userRepository,
+		customerRepository: customerRepository,
 	}
 }
 
@@ -39,12 +43,25 @@ func (a authService) Register(ctx context.Context, req dto.RegisterReq) (dto.Use
 		return dto.UserData{}, err
 	}
 
+	role := req.Role
+	if role == "" {
+		role = constants.RoleStaff
+	}
+	// The first account on a fresh install is the administrator.
+	hasUsers, err := a.HasUsers(ctx)
+	if err != nil {
+		return dto.UserData{}, err
+	}
+	if !hasUsers {
+		role = constants.RoleAdmin
+	}
+
 	newUser := domain.User{
 		ID:       uuid.New(),
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: string(hashedPassword),
-		Role:     "user",
+		Role:     role,
 	}
 
 	err = a.userRepository.Save(ctx, &newUser)
@@ -61,6 +78,75 @@ func (a authService) Register(ctx context.Context, req dto.RegisterReq) (dto.Use
 	}, nil
 }
 
+// HasUsers reports whether any account exists yet.
+func (a authService) HasUsers(ctx context.Context) (bool, error) {
+	count, err := a.userRepository.Count(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// RegisterPatron creates a patron account for an existing customer. The
+// customer is identified by code, and the one-time claim code staff issued
+// for them proves the claim.
+func (a authService) RegisterPatron(ctx context.Context, req dto.PatronRegisterReq) (dto.UserData, error) {
+	customer, err := a.customerRepository.FindByCode(req.CustomerCode)
+	if err != nil || customer.PatronClaimHash == "" || customer.PatronClaimExpiresAt == nil ||
+		time.Now().After(*customer.PatronClaimExpiresAt) ||
+		subtle.ConstantTimeCompare([]byte(customer.PatronClaimHash), []byte(hashClaimCode(req.ClaimCode))) != 1 {
+		return dto.UserData{}, constants.ErrPatronClaimMismatch
+	}
+
+	existingUser, err := a.userRepository.FindByCustomerID(ctx, customer.ID)
+	if err == nil && existingUser.ID != uuid.Nil {
+		return dto.UserData{}, constants.ErrCustomerAccountExists
+	}
+
+	existingUser, err = a.userRepository.FindByEmail(ctx, req.Email)
+	if err == nil && existingUser.ID != uuid.Nil {
+		return dto.UserData{}, constants.ErrEmailAlreadyExists
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return dto.UserData{}, err
+	}
+
+	newUser := domain.User{
+		ID:         uuid.New(),
+		Name:       customer.Name,
+		Email:      req.Email,
+		Password:   string(hashedPassword),
+		Role:       constants.RolePatron,
+		CustomerID: &customer.ID,
+	}
+
+	err = a.userRepository.Save(ctx, &newUser)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return dto.UserData{}, err
+	}
+
+	// The claim code is single use.
+	customer.PatronClaimHash = ""
+	customer.PatronClaimExpiresAt = nil
+	if err := a.customerRepository.Update(customer); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return dto.UserData{}, err
+	}
+
+	return dto.UserData{
+		Id:         newUser.ID.String(),
+		Name:       newUser.Name,
+		Email:      newUser.Email,
+		Role:       newUser.Role,
+		CustomerID: customer.ID.String(),
+	}, nil
+}
+
 func (a authService) Authenticate(ctx context.Context, req dto.AuthReq) (dto.AuthRes, error) {
 	user, err := a.userRepository.FindByEmail(ctx, req.Email)
 	if err != nil {
@@ -74,14 +160,18 @@ func (a authService) Authenticate(ctx context.Context, req dto.AuthReq) (dto.Aut
 		return dto.AuthRes{}, constants.ErrInvalidCredential
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256,
-		jwt.MapClaims{
-			"id":    user.ID,
-			"name":  user.Name,
-			"email": user.Email,
-			"role":  user.Role,
-			"exp":   time.Now().Add(time.Hour * 24).Unix(),
-		})
+	claims := jwt.MapClaims{
+		"id":    user.ID,
+		"name":  user.Name,
+		"email": user.Email,
+		"role":  user.Role,
+		"exp":   time.Now().Add(time.Hour * 24).Unix(),
+	}
+	if user.CustomerID != nil {
+		claims["customer_id"] = user.CustomerID.String()
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(a.cnf.Secret.Jwt))
 	if err != nil {
 		slog.ErrorContext(ctx, err.Error())
@@ -103,11 +193,13 @@ func (a authService) Validate(ctx context.Context, tokenString string) (dto.User
 	}
 	if token.Valid {
 		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			customerID, _ := claims["customer_id"].(string)
 			return dto.UserData{
-				Id:    claims["id"].(string),
-				Name:  claims["name"].(string),
-				Email: claims["email"].(string),
-				Role:  claims["role"].(string),
+				Id:         claims["id"].(string),
+				Name:       claims["name"].(string),
+				Email:      claims["email"].(string),
+				Role:       claims["role"].(string),
+				CustomerID: customerID,
 			}, nil
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"go-rest-api/domain"
 	"go-rest-api/dto"
 	"go-rest-api/internal/config"
+	"go-rest-api/internal/constants"
 	"log/slog"
 	"math"
 	"time"
@@ -14,31 +15,63 @@ import (
 )
 
 type bookService struct {
-	bookRepo  domain.BookRepository
-	mediaRepo domain.MediaRepository
-	config    *config.Config
+	bookRepo       domain.BookRepository
+	mediaRepo      domain.MediaRepository
+	dependencyRepo domain.DependencyRepository
+	workRepo       domain.WorkRepository
+	seriesRepo     domain.SeriesRepository
+	config         *config.Config
 }
 
-func NewBookService(bookRepo domain.BookRepository, mediaRepo domain.MediaRepository, config *config.Config) domain.BookService {
+func NewBookService(
+	bookRepo domain.BookRepository,
+	mediaRepo domain.MediaRepository,
+	dependencyRepo domain.DependencyRepository,
+	workRepo domain.WorkRepository,
+	seriesRepo domain.SeriesRepository,
+	config *config.Config,
+) domain.BookService {
 	return &bookService{
-		bookRepo:  bookRepo,
-		mediaRepo: mediaRepo,
-		config:    config,
+		bookRepo:       bookRepo,
+		mediaRepo:      mediaRepo,
+		dependencyRepo: dependencyRepo,
+		workRepo:       workRepo,
+		seriesRepo:     seriesRepo,
+		config:         config,
 	}
 }
 
-func (s *bookService) GetBooks(ctx context.Context, page, perPage int, search string, cover_id *uuid.UUID) (*dto.PaginatedResponseData[[]dto.BookResponse], error) {
-	books, total, err := s.bookRepo.FindBooks(ctx, page, perPage, search, cover_id)
+func (s *bookService) GetBooks(ctx context.Context, page, perPage int, filter dto.BookFilter) (*dto.PaginatedResponseData[[]dto.BookResponse], error) {
+	books, total, err := s.bookRepo.FindBooks(ctx, page, perPage, filter)
 	if err != nil {
 		slog.ErrorContext(ctx, err.Error())
 		return nil, err
 	}
 
+	facets, err := s.bookRepo.FindBookFacets(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	markSelectedFacets(facets, filter)
+
 	bookResponses := make([]dto.BookResponse, 0, len(books))
 	for _, book := range books {
 		bookResponses = append(bookResponses, s.toBookResponse(&book))
 	}
 
+	if err := s.attachAvailability(ctx, bookResponses); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if filter.Collapse == constants.BookCollapseWork {
+		if err := s.attachEditionCounts(ctx, bookResponses); err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, err
+		}
+	}
+
 	totalPages := int(math.Ceil(float64(total) / float64(perPage)))
 
 	paginatedResponse := &dto.PaginatedResponseData[[]dto.BookResponse]{
@@ -47,6 +80,7 @@ func (s *bookService) GetBooks(ctx context.Context, page, perPage int, search st
 		PerPage:    perPage,
 		TotalPages: totalPages,
 		TotalItems: total,
+		Facets:     facets,
 	}
 
 	return paginatedResponse, nil
@@ -59,16 +93,49 @@ func (s *bookService) GetBookByID(ctx context.Context, id uuid.UUID) (*dto.BookR
 		return nil, err
 	}
 
-	response := s.toBookResponse(book)
-	return &response, nil
+	responses := []dto.BookResponse{s.toBookResponse(book)}
+	if err := s.attachAvailability(ctx, responses); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if responses[0].Branches, err = s.bookRepo.FindBranchAvailability(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return &responses[0], nil
 }
 
 func (s *bookService) CreateBook(ctx context.Context, req dto.BookCreateRequest) (*dto.BookResponse, error) {
 	book := &domain.Book{
-		Title:       req.Title,
-		Description: req.Description,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		Title:           req.Title,
+		Description:     req.Description,
+		ISBN:            req.ISBN,
+		Author:          req.Author,
+		Publisher:       req.Publisher,
+		PublicationYear: req.PublicationYear,
+		Language:        req.Language,
+		Category:        req.Category,
+		Subjects:        req.Subjects,
+		Edition:         req.Edition,
+		Format:          req.Format,
+		SeriesVolume:    req.SeriesVolume,
+		ItemType:        req.ItemType,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	if book.ItemType == "" {
+		book.ItemType = constants.ItemTypeCirculating
+	}
+
+	if req.MinAge != nil {
+		book.MinAge = *req.MinAge
+	}
+
+	if err := s.linkWorkAndSeries(ctx, book, req.WorkID, req.SeriesID); err != nil {
+		return nil, err
 	}
 
 	if req.CoverID != nil {
@@ -103,6 +170,58 @@ func (s *bookService) UpdateBook(ctx context.Context, id uuid.UUID, req dto.Book
 		book.Description = req.Description
 	}
 
+	if req.ISBN != "" {
+		book.ISBN = req.ISBN
+	}
+
+	if req.Author != "" {
+		book.Author = req.Author
+	}
+
+	if req.Publisher != "" {
+		book.Publisher = req.Publisher
+	}
+
+	if req.PublicationYear != 0 {
+		book.PublicationYear = req.PublicationYear
+	}
+
+	if req.Language != "" {
+		book.Language = req.Language
+	}
+
+	if req.Category != "" {
+		book.Category = req.Category
+	}
+
+	if req.Subjects != "" {
+		book.Subjects = req.Subjects
+	}
+
+	if req.Edition != "" {
+		book.Edition = req.Edition
+	}
+
+	if req.Format != "" {
+		book.Format = req.Format
+	}
+
+	if req.SeriesVolume != nil {
+		book.SeriesVolume = req.SeriesVolume
+	}
+
+	if req.ItemType != "" {
+		book.ItemType = req.ItemType
+	}
+
+	if req.MinAge != nil {
+		book.MinAge = *req.MinAge
+	}
+
+	if err := s.linkWorkAndSeries(ctx, book, req.WorkID, req.SeriesID); err != nil {
+		return nil, err
+	}
+
 	if req.CoverID != nil {
 		media, err := s.mediaRepo.FindByID(*req.CoverID)
 		if err != nil {
@@ -131,6 +250,24 @@ func (s *bookService) DeleteBook(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
+	dependents, err := s.dependencyRepo.FindBookDependents(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	rule := s.config.Delete.Book
+	if err := checkDeleteRule(rule, dependents); err != nil {
+		return err
+	}
+
+	switch rule {
+	case constants.DeleteRuleCascade:
+		return s.dependencyRepo.CascadeDeleteBook(ctx, id)
+	case constants.DeleteRuleArchive:
+		return s.dependencyRepo.ArchiveBook(ctx, id)
+	}
+
 	return s.bookRepo.Delete(ctx, id)
 }
 
@@ -152,13 +289,196 @@ func (s *bookService) DeleteBookCover(ctx context.Context, id uuid.UUID) error {
 	return s.bookRepo.Update(ctx, book)
 }
 
+func (s *bookService) GetTrashedBooks(ctx context.Context) ([]dto.BookResponse, error) {
+	books, err := s.bookRepo.FindDeleted(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	bookResponses := make([]dto.BookResponse, 0, len(books))
+	for _, book := range books {
+		bookResponses = append(bookResponses, s.toBookResponse(&book))
+	}
+
+	return bookResponses, nil
+}
+
+func (s *bookService) RestoreBook(ctx context.Context, id uuid.UUID) (*dto.BookResponse, error) {
+	if _, err := s.bookRepo.FindDeletedByID(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if err := s.dependencyRepo.UnarchiveBook(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.GetBookByID(ctx, id)
+}
+
+// PurgeBook permanently removes a book from the trash once the retention
+// period has passed and nothing references it anymore.
+func (s *bookService) PurgeBook(ctx context.Context, id uuid.UUID) error {
+	book, err := s.bookRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	retention := time.Duration(s.config.Trash.RetentionDays) * 24 * time.Hour
+	if time.Since(book.DeletedAt.Time) < retention {
+		return constants.ErrRetentionNotElapsed
+	}
+
+	dependents, err := s.bookRepo.CountDependents(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if dependents > 0 {
+		return constants.ErrHasDependents
+	}
+
+	return s.bookRepo.Purge(ctx, id)
+}
+
+// linkWorkAndSeries attaches the book to the given work and series. A nil ID
+// leaves the link unchanged and uuid.Nil removes it.
+func (s *bookService) linkWorkAndSeries(ctx context.Context, book *domain.Book, workID, seriesID *uuid.UUID) error {
+	if workID != nil {
+		if *workID == uuid.Nil {
+			book.WorkID = nil
+		} else {
+			if _, err := s.workRepo.FindByID(ctx, *workID); err != nil {
+				slog.ErrorContext(ctx, err.Error())
+				return errors.New("invalid work ID: work not found")
+			}
+			book.WorkID = workID
+		}
+		book.Work = nil
+	}
+
+	if seriesID != nil {
+		if *seriesID == uuid.Nil {
+			book.SeriesID = nil
+			book.SeriesVolume = nil
+		} else {
+			if _, err := s.seriesRepo.FindByID(ctx, *seriesID); err != nil {
+				slog.ErrorContext(ctx, err.Error())
+				return errors.New("invalid series ID: series not found")
+			}
+			book.SeriesID = seriesID
+		}
+		book.Series = nil
+	}
+
+	return nil
+}
+
+// attachEditionCounts tells collapsed results how many editions their work
+// has, so clients can offer to expand it.
+func (s *bookService) attachEditionCounts(ctx context.Context, responses []dto.BookResponse) error {
+	ids := make([]uuid.UUID, 0, len(responses))
+	for _, response := range responses {
+		if response.WorkID != nil {
+			ids = append(ids, *response.WorkID)
+		}
+	}
+
+	counts, err := s.workRepo.CountEditions(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range responses {
+		responses[i].EditionCount = 1
+		if responses[i].WorkID != nil {
+			responses[i].EditionCount = counts[*responses[i].WorkID]
+		}
+	}
+
+	return nil
+}
+
+// attachAvailability fills in the copy counts of every response with one
+// query for the whole page.
+func (s *bookService) attachAvailability(ctx context.Context, responses []dto.BookResponse) error {
+	ids := make([]uuid.UUID, 0, len(responses))
+	for _, response := range responses {
+		ids = append(ids, response.ID)
+	}
+
+	availability, err := s.bookRepo.FindAvailability(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range responses {
+		counts := availability[responses[i].ID]
+		responses[i].Availability = &counts
+	}
+
+	return nil
+}
+
+func markSelectedFacets(facets *dto.BookFacets, filter dto.BookFilter) {
+	if filter.Available != nil {
+		selected := constants.BookFacetValueUnavailable
+		if *filter.Available {
+			selected = constants.BookFacetValueAvailable
+		}
+		markSelected(facets.Availability, selected)
+	}
+
+	markSelected(facets.Category, filter.Category)
+	markSelected(facets.Author, filter.Author)
+	markSelected(facets.Publisher, filter.Publisher)
+	markSelected(facets.Language, filter.Language)
+
+	for i, year := range facets.PublicationYear {
+		facets.PublicationYear[i].Selected = filter.YearFrom == year.From && filter.YearTo == year.To
+	}
+}
+
+func markSelected(counts []dto.FacetCount, value string) {
+	if value == "" {
+		return
+	}
+
+	for i := range counts {
+		counts[i].Selected = counts[i].Value == value
+	}
+}
+
 func (s *bookService) toBookResponse(book *domain.Book) dto.BookResponse {
 	response := dto.BookResponse{
-		ID:          book.ID,
-		Title:       book.Title,
-		Description: book.Description,
-		CreatedAt:   book.CreatedAt,
-		UpdatedAt:   book.UpdatedAt,
+		ID:              book.ID,
+		Title:           book.Title,
+		Description:     book.Description,
+		ISBN:            book.ISBN,
+		Author:          book.Author,
+		Publisher:       book.Publisher,
+		PublicationYear: book.PublicationYear,
+		Language:        book.Language,
+		Category:        book.Category,
+		Subjects:        book.Subjects,
+		WorkID:          book.WorkID,
+		Edition:         book.Edition,
+		Format:          book.Format,
+		SeriesID:        book.SeriesID,
+		SeriesVolume:    book.SeriesVolume,
+		ItemType:        book.ItemType,
+		MinAge:          book.MinAge,
+		RatingAverage:   book.RatingAverage,
+		RatingCount:     book.RatingCount,
+		CreatedAt:       book.CreatedAt,
+		UpdatedAt:       book.UpdatedAt,
+	}
+
+	if book.DeletedAt.Valid {
+		response.DeletedAt = &book.DeletedAt.Time
 	}
 
 	if book.Cover != nil {
@@ -0,0 +1,502 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/repository"
+	"go-rest-api/internal/utils"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var bookCSVHeader = []string{
+	"book_id", "title", "description", "author", "publisher", "publication_year",
+	"language", "category", "cover", "stock_code", "stock_status", "borrowed_at",
+	"isbn", "subjects",
+}
+
+type bookCSVService struct {
+	bookRepo      domain.BookRepository
+	bookstockRepo domain.BookstockRepository
+	mediaRepo     domain.MediaRepository
+}
+
+func NewBookCSVService(bookRepo domain.BookRepository, bookstockRepo domain.BookstockRepository, mediaRepo domain.MediaRepository) domain.BookCSVService {
+	return &bookCSVService{
+		bookRepo:      bookRepo,
+		bookstockRepo: bookstockRepo,
+		mediaRepo:     mediaRepo,
+	}
+}
+
+// ImportBooks reads the CSV one record at a time and writes books in chunks,
+// so memory use does not grow with the size of the file. Adjacent rows with
+// the same title and author describe copies of one book.
+func (s *bookCSVService) ImportBooks(ctx context.Context, r io.Reader, opts dto.BookImportOptions) (*dto.BookImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = constants.BookImportModeAtomic
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("CSV header must contain a title column")
+	}
+
+	imp := &bookImporter{
+		service: s,
+		ctx:     ctx,
+		opts:    opts,
+		columns: columns,
+		report: &dto.BookImportReport{
+			DryRun: opts.DryRun,
+			Mode:   opts.Mode,
+			Errors: []dto.BookImportError{},
+		},
+		invalidRows: make(map[int]bool),
+		seenBooks:   make(map[string]bool),
+		seenCodes:   make(map[string]bool),
+		covers:      make(map[string]*uuid.UUID),
+	}
+
+	if !opts.DryRun && opts.Mode == constants.BookImportModeAtomic {
+		imp.tx = s.bookRepo.(*repository.BookRepositoryImpl).GetDB().WithContext(ctx).Begin()
+		defer func() {
+			if r := recover(); r != nil {
+				imp.tx.Rollback()
+				panic(r)
+			}
+		}()
+	}
+
+	row := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				imp.report.TotalRows++
+				imp.addError(row, "", constants.BookImportErrorInvalid, parseErr.Err.Error())
+				continue
+			}
+			imp.rollback()
+			return nil, err
+		}
+
+		imp.report.TotalRows++
+		if err := imp.processRow(row, record); err != nil {
+			imp.rollback()
+			slog.ErrorContext(ctx, err.Error())
+			return nil, err
+		}
+	}
+
+	if err := imp.flush(); err != nil {
+		imp.rollback()
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if imp.tx != nil {
+		// An atomic import is all or nothing: any rejected row rolls back
+		// every book written so far.
+		if len(imp.report.Errors) > 0 {
+			imp.rollback()
+		} else if err := imp.tx.Commit().Error; err != nil {
+			imp.resetCounts()
+			slog.ErrorContext(ctx, err.Error())
+			return nil, err
+		}
+	}
+
+	imp.report.ValidRows = imp.report.TotalRows - len(imp.invalidRows)
+	return imp.report, nil
+}
+
+func (s *bookCSVService) ExportBooks(ctx context.Context, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(bookCSVHeader); err != nil {
+		return err
+	}
+
+	err := s.bookRepo.FindInBatches(ctx, constants.BookExportBatchSize, func(books []domain.Book) error {
+		for _, book := range books {
+			if len(book.BookStocks) == 0 {
+				if err := writer.Write(bookCSVRecord(&book, nil)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			for _, stock := range book.BookStocks {
+				if err := writer.Write(bookCSVRecord(&book, &stock)); err != nil {
+					return err
+				}
+			}
+		}
+
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func bookCSVRecord(book *domain.Book, stock *domain.BookStock) []string {
+	year := ""
+	if book.PublicationYear > 0 {
+		year = strconv.Itoa(book.PublicationYear)
+	}
+
+	cover := ""
+	if book.Cover != nil {
+		cover = book.Cover.Path
+	}
+
+	record := []string{
+		book.ID.String(), book.Title, book.Description, book.Author, book.Publisher, year,
+		book.Language, book.Category, cover, "", "", "",
+		book.ISBN, book.Subjects,
+	}
+
+	if stock != nil {
+		record[9] = stock.Code
+		record[10] = stock.Status
+		if stock.BorrowedAt != nil {
+			record[11] = stock.BorrowedAt.Format(time.RFC3339)
+		}
+	}
+
+	return record
+}
+
+type importCopy struct {
+	row   int
+	stock domain.BookStock
+}
+
+type importBook struct {
+	key    string
+	row    int
+	rows   []int
+	book   domain.Book
+	copies []importCopy
+}
+
+type bookImporter struct {
+	service *bookCSVService
+	ctx     context.Context
+	opts    dto.BookImportOptions
+	columns map[string]int
+	report  *dto.BookImportReport
+	tx      *gorm.DB
+
+	invalidRows map[int]bool
+	seenBooks   map[string]bool
+	seenCodes   map[string]bool
+	covers      map[string]*uuid.UUID
+
+	chunk   []*importBook
+	current *importBook
+}
+
+func (imp *bookImporter) processRow(row int, record []string) error {
+	field := func(name string) string {
+		i, ok := imp.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	req := dto.BookCreateRequest{
+		Title:       field("title"),
+		Description: field("description"),
+		Author:      field("author"),
+		Publisher:   field("publisher"),
+		Language:    field("language"),
+		Category:    field("category"),
+		ISBN:        field("isbn"),
+		Subjects:    field("subjects"),
+	}
+
+	valid := true
+	if year := field("publication_year"); year != "" {
+		parsed, err := strconv.Atoi(year)
+		if err != nil {
+			imp.addError(row, "publication_year", constants.BookImportErrorInvalid, "publication_year must be a number")
+			valid = false
+		}
+		req.PublicationYear = parsed
+	}
+
+	for name, message := range utils.Validate(req) {
+		imp.addError(row, strings.ToLower(name), constants.BookImportErrorInvalid, message)
+		valid = false
+	}
+
+	status := strings.ToUpper(field("stock_status"))
+	switch status {
+	case "":
+		status = constants.BookStockStatusAvailable
+	case constants.BookStockStatusAvailable, constants.BookStockStatusDamaged, constants.BookStockStatusLost:
+	default:
+		imp.addError(row, "stock_status", constants.BookImportErrorInvalid, "stock_status must be one of AVAILABLE, DAMAGED, LOST")
+		valid = false
+	}
+
+	coverID, err := imp.resolveCover(field("cover"))
+	if err != nil {
+		return err
+	}
+	if field("cover") != "" && coverID == nil {
+		imp.addError(row, "cover", constants.BookImportErrorUnknownCover, fmt.Sprintf("cover %q does not match any media", field("cover")))
+		valid = false
+	}
+
+	code := field("stock_code")
+	if code != "" && imp.seenCodes[code] {
+		imp.addError(row, "stock_code", constants.BookImportErrorDuplicate, fmt.Sprintf("stock code %s appears more than once in the file", code))
+		valid = false
+	}
+
+	if !valid {
+		return nil
+	}
+
+	key := strings.ToLower(req.Title) + "\x00" + strings.ToLower(req.Author)
+	if imp.current == nil || imp.current.key != key {
+		if imp.seenBooks[key] {
+			imp.addError(row, "title", constants.BookImportErrorDuplicate, "book already appeared earlier in the file, rows of the same book must be adjacent")
+			return nil
+		}
+
+		if len(imp.chunk) >= constants.BookImportChunkSize {
+			if err := imp.flush(); err != nil {
+				return err
+			}
+		}
+
+		imp.current = &importBook{
+			key: key,
+			row: row,
+			book: domain.Book{
+				Title:           req.Title,
+				Description:     req.Description,
+				Author:          req.Author,
+				Publisher:       req.Publisher,
+				PublicationYear: req.PublicationYear,
+				Language:        req.Language,
+				Category:        req.Category,
+				ISBN:            req.ISBN,
+				Subjects:        req.Subjects,
+				CoverID:         coverID,
+			},
+		}
+		imp.seenBooks[key] = true
+		imp.chunk = append(imp.chunk, imp.current)
+	}
+
+	imp.current.rows = append(imp.current.rows, row)
+	if code != "" {
+		imp.seenCodes[code] = true
+		imp.current.copies = append(imp.current.copies, importCopy{
+			row:   row,
+			stock: domain.BookStock{Code: code, Status: status},
+		})
+	}
+
+	return nil
+}
+
+// flush checks the pending chunk against the database and writes the books
+// that are not duplicates.
+func (imp *bookImporter) flush() error {
+	chunk := imp.chunk
+	imp.chunk = nil
+	imp.current = nil
+	if len(chunk) == 0 {
+		return nil
+	}
+
+	pairs := make([][]interface{}, 0, len(chunk))
+	codes := make([]string, 0, len(chunk))
+	for _, item := range chunk {
+		pairs = append(pairs, []interface{}{strings.ToLower(item.book.Title), strings.ToLower(item.book.Author)})
+		for _, c := range item.copies {
+			codes = append(codes, c.stock.Code)
+		}
+	}
+
+	existingBooks, err := imp.service.bookRepo.FindByTitleAuthor(imp.ctx, pairs)
+	if err != nil {
+		return err
+	}
+	bookExists := make(map[string]bool, len(existingBooks))
+	for _, book := range existingBooks {
+		bookExists[strings.ToLower(book.Title)+"\x00"+strings.ToLower(book.Author)] = true
+	}
+
+	codeExists := make(map[string]bool)
+	if len(codes) > 0 {
+		existingStocks, err := imp.service.bookstockRepo.FindByCodes(codes)
+		if err != nil {
+			return err
+		}
+		for _, stock := range existingStocks {
+			codeExists[stock.Code] = true
+		}
+	}
+
+	books := make([]*importBook, 0, len(chunk))
+	for _, item := range chunk {
+		if bookExists[item.key] {
+			for _, row := range item.rows {
+				imp.addError(row, "title", constants.BookImportErrorDuplicate, "book with this title and author already exists in the catalog")
+			}
+			continue
+		}
+
+		for _, c := range item.copies {
+			if codeExists[c.stock.Code] {
+				imp.addError(c.row, "stock_code", constants.BookImportErrorDuplicate, fmt.Sprintf("stock code %s already exists", c.stock.Code))
+				continue
+			}
+			item.book.BookStocks = append(item.book.BookStocks, c.stock)
+		}
+		books = append(books, item)
+	}
+
+	if imp.opts.DryRun {
+		imp.count(books)
+		return nil
+	}
+
+	if imp.tx != nil {
+		if len(imp.report.Errors) > 0 {
+			// The atomic transaction will be rolled back, skip the writes.
+			return nil
+		}
+		if err := imp.create(imp.tx, books); err != nil {
+			imp.addError(books[0].row, "", constants.BookImportErrorDatabase, err.Error())
+			return nil
+		}
+		imp.count(books)
+		return nil
+	}
+
+	tx := imp.service.bookRepo.(*repository.BookRepositoryImpl).GetDB().WithContext(imp.ctx).Begin()
+	if err := imp.create(tx, books); err != nil {
+		tx.Rollback()
+		for _, item := range books {
+			imp.addError(item.row, "", constants.BookImportErrorDatabase, err.Error())
+		}
+		return nil
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	imp.count(books)
+
+	return nil
+}
+
+func (imp *bookImporter) create(tx *gorm.DB, books []*importBook) error {
+	now := time.Now()
+	for _, item := range books {
+		item.book.CreatedAt = now
+		item.book.UpdatedAt = now
+		if err := tx.Create(&item.book).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (imp *bookImporter) count(books []*importBook) {
+	for _, item := range books {
+		imp.report.BooksCreated++
+		imp.report.CopiesCreated += len(item.book.BookStocks)
+	}
+}
+
+func (imp *bookImporter) resetCounts() {
+	imp.report.BooksCreated = 0
+	imp.report.CopiesCreated = 0
+}
+
+func (imp *bookImporter) rollback() {
+	if imp.tx != nil {
+		imp.tx.Rollback()
+		imp.resetCounts()
+	}
+}
+
+func (imp *bookImporter) resolveCover(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if id, ok := imp.covers[value]; ok {
+		return id, nil
+	}
+
+	var media *domain.Media
+	var err error
+	if id, parseErr := uuid.Parse(value); parseErr == nil {
+		media, err = imp.service.mediaRepo.FindByID(id)
+	} else {
+		media, err = imp.service.mediaRepo.FindByFileName(value)
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		imp.covers[value] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	imp.covers[value] = &media.ID
+	return &media.ID, nil
+}
+
+func (imp *bookImporter) addError(row int, field, kind, message string) {
+	imp.invalidRows[row] = true
+	imp.report.Errors = append(imp.report.Errors, dto.BookImportError{
+		Row:     row,
+		Field:   field,
+		Kind:    kind,
+		Message: message,
+	})
+}
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"go-rest-api/domain"
 	"go-rest-api/dto"
+	"go-rest-api/internal/config"
 	"go-rest-api/internal/constants"
 	"go-rest-api/internal/repository"
+	"go-rest-api/internal/stockcode"
 	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type bookTransactionService struct {
@@ -18,6 +21,9 @@ type bookTransactionService struct {
 	bookRepo            domain.BookRepository
 	bookstockRepo       domain.BookstockRepository
 	customerRepo        domain.CustomerRepository
+	tierRepo            domain.MembershipTierRepository
+	suspensionRepo      domain.SuspensionRepository
+	config              *config.Config
 }
 
 func NewBookTransactionService(
@@ -25,12 +31,18 @@ func NewBookTransactionService(
 	bookRepo domain.BookRepository,
 	bookstockRepo domain.BookstockRepository,
 	customerRepo domain.CustomerRepository,
+	tierRepo domain.MembershipTierRepository,
+	suspensionRepo domain.SuspensionRepository,
+	config *config.Config,
 ) domain.BookTransactionService {
 	return &bookTransactionService{
 		bookTransactionRepo: bookTransactionRepo,
 		bookRepo:            bookRepo,
 		bookstockRepo:       bookstockRepo,
 		customerRepo:        customerRepo,
+		tierRepo:            tierRepo,
+		suspensionRepo:      suspensionRepo,
+		config:              config,
 	}
 }
 
@@ -55,12 +67,22 @@ func (s *bookTransactionService) CreateBookTransaction(ctx context.Context, req
 		return nil, errors.New("invalid book ID: book not found")
 	}
 
+	if stockcode.Mistyped(s.config.StockCode.Prefix, req.StockCode) {
+		return nil, constants.ErrStockCodeMistyped
+	}
+
 	bookstock, err := s.bookstockRepo.FindByCode(req.StockCode)
 	if err != nil {
 		slog.ErrorContext(ctx, err.Error())
 		return nil, errors.New("invalid stock code: stock not found")
 	}
 
+	// Circulation rules and holds are read from the book, so it must be the
+	// one this copy belongs to.
+	if bookstock.BookID != book.ID {
+		return nil, constants.ErrStockBookMismatch
+	}
+
 	if bookstock.Status != constants.BookStockStatusAvailable {
 		return nil, errors.New("book stock is not available for borrowing")
 	}
@@ -71,12 +93,43 @@ func (s *bookTransactionService) CreateBookTransaction(ctx context.Context, req
 		return nil, errors.New("invalid customer ID: customer not found")
 	}
 
-	dueDate, err := time.Parse("2006-01-02", req.DueDate)
+	if membershipExpired(customer, time.Now()) {
+		return nil, constants.ErrMembershipExpired
+	}
+
+	if err := checkNotSuspended(ctx, s.suspensionRepo, customer.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCirculation(book, bookstock, customer, time.Now()); err != nil {
+		return nil, err
+	}
+
+	tier, err := customerTier(ctx, s.tierRepo, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.bookTransactionRepo.CountActiveByCustomer(ctx, customer.ID)
 	if err != nil {
 		slog.ErrorContext(ctx, err.Error())
-		return nil, errors.New("invalid due date format: use YYYY-MM-DD")
+		return nil, err
+	}
+	if active >= int64(tier.LoanLimit) {
+		return nil, constants.ErrLoanLimitReached
 	}
 
+	dueDate := truncateToDate(time.Now()).AddDate(0, 0, tier.LoanPeriodDays)
+	if req.DueDate != "" {
+		dueDate, err = time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, errors.New("invalid due date format: use YYYY-MM-DD")
+		}
+	}
+
+	regradeCopy(bookstock, req.Condition, req.ConditionNotes)
+
 	now := time.Now()
 	book_transaction := &domain.BookTransaction{
 		ID:         uuid.New(),
@@ -90,6 +143,9 @@ func (s *bookTransactionService) CreateBookTransaction(ctx context.Context, req
 		Status:     constants.BookTransactionStatusBorrowed,
 		BorrowedAt: &now,
 		ReturnAt:   nil,
+
+		CheckoutCondition: bookstock.Condition,
+		CheckoutNotes:     bookstock.ConditionNotes,
 	}
 
 	// Begin transaction
@@ -100,6 +156,11 @@ func (s *bookTransactionService) CreateBookTransaction(ctx context.Context, req
 		}
 	}()
 
+	if err := checkCopyNotHeld(tx, bookstock.Code, req.CustomerID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	if err := tx.Create(book_transaction).Error; err != nil {
 		tx.Rollback()
 		slog.ErrorContext(ctx, err.Error())
@@ -116,6 +177,18 @@ func (s *bookTransactionService) CreateBookTransaction(ctx context.Context, req
 		return nil, err
 	}
 
+	if err := fulfillHolds(tx, book, req.CustomerID, bookstock.Code, now); err != nil {
+		tx.Rollback()
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if err := cancelRequestedTransfers(tx, bookstock.Code, now); err != nil {
+		tx.Rollback()
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		slog.ErrorContext(ctx, err.Error())
@@ -162,7 +235,7 @@ func (s *bookTransactionService) UpdateBookTransaction(ctx context.Context, id u
 	return &response, nil
 }
 
-func (s *bookTransactionService) ReturnBookTransaction(ctx context.Context, req dto.BookTransactionUpdateStatusRequest) (*dto.BookTransactionResponse, error) {
+func (s *bookTransactionService) ReturnBookTransaction(ctx context.Context, req dto.BookTransactionUpdateStatusRequest, userID uuid.UUID) (*dto.BookTransactionResponse, error) {
 	// Find book_transaction
 	book_transaction, err := s.bookTransactionRepo.FindByID(req.ID)
 	if err != nil {
@@ -174,6 +247,10 @@ func (s *bookTransactionService) ReturnBookTransaction(ctx context.Context, req
 		return nil, errors.New("book_transaction is not in borrowed status")
 	}
 
+	if req.Condition != "" && !isBookCondition(req.Condition) {
+		return nil, constants.ErrInvalidCondition
+	}
+
 	// Find bookstock
 	bookstock, err := s.bookstockRepo.FindByCode(book_transaction.StockCode)
 	if err != nil {
@@ -183,6 +260,27 @@ func (s *bookTransactionService) ReturnBookTransaction(ctx context.Context, req
 
 	now := time.Now()
 
+	// Late returns are fined at the daily rate of the customer's tier.
+	var charge *domain.Charge
+	if daysLate := daysOverdue(book_transaction.DueDate, now); daysLate > 0 {
+		tier, err := customerTier(ctx, s.tierRepo, &book_transaction.Customer)
+		if err != nil {
+			return nil, err
+		}
+		if tier.DailyFine > 0 {
+			charge = &domain.Charge{
+				ID:                uuid.New(),
+				BookTransactionID: book_transaction.ID,
+				Kind:              constants.ChargeKindLateFee,
+				DaysLate:          daysLate,
+				DailyLateFee:      tier.DailyFine,
+				Total:             float64(daysLate) * tier.DailyFine,
+				UserID:            userID,
+				CreatedAt:         now,
+			}
+		}
+	}
+
 	// Begin transaction
 	tx := s.bookTransactionRepo.(*repository.BookTransactionRepositoryImpl).GetDB().Begin()
 	defer func() {
@@ -191,8 +289,12 @@ func (s *bookTransactionService) ReturnBookTransaction(ctx context.Context, req
 		}
 	}()
 
+	regradeCopy(bookstock, req.Condition, req.ConditionNotes)
+
 	book_transaction.Status = constants.BookTransactionStatusAvailable
 	book_transaction.ReturnAt = &now
+	book_transaction.ReturnCondition = bookstock.Condition
+	book_transaction.ReturnNotes = bookstock.ConditionNotes
 
 	if err := tx.Save(book_transaction).Error; err != nil {
 		tx.Rollback()
@@ -210,6 +312,20 @@ func (s *bookTransactionService) ReturnBookTransaction(ctx context.Context, req
 		return nil, err
 	}
 
+	if err := trapHold(tx, &book_transaction.Book, bookstock, now); err != nil {
+		tx.Rollback()
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if charge != nil {
+		if err := tx.Omit("BookTransaction", "User").Create(charge).Error; err != nil {
+			tx.Rollback()
+			slog.ErrorContext(ctx, err.Error())
+			return nil, err
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		slog.ErrorContext(ctx, err.Error())
@@ -217,6 +333,127 @@ func (s *bookTransactionService) ReturnBookTransaction(ctx context.Context, req
 	}
 
 	response := s.toBookTransactionResponse(book_transaction)
+	if charge != nil {
+		response.Charges = []dto.ChargeResponse{toChargeResponse(charge)}
+	}
+	return &response, nil
+}
+
+// RenewBookTransaction extends a loan by the loan period of the customer's
+// tier, counted from the due date or from today if the loan is overdue.
+func (s *bookTransactionService) RenewBookTransaction(ctx context.Context, id uuid.UUID) (*dto.BookTransactionResponse, error) {
+	book_transaction, err := s.bookTransactionRepo.FindByID(id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBookTransactionNotFound
+	}
+
+	if book_transaction.Status != constants.BookTransactionStatusBorrowed {
+		return nil, constants.ErrLoanNotActive
+	}
+
+	now := time.Now()
+	if membershipExpired(&book_transaction.Customer, now) {
+		return nil, constants.ErrMembershipExpired
+	}
+
+	if err := checkNotSuspended(ctx, s.suspensionRepo, book_transaction.CustomerID); err != nil {
+		return nil, err
+	}
+
+	tier, err := customerTier(ctx, s.tierRepo, &book_transaction.Customer)
+	if err != nil {
+		return nil, err
+	}
+	if book_transaction.Renewals >= tier.RenewalLimit {
+		return nil, constants.ErrRenewalLimitReached
+	}
+
+	from := truncateToDate(now)
+	if book_transaction.DueDate.After(from) {
+		from = book_transaction.DueDate
+	}
+	dueDate := from.AddDate(0, 0, tier.LoanPeriodDays)
+
+	// The renewal count guards against two renewals racing past the limit.
+	result := s.bookTransactionRepo.(*repository.BookTransactionRepositoryImpl).GetDB().WithContext(ctx).
+		Model(&domain.BookTransaction{}).
+		Where("id = ? AND status = ? AND renewals = ?", id, constants.BookTransactionStatusBorrowed, book_transaction.Renewals).
+		Updates(map[string]interface{}{"due_date": dueDate, "renewals": book_transaction.Renewals + 1})
+	if result.Error != nil {
+		slog.ErrorContext(ctx, result.Error.Error())
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, constants.ErrLoanNotActive
+	}
+
+	book_transaction.DueDate = dueDate
+	book_transaction.Renewals++
+
+	response := s.toBookTransactionResponse(book_transaction)
+	return &response, nil
+}
+
+// DeclareLost closes a loan whose copy will not come back. The copy is
+// marked lost and the customer is charged its replacement cost, or the
+// configured default when the copy has none.
+func (s *bookTransactionService) DeclareLost(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*dto.BookTransactionResponse, error) {
+	book_transaction, err := s.bookTransactionRepo.FindByID(id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBookTransactionNotFound
+	}
+
+	if book_transaction.Status != constants.BookTransactionStatusBorrowed && book_transaction.Status != constants.BookTransactionStatusOverdue {
+		return nil, constants.ErrLoanNotActive
+	}
+
+	bookstock, err := s.bookstockRepo.FindByCode(book_transaction.StockCode)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBookstockNotFound
+	}
+
+	cost := s.config.Charge.DefaultReplacementCost
+	if bookstock.ReplacementCost != nil {
+		cost = *bookstock.ReplacementCost
+	}
+
+	charge := &domain.Charge{
+		ID:                uuid.New(),
+		BookTransactionID: book_transaction.ID,
+		Kind:              constants.ChargeKindLostItem,
+		Total:             cost,
+		UserID:            userID,
+		CreatedAt:         time.Now(),
+	}
+
+	err = s.bookTransactionRepo.(*repository.BookTransactionRepositoryImpl).GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.BookTransaction{}).Where("id = ?", book_transaction.ID).
+			Update("status", constants.BookTransactionStatusLost).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&domain.BookStock{}).Where("code = ?", bookstock.Code).
+			Updates(map[string]interface{}{"status": constants.BookStockStatusLost, "borrowed_id": nil, "borrowed_at": nil}).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("BookTransaction", "User").Create(charge).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	book_transaction.Status = constants.BookTransactionStatusLost
+	book_transaction.BookStock.Status = constants.BookStockStatusLost
+	book_transaction.BookStock.BorrowedID = nil
+	book_transaction.BookStock.BorrowedAt = nil
+
+	response := s.toBookTransactionResponse(book_transaction)
+	response.Charges = []dto.ChargeResponse{toChargeResponse(charge)}
 	return &response, nil
 }
 
@@ -245,6 +482,12 @@ func (s *bookTransactionService) toBookTransactionResponse(book_transaction *dom
 		Status:     book_transaction.Status,
 		BorrowedAt: book_transaction.BorrowedAt,
 		ReturnAt:   book_transaction.ReturnAt,
+		Renewals:   book_transaction.Renewals,
+
+		CheckoutCondition: book_transaction.CheckoutCondition,
+		CheckoutNotes:     book_transaction.CheckoutNotes,
+		ReturnCondition:   book_transaction.ReturnCondition,
+		ReturnNotes:       book_transaction.ReturnNotes,
 	}
 
 	bookResponse := &dto.BookResponse{
@@ -269,6 +512,7 @@ func (s *bookTransactionService) toBookTransactionResponse(book_transaction *dom
 		Code:       book_transaction.BookStock.Code,
 		BookID:     book_transaction.BookStock.BookID,
 		Status:     book_transaction.BookStock.Status,
+		Condition:  book_transaction.BookStock.Condition,
 		BorrowedID: book_transaction.BookStock.BorrowedID,
 		BorrowedAt: book_transaction.BookStock.BorrowedAt,
 	}
@@ -282,3 +526,88 @@ func (s *bookTransactionService) toBookTransactionResponse(book_transaction *dom
 
 	return response
 }
+
+// regradeCopy applies the condition given at the desk, keeping the copy's
+// current grade and notes for whatever was left empty.
+func regradeCopy(bookstock *domain.BookStock, condition, notes string) {
+	if condition != "" {
+		bookstock.Condition = condition
+	}
+	if notes != "" {
+		bookstock.ConditionNotes = notes
+	}
+}
+
+// daysOverdue counts the whole days from the due date to now.
+func daysOverdue(dueDate, now time.Time) int {
+	due := truncateToDate(dueDate)
+	today := truncateToDate(now)
+	if !today.After(due) {
+		return 0
+	}
+	return int(today.Sub(due).Hours() / 24)
+}
+
+func toChargeResponse(charge *domain.Charge) dto.ChargeResponse {
+	return dto.ChargeResponse{
+		ID:                charge.ID,
+		BookTransactionID: charge.BookTransactionID,
+		Kind:              charge.Kind,
+		DaysLate:          charge.DaysLate,
+		DailyLateFee:      charge.DailyLateFee,
+		Total:             charge.Total,
+		UserID:            charge.UserID,
+		PaidAt:            charge.PaidAt,
+		CreatedAt:         charge.CreatedAt,
+	}
+}
+
+// effectiveItemType is the copy's own item type, falling back to its book's.
+func effectiveItemType(book *domain.Book, bookstock *domain.BookStock) string {
+	if bookstock.ItemType != "" {
+		return bookstock.ItemType
+	}
+	if book != nil && book.ItemType != "" {
+		return book.ItemType
+	}
+	return constants.ItemTypeCirculating
+}
+
+// checkCirculation refuses loans of reference and in-library copies, and of
+// restricted copies to customers younger than the item's minimum age.
+func (s *bookTransactionService) checkCirculation(book *domain.Book, bookstock *domain.BookStock, customer *domain.Customer, now time.Time) error {
+	switch effectiveItemType(book, bookstock) {
+	case constants.ItemTypeReference:
+		return constants.ErrItemReferenceOnly
+	case constants.ItemTypeInLibrary:
+		return constants.ErrItemInLibraryOnly
+	case constants.ItemTypeRestricted:
+		minAge := book.MinAge
+		if minAge == 0 {
+			minAge = s.config.Circulation.RestrictedMinAge
+		}
+		if customer.BirthDate == nil {
+			return constants.ErrCustomerBirthDateNeeded
+		}
+		if ageOn(*customer.BirthDate, now) < minAge {
+			return constants.ErrCustomerTooYoung
+		}
+	}
+	return nil
+}
+
+func ageOn(birthDate, now time.Time) int {
+	age := now.Year() - birthDate.Year()
+	if now.Month() < birthDate.Month() || (now.Month() == birthDate.Month() && now.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+func isBookCondition(condition string) bool {
+	switch condition {
+	case constants.ConditionNew, constants.ConditionGood, constants.ConditionWorn, constants.ConditionPoor:
+		return true
+	}
+	return false
+}
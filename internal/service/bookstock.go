@@ -5,26 +5,50 @@ import (
 	"errors"
 	"go-rest-api/domain"
 	"go-rest-api/dto"
+	"go-rest-api/internal/config"
 	"go-rest-api/internal/constants"
+	"go-rest-api/internal/repository"
+	"go-rest-api/internal/stockcode"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type bookstockService struct {
-	bookstockRepo domain.BookstockRepository
-	bookRepo      domain.BookRepository
+	bookstockRepo  domain.BookstockRepository
+	bookRepo       domain.BookRepository
+	dependencyRepo domain.DependencyRepository
+	branchRepo     domain.BranchRepository
+	codes          *stockCodeGenerator
+	config         *config.Config
 }
 
-func NewBookstockService(bookstockRepo domain.BookstockRepository, bookRepo domain.BookRepository) domain.BookstockService {
+func NewBookstockService(
+	bookstockRepo domain.BookstockRepository,
+	bookRepo domain.BookRepository,
+	dependencyRepo domain.DependencyRepository,
+	branchRepo domain.BranchRepository,
+	stockCodeRepo domain.StockCodeRepository,
+	config *config.Config,
+) domain.BookstockService {
 	return &bookstockService{
-		bookstockRepo: bookstockRepo,
-		bookRepo:      bookRepo,
+		bookstockRepo:  bookstockRepo,
+		bookRepo:       bookRepo,
+		dependencyRepo: dependencyRepo,
+		branchRepo:     branchRepo,
+		codes: &stockCodeGenerator{
+			stockCodeRepo: stockCodeRepo,
+			bookstockRepo: bookstockRepo,
+			config:        config,
+		},
+		config: config,
 	}
 }
 
-func (s *bookstockService) GetAllBookstocks() ([]dto.BookstockResponse, error) {
-	bookstocks, err := s.bookstockRepo.FindAll()
+func (s *bookstockService) GetAllBookstocks(filter dto.BookstockFilter) ([]dto.BookstockResponse, error) {
+	bookstocks, err := s.bookstockRepo.FindAll(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -61,8 +85,8 @@ func (s *bookstockService) GetBookstocksByBookID(bookID uuid.UUID) ([]dto.Bookst
 	return bookstockResponses, nil
 }
 
-func (s *bookstockService) GetAvailableBookstocksByBookID(bookID uuid.UUID) ([]dto.BookstockResponse, error) {
-	bookstocks, err := s.bookstockRepo.FindAvailableByBookID(bookID)
+func (s *bookstockService) GetAvailableBookstocksByBookID(bookID uuid.UUID, filter dto.BookstockFilter) ([]dto.BookstockResponse, error) {
+	bookstocks, err := s.bookstockRepo.FindAvailableByBookID(bookID, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -81,16 +105,44 @@ func (s *bookstockService) CreateBookstock(req dto.BookstockCreateRequest) (*dto
 		return nil, errors.New("invalid book ID: book not found")
 	}
 
-	existingBookstock, err := s.bookstockRepo.FindByCode(req.Code)
-	if err == nil && existingBookstock != nil {
-		return nil, errors.New("bookstock with this code already exists")
+	branchID, branch, err := resolveLocation(context.Background(), s.branchRepo, req.HomeBranchID, req.HomeShelfID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Code == "" {
+		codes, err := s.codes.Generate(context.Background(), branchCode(branch), 1)
+		if err != nil {
+			return nil, err
+		}
+		req.Code = codes[0]
+	} else {
+		existingBookstock, err := s.bookstockRepo.FindByCode(req.Code)
+		if err == nil && existingBookstock != nil {
+			return nil, errors.New("bookstock with this code already exists")
+		}
 	}
 
 	bookstock := &domain.BookStock{
-		Code:   req.Code,
-		BookID: req.BookID,
-		Book:   *book,
-		Status: constants.BookStockStatusAvailable, // Default status
+		Code:            req.Code,
+		BookID:          req.BookID,
+		Book:            *book,
+		Status:          constants.BookStockStatusAvailable, // Default status
+		Condition:       req.Condition,
+		ConditionNotes:  req.ConditionNotes,
+		ItemType:        req.ItemType,
+		HomeBranchID:    branchID,
+		HomeShelfID:     req.HomeShelfID,
+		CurrentBranchID: branchID,
+		CurrentShelfID:  req.HomeShelfID,
+		UnitPrice:       req.UnitPrice,
+		ReplacementCost: req.ReplacementCost,
+	}
+	if bookstock.Condition == "" {
+		bookstock.Condition = constants.ConditionNew
+	}
+	if bookstock.ReplacementCost == nil {
+		bookstock.ReplacementCost = req.UnitPrice
 	}
 
 	if err := s.bookstockRepo.Create(bookstock); err != nil {
@@ -101,17 +153,40 @@ func (s *bookstockService) CreateBookstock(req dto.BookstockCreateRequest) (*dto
 	return &response, nil
 }
 
-func (s *bookstockService) UpdateBookstock(code string, req dto.BookstockUpdateRequest) (*dto.BookstockResponse, error) {
+// UpdateBookstock sets a copy's status by hand. The change is logged like a
+// bulk change, and a hold waiting on the copy is requeued when it leaves the
+// shelf.
+func (s *bookstockService) UpdateBookstock(ctx context.Context, code string, changedBy uuid.UUID, req dto.BookstockUpdateRequest) (*dto.BookstockResponse, error) {
 	bookstock, err := s.bookstockRepo.FindByCode(code)
 	if err != nil {
 		return nil, errors.New("bookstock not found")
 	}
 
+	if bookstock.Status == constants.BookStockStatusInTransit {
+		return nil, constants.ErrBookstockInTransit
+	}
+	if bookstock.Status == constants.BookStockStatusWithdrawn {
+		return nil, constants.ErrBookstockWithdrawn
+	}
+
+	now := time.Now()
+	reason := req.Reason
+	if reason == "" {
+		reason = "Status changed by hand"
+	}
+	log := domain.BookStockStatusLog{
+		ID:         uuid.New(),
+		StockCode:  bookstock.Code,
+		FromStatus: bookstock.Status,
+		ToStatus:   req.Status,
+		Reason:     reason,
+		ChangedBy:  &changedBy,
+		CreatedAt:  now,
+	}
 	bookstock.Status = req.Status
 
 	if req.Status == constants.BookStockStatusBorrowed && bookstock.BorrowedID == nil {
 		dummyBorrowID := uuid.New()
-		now := time.Now()
 		bookstock.BorrowedID = &dummyBorrowID
 		bookstock.BorrowedAt = &now
 	} else if req.Status != constants.BookStockStatusBorrowed {
@@ -119,7 +194,99 @@ func (s *bookstockService) UpdateBookstock(code string, req dto.BookstockUpdateR
 		bookstock.BorrowedAt = nil
 	}
 
+	err = s.bookstockRepo.(*repository.BookstockRepositoryImpl).GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Book", "HomeBranch", "HomeShelf", "CurrentBranch", "CurrentShelf").Save(bookstock).Error; err != nil {
+			return err
+		}
+		if log.FromStatus == log.ToStatus {
+			return nil
+		}
+		if err := tx.Create(&log).Error; err != nil {
+			return err
+		}
+		return requeueHoldOnChange(now)(tx, log)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := s.toBookstockResponse(bookstock)
+	return &response, nil
+}
+
+func (s *bookstockService) UpdateLocation(ctx context.Context, code string, req dto.BookstockLocationRequest) (*dto.BookstockResponse, error) {
+	bookstock, err := s.bookstockRepo.FindByCode(code)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBookstockNotFound
+	}
+
+	homeBranchID, _, err := resolveLocation(ctx, s.branchRepo, req.HomeBranchID, req.HomeShelfID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentBranchID, currentShelfID := homeBranchID, req.HomeShelfID
+	if req.CurrentBranchID != nil || req.CurrentShelfID != nil {
+		if currentBranchID, _, err = resolveLocation(ctx, s.branchRepo, req.CurrentBranchID, req.CurrentShelfID); err != nil {
+			return nil, err
+		}
+		currentShelfID = req.CurrentShelfID
+	}
+
+	bookstock.HomeBranchID = homeBranchID
+	bookstock.HomeShelfID = req.HomeShelfID
+	bookstock.CurrentBranchID = currentBranchID
+	bookstock.CurrentShelfID = currentShelfID
+
+	if err := s.bookstockRepo.Update(bookstock); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	// Reload so the response carries the new branch and shelf names.
+	if bookstock, err = s.bookstockRepo.FindByCode(code); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := s.toBookstockResponse(bookstock)
+	return &response, nil
+}
+
+func (s *bookstockService) UpdateCondition(ctx context.Context, code string, req dto.BookstockConditionRequest) (*dto.BookstockResponse, error) {
+	bookstock, err := s.bookstockRepo.FindByCode(code)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBookstockNotFound
+	}
+
+	bookstock.Condition = req.Condition
+	bookstock.ConditionNotes = req.Notes
+
 	if err := s.bookstockRepo.Update(bookstock); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := s.toBookstockResponse(bookstock)
+	return &response, nil
+}
+
+// UpdateItemType overrides the book's item type for one copy; an empty type
+// clears the override.
+func (s *bookstockService) UpdateItemType(ctx context.Context, code string, req dto.BookstockItemTypeRequest) (*dto.BookstockResponse, error) {
+	bookstock, err := s.bookstockRepo.FindByCode(code)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBookstockNotFound
+	}
+
+	bookstock.ItemType = req.ItemType
+
+	if err := s.bookstockRepo.Update(bookstock); err != nil {
+		slog.ErrorContext(ctx, err.Error())
 		return nil, err
 	}
 
@@ -133,16 +300,44 @@ func (s *bookstockService) DeleteBookstock(code string) error {
 		return errors.New("bookstock not found")
 	}
 
+	dependents, err := s.dependencyRepo.FindBookstockDependents(context.Background(), code)
+	if err != nil {
+		return err
+	}
+
+	rule := s.config.Delete.Bookstock
+	if err := checkDeleteRule(rule, dependents); err != nil {
+		return err
+	}
+
+	switch rule {
+	case constants.DeleteRuleCascade:
+		return s.dependencyRepo.CascadeDeleteBookstock(context.Background(), code)
+	case constants.DeleteRuleArchive:
+		return s.dependencyRepo.ArchiveBookstock(context.Background(), code)
+	}
+
 	return s.bookstockRepo.Delete(code)
 }
 
 func (s *bookstockService) toBookstockResponse(bookstock *domain.BookStock) dto.BookstockResponse {
 	response := dto.BookstockResponse{
-		Code:       bookstock.Code,
-		BookID:     bookstock.BookID,
-		Status:     bookstock.Status,
-		BorrowedID: bookstock.BorrowedID,
-		BorrowedAt: bookstock.BorrowedAt,
+		Code:           bookstock.Code,
+		BookID:         bookstock.BookID,
+		Status:         bookstock.Status,
+		Condition:      bookstock.Condition,
+		ConditionNotes: bookstock.ConditionNotes,
+		ItemType:       bookstock.ItemType,
+		BorrowedID:     bookstock.BorrowedID,
+		BorrowedAt:     bookstock.BorrowedAt,
+		Home:           toCopyLocation(bookstock.HomeBranchID, bookstock.HomeBranch, bookstock.HomeShelfID, bookstock.HomeShelf),
+		Current:        toCopyLocation(bookstock.CurrentBranchID, bookstock.CurrentBranch, bookstock.CurrentShelfID, bookstock.CurrentShelf),
+
+		UnitPrice:           bookstock.UnitPrice,
+		ReplacementCost:     bookstock.ReplacementCost,
+		PurchaseOrderLineID: bookstock.PurchaseOrderLineID,
+		WithdrawnAt:         bookstock.WithdrawnAt,
+		WithdrawnReason:     bookstock.WithdrawnReason,
 	}
 
 	if bookstock.Book.ID != uuid.Nil {
@@ -167,3 +362,174 @@ func (s *bookstockService) toBookstockResponse(bookstock *domain.BookStock) dto.
 
 	return response
 }
+
+func (s *bookstockService) BulkCreateBookstocks(ctx context.Context, req dto.BookstockBulkCreateRequest) (*dto.BookstockBulkReport, error) {
+	if _, err := s.bookRepo.FindByID(ctx, req.BookID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, errors.New("invalid book ID: book not found")
+	}
+
+	branchID, branch, err := resolveLocation(ctx, s.branchRepo, req.HomeBranchID, req.HomeShelfID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.codes.Generate(ctx, branchCode(branch), req.Quantity)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	bookstocks := make([]domain.BookStock, 0, len(codes))
+	report := &dto.BookstockBulkReport{Total: len(codes), Items: make([]dto.BookstockBulkItem, 0, len(codes))}
+	for _, code := range codes {
+		bookstocks = append(bookstocks, domain.BookStock{
+			Code:            code,
+			BookID:          req.BookID,
+			Status:          constants.BookStockStatusAvailable,
+			Condition:       constants.ConditionNew,
+			ItemType:        req.ItemType,
+			HomeBranchID:    branchID,
+			HomeShelfID:     req.HomeShelfID,
+			CurrentBranchID: branchID,
+			CurrentShelfID:  req.HomeShelfID,
+		})
+		report.Items = append(report.Items, dto.BookstockBulkItem{Code: code, Result: constants.BulkItemCreated})
+	}
+
+	if err := s.bookstockRepo.CreateMany(ctx, bookstocks); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	report.Applied = true
+	report.Succeeded = len(codes)
+	return report, nil
+}
+
+// BulkUpdateStatus checks every code first and only writes when all of them
+// can be changed, so a shelf is never left half updated. A copy whose status
+// changes between the check and the write fails the whole batch.
+func (s *bookstockService) BulkUpdateStatus(ctx context.Context, changedBy uuid.UUID, req dto.BookstockBulkStatusRequest) (*dto.BookstockBulkReport, error) {
+	existing, err := s.bookstockRepo.FindByCodes(req.Codes)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	byCode := make(map[string]domain.BookStock, len(existing))
+	for _, stock := range existing {
+		byCode[stock.Code] = stock
+	}
+
+	now := time.Now()
+	report := &dto.BookstockBulkReport{Total: len(req.Codes), Items: make([]dto.BookstockBulkItem, 0, len(req.Codes))}
+	var logs []domain.BookStockStatusLog
+	seen := make(map[string]bool, len(req.Codes))
+
+	for _, code := range req.Codes {
+		item := dto.BookstockBulkItem{Code: code}
+		stock, ok := byCode[code]
+
+		switch {
+		case seen[code]:
+			item.Result, item.Error = constants.BulkItemFailed, "duplicate code in request"
+		case stockcode.Mistyped(s.config.StockCode.Prefix, code):
+			item.Result, item.Error = constants.BulkItemFailed, constants.ErrStockCodeMistyped.Error()
+		case !ok:
+			item.Result, item.Error = constants.BulkItemFailed, constants.ErrBookstockNotFound.Error()
+		case stock.Status == constants.BookStockStatusBorrowed:
+			item.Result, item.Error = constants.BulkItemFailed, constants.ErrBookstockOnLoan.Error()
+		case stock.Status == constants.BookStockStatusInTransit:
+			item.Result, item.Error = constants.BulkItemFailed, constants.ErrBookstockInTransit.Error()
+		case stock.Status == constants.BookStockStatusWithdrawn:
+			item.Result, item.Error = constants.BulkItemFailed, constants.ErrBookstockWithdrawn.Error()
+		case stock.Status == req.Status:
+			item.Result = constants.BulkItemUnchanged
+		default:
+			item.Result = constants.BulkItemUpdated
+			logs = append(logs, domain.BookStockStatusLog{
+				ID:         uuid.New(),
+				StockCode:  code,
+				FromStatus: stock.Status,
+				ToStatus:   req.Status,
+				Reason:     req.Reason,
+				ChangedBy:  &changedBy,
+				CreatedAt:  now,
+			})
+		}
+		seen[code] = true
+
+		if item.Result == constants.BulkItemFailed {
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	if report.Failed > 0 {
+		return report, constants.ErrBulkRejected
+	}
+
+	if err := s.bookstockRepo.ChangeStatuses(ctx, logs, requeueHoldOnChange(now)); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	report.Applied = true
+	return report, nil
+}
+
+func (s *bookstockService) GetStatusHistory(ctx context.Context, code string) ([]dto.BookstockStatusLogResponse, error) {
+	if _, err := s.bookstockRepo.FindByCode(code); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBookstockNotFound
+	}
+
+	logs, err := s.bookstockRepo.FindStatusLogs(ctx, code)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.BookstockStatusLogResponse, 0, len(logs))
+	for _, log := range logs {
+		responses = append(responses, dto.BookstockStatusLogResponse{
+			ID:         log.ID,
+			StockCode:  log.StockCode,
+			FromStatus: log.FromStatus,
+			ToStatus:   log.ToStatus,
+			Reason:     log.Reason,
+			ChangedBy:  log.ChangedBy,
+			CreatedAt:  log.CreatedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+// branchCode returns the stock code segment of branch, or "" for the
+// configured default.
+func branchCode(branch *domain.Branch) string {
+	if branch == nil {
+		return ""
+	}
+	return branch.Code
+}
+
+func toCopyLocation(branchID *uuid.UUID, branch *domain.Branch, shelfID *uuid.UUID, shelf *domain.ShelfLocation) *dto.CopyLocation {
+	if branchID == nil && shelfID == nil {
+		return nil
+	}
+
+	location := &dto.CopyLocation{BranchID: branchID, ShelfID: shelfID}
+	if branch != nil {
+		location.BranchCode = branch.Code
+		location.BranchName = branch.Name
+	}
+	if shelf != nil {
+		location.ShelfCode = shelf.Code
+		location.ShelfName = shelf.Name
+	}
+	return location
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type branchService struct {
+	branchRepo domain.BranchRepository
+}
+
+func NewBranchService(branchRepo domain.BranchRepository) domain.BranchService {
+	return &branchService{
+		branchRepo: branchRepo,
+	}
+}
+
+func (s *branchService) GetBranches(ctx context.Context) ([]dto.BranchResponse, error) {
+	branches, err := s.branchRepo.FindAll(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.BranchResponse, 0, len(branches))
+	for _, branch := range branches {
+		responses = append(responses, toBranchResponse(&branch))
+	}
+
+	return responses, nil
+}
+
+func (s *branchService) GetBranchByID(ctx context.Context, id uuid.UUID) (*dto.BranchResponse, error) {
+	branch, err := s.findBranch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toBranchResponse(branch)
+	return &response, nil
+}
+
+func (s *branchService) CreateBranch(ctx context.Context, req dto.BranchRequest) (*dto.BranchResponse, error) {
+	if _, err := s.branchRepo.FindByCode(ctx, req.Code); err == nil {
+		return nil, constants.ErrBranchExists
+	}
+
+	branch := &domain.Branch{
+		ID:      uuid.New(),
+		Code:    req.Code,
+		Name:    req.Name,
+		Address: req.Address,
+	}
+
+	if err := s.branchRepo.Create(ctx, branch); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toBranchResponse(branch)
+	return &response, nil
+}
+
+// UpdateBranch renames a branch. Changing the code only affects codes
+// generated afterwards; existing stock codes are kept.
+func (s *branchService) UpdateBranch(ctx context.Context, id uuid.UUID, req dto.BranchRequest) (*dto.BranchResponse, error) {
+	branch, err := s.findBranch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing, err := s.branchRepo.FindByCode(ctx, req.Code); err == nil && existing.ID != id {
+		return nil, constants.ErrBranchExists
+	}
+
+	branch.Code = req.Code
+	branch.Name = req.Name
+	branch.Address = req.Address
+
+	if err := s.branchRepo.Update(ctx, branch); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toBranchResponse(branch)
+	return &response, nil
+}
+
+func (s *branchService) DeleteBranch(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.findBranch(ctx, id); err != nil {
+		return err
+	}
+
+	copies, err := s.branchRepo.CountBranchCopies(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if copies > 0 {
+		return constants.ErrBranchInUse
+	}
+
+	if err := s.branchRepo.Delete(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *branchService) GetShelves(ctx context.Context, branchID uuid.UUID) ([]dto.ShelfResponse, error) {
+	if _, err := s.findBranch(ctx, branchID); err != nil {
+		return nil, err
+	}
+
+	shelves, err := s.branchRepo.FindShelves(ctx, branchID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.ShelfResponse, 0, len(shelves))
+	for _, shelf := range shelves {
+		responses = append(responses, toShelfResponse(&shelf))
+	}
+
+	return responses, nil
+}
+
+func (s *branchService) CreateShelf(ctx context.Context, branchID uuid.UUID, req dto.ShelfRequest) (*dto.ShelfResponse, error) {
+	if _, err := s.findBranch(ctx, branchID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkShelfCode(ctx, branchID, uuid.Nil, req.Code); err != nil {
+		return nil, err
+	}
+
+	shelf := &domain.ShelfLocation{
+		ID:          uuid.New(),
+		BranchID:    branchID,
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := s.branchRepo.CreateShelf(ctx, shelf); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toShelfResponse(shelf)
+	return &response, nil
+}
+
+func (s *branchService) UpdateShelf(ctx context.Context, id uuid.UUID, req dto.ShelfRequest) (*dto.ShelfResponse, error) {
+	shelf, err := s.findShelf(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkShelfCode(ctx, shelf.BranchID, id, req.Code); err != nil {
+		return nil, err
+	}
+
+	shelf.Code = req.Code
+	shelf.Name = req.Name
+	shelf.Description = req.Description
+
+	if err := s.branchRepo.UpdateShelf(ctx, shelf); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toShelfResponse(shelf)
+	return &response, nil
+}
+
+func (s *branchService) DeleteShelf(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.findShelf(ctx, id); err != nil {
+		return err
+	}
+
+	copies, err := s.branchRepo.CountShelfCopies(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if copies > 0 {
+		return constants.ErrShelfInUse
+	}
+
+	if err := s.branchRepo.DeleteShelf(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *branchService) findBranch(ctx context.Context, id uuid.UUID) (*domain.Branch, error) {
+	branch, err := s.branchRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrBranchNotFound
+		}
+		return nil, err
+	}
+	return branch, nil
+}
+
+func (s *branchService) findShelf(ctx context.Context, id uuid.UUID) (*domain.ShelfLocation, error) {
+	shelf, err := s.branchRepo.FindShelfByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrShelfNotFound
+		}
+		return nil, err
+	}
+	return shelf, nil
+}
+
+// checkShelfCode makes sure no other shelf of the branch uses code.
+func (s *branchService) checkShelfCode(ctx context.Context, branchID, shelfID uuid.UUID, code string) error {
+	shelves, err := s.branchRepo.FindShelves(ctx, branchID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+	for _, shelf := range shelves {
+		if shelf.Code == code && shelf.ID != shelfID {
+			return constants.ErrShelfExists
+		}
+	}
+	return nil
+}
+
+// resolveLocation checks that the branch exists and that the shelf, when
+// given, belongs to it. A shelf without a branch takes the shelf's branch.
+func resolveLocation(ctx context.Context, branchRepo domain.BranchRepository, branchID, shelfID *uuid.UUID) (*uuid.UUID, *domain.Branch, error) {
+	if shelfID != nil {
+		shelf, err := branchRepo.FindShelfByID(ctx, *shelfID)
+		if err != nil {
+			return nil, nil, constants.ErrShelfNotFound
+		}
+		if branchID != nil && *branchID != shelf.BranchID {
+			return nil, nil, constants.ErrShelfNotInBranch
+		}
+		branchID = &shelf.BranchID
+	}
+	if branchID == nil {
+		return nil, nil, nil
+	}
+
+	branch, err := branchRepo.FindByID(ctx, *branchID)
+	if err != nil {
+		return nil, nil, constants.ErrBranchNotFound
+	}
+	return &branch.ID, branch, nil
+}
+
+func toBranchResponse(branch *domain.Branch) dto.BranchResponse {
+	response := dto.BranchResponse{
+		ID:        branch.ID,
+		Code:      branch.Code,
+		Name:      branch.Name,
+		Address:   branch.Address,
+		CreatedAt: branch.CreatedAt,
+		UpdatedAt: branch.UpdatedAt,
+	}
+
+	for _, shelf := range branch.Shelves {
+		response.Shelves = append(response.Shelves, toShelfResponse(&shelf))
+	}
+
+	return response
+}
+
+func toShelfResponse(shelf *domain.ShelfLocation) dto.ShelfResponse {
+	return dto.ShelfResponse{
+		ID:          shelf.ID,
+		BranchID:    shelf.BranchID,
+		Code:        shelf.Code,
+		Name:        shelf.Name,
+		Description: shelf.Description,
+		CreatedAt:   shelf.CreatedAt,
+		UpdatedAt:   shelf.UpdatedAt,
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type chargeService struct {
+	chargeRepo          domain.ChargeRepository
+	bookTransactionRepo domain.BookTransactionRepository
+}
+
+func NewChargeService(chargeRepo domain.ChargeRepository, bookTransactionRepo domain.BookTransactionRepository) domain.ChargeService {
+	return &chargeService{chargeRepo: chargeRepo, bookTransactionRepo: bookTransactionRepo}
+}
+
+func (s *chargeService) GetAllCharges() ([]dto.ChargeResponse, error) {
+	charges, err := s.chargeRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	return toChargeResponses(charges), nil
+}
+
+func (s *chargeService) GetChargeByID(id uuid.UUID) (*dto.ChargeResponse, error) {
+	charge, err := s.findCharge(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toChargeResponse(charge)
+	return &response, nil
+}
+
+func (s *chargeService) GetChargesByBookTransactionID(bookTransactionID uuid.UUID) ([]dto.ChargeResponse, error) {
+	charges, err := s.chargeRepo.FindByBookTransactionID(bookTransactionID)
+	if err != nil {
+		return nil, err
+	}
+	return toChargeResponses(charges), nil
+}
+
+// CreateCharge records a manual late fee against a loan.
+func (s *chargeService) CreateCharge(req dto.ChargeCreateRequest) (*dto.ChargeResponse, error) {
+	if _, err := s.bookTransactionRepo.FindByID(req.BookTransactionID); err != nil {
+		return nil, constants.ErrBookTransactionNotFound
+	}
+
+	charge := &domain.Charge{
+		ID:                uuid.New(),
+		BookTransactionID: req.BookTransactionID,
+		Kind:              constants.ChargeKindLateFee,
+		DaysLate:          req.DaysLate,
+		DailyLateFee:      req.DailyLateFee,
+		Total:             float64(req.DaysLate) * req.DailyLateFee,
+		UserID:            req.UserID,
+		CreatedAt:         time.Now(),
+	}
+	if err := s.chargeRepo.Create(charge); err != nil {
+		return nil, err
+	}
+
+	response := toChargeResponse(charge)
+	return &response, nil
+}
+
+func (s *chargeService) UpdateCharge(id uuid.UUID, req dto.ChargeUpdateRequest) (*dto.ChargeResponse, error) {
+	charge, err := s.findCharge(id)
+	if err != nil {
+		return nil, err
+	}
+	if charge.PaidAt != nil {
+		return nil, constants.ErrChargePaid
+	}
+
+	charge.DaysLate = req.DaysLate
+	charge.DailyLateFee = req.DailyLateFee
+	charge.Total = float64(req.DaysLate) * req.DailyLateFee
+	if err := s.chargeRepo.Update(charge); err != nil {
+		return nil, err
+	}
+
+	response := toChargeResponse(charge)
+	return &response, nil
+}
+
+// PayCharge settles a charge so it no longer counts as outstanding.
+func (s *chargeService) PayCharge(id uuid.UUID) (*dto.ChargeResponse, error) {
+	charge, err := s.findCharge(id)
+	if err != nil {
+		return nil, err
+	}
+	if charge.PaidAt != nil {
+		return nil, constants.ErrChargePaid
+	}
+
+	now := time.Now()
+	charge.PaidAt = &now
+	if err := s.chargeRepo.Update(charge); err != nil {
+		return nil, err
+	}
+
+	response := toChargeResponse(charge)
+	return &response, nil
+}
+
+func (s *chargeService) DeleteCharge(id uuid.UUID) error {
+	if _, err := s.findCharge(id); err != nil {
+		return err
+	}
+	return s.chargeRepo.Delete(id)
+}
+
+func (s *chargeService) findCharge(id uuid.UUID) (*domain.Charge, error) {
+	charge, err := s.chargeRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrChargeNotFound
+		}
+		return nil, err
+	}
+	return charge, nil
+}
+
+func toChargeResponses(charges []domain.Charge) []dto.ChargeResponse {
+	responses := make([]dto.ChargeResponse, 0, len(charges))
+	for _, charge := range charges {
+		responses = append(responses, toChargeResponse(&charge))
+	}
+	return responses
+}
@@ -1,18 +1,35 @@
 package service
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"go-rest-api/domain"
 	"go-rest-api/dto"
+	"go-rest-api/internal/config"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/repository"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type CustomerService struct {
-	customerRepo domain.CustomerRepository
+	customerRepo   domain.CustomerRepository
+	dependencyRepo domain.DependencyRepository
+	tierRepo       domain.MembershipTierRepository
+	suspensionRepo domain.SuspensionRepository
+	config         *config.Config
 }
 
-func NewCustomerService(customerRepo domain.CustomerRepository) domain.CustomerService {
-	return &CustomerService{customerRepo: customerRepo}
+func NewCustomerService(customerRepo domain.CustomerRepository, dependencyRepo domain.DependencyRepository, tierRepo domain.MembershipTierRepository, suspensionRepo domain.SuspensionRepository, config *config.Config) domain.CustomerService {
+	return &CustomerService{customerRepo: customerRepo, dependencyRepo: dependencyRepo, tierRepo: tierRepo, suspensionRepo: suspensionRepo, config: config}
 }
 
 func (s *CustomerService) GetAllCustomers() ([]dto.CustomerResponse, error) {
@@ -22,12 +39,8 @@ func (s *CustomerService) GetAllCustomers() ([]dto.CustomerResponse, error) {
 	}
 
 	customerResponses := make([]dto.CustomerResponse, len(customers))
-	for i, customer := range customers {
-		customerResponses[i] = dto.CustomerResponse{
-			ID:   customer.ID,
-			Code: customer.Code,
-			Name: customer.Name,
-		}
+	for i := range customers {
+		customerResponses[i] = toCustomerResponse(&customers[i])
 	}
 
 	return customerResponses, nil
@@ -39,11 +52,23 @@ func (s *CustomerService) GetCustomerByID(id uuid.UUID) (*dto.CustomerResponse,
 		return nil, err
 	}
 
-	return &dto.CustomerResponse{
-		ID:   customer.ID,
-		Code: customer.Code,
-		Name: customer.Name,
-	}, nil
+	suspensions, err := s.suspensionRepo.FindByCustomer(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	suspended := false
+	for i := range suspensions {
+		if suspensionStatus(&suspensions[i], now) == constants.SuspensionStatusActive {
+			suspended = true
+		}
+	}
+
+	response := toCustomerResponse(customer)
+	response.Suspended = &suspended
+	response.Suspensions = toSuspensionResponses(suspensions, now)
+	return &response, nil
 }
 
 func (s *CustomerService) GetCustomerByCode(code string) (*dto.CustomerResponse, error) {
@@ -52,30 +77,62 @@ func (s *CustomerService) GetCustomerByCode(code string) (*dto.CustomerResponse,
 		return nil, err
 	}
 
-	return &dto.CustomerResponse{
-		ID:   customer.ID,
-		Code: customer.Code,
-		Name: customer.Name,
-	}, nil
+	response := toCustomerResponse(customer)
+	return &response, nil
 }
 
 func (s *CustomerService) CreateCustomer(req dto.CustomerCreateRequest) (*dto.CustomerResponse, error) {
 	customer := domain.Customer{
-		ID:   uuid.New(),
-		Code: req.Code,
-		Name: req.Name,
+		ID:                  uuid.New(),
+		Code:                req.Code,
+		Name:                req.Name,
+		Email:               req.Email,
+		Phone:               req.Phone,
+		Address:             req.Address,
+		MembershipType:      req.MembershipType,
+		NotificationChannel: req.NotificationChannel,
 	}
 
-	err := s.customerRepo.Create(&customer)
-	if err != nil {
+	var err error
+	if customer.BirthDate, err = parseDate("birth_date", req.BirthDate); err != nil {
+		return nil, err
+	}
+	if customer.MembershipStartedAt, err = parseDate("membership_started_at", req.MembershipStartedAt); err != nil {
+		return nil, err
+	}
+	if customer.MembershipExpiresAt, err = parseDate("membership_expires_at", req.MembershipExpiresAt); err != nil {
 		return nil, err
 	}
 
-	return &dto.CustomerResponse{
-		ID:   customer.ID,
-		Code: customer.Code,
-		Name: customer.Name,
-	}, nil
+	if customer.MembershipType == "" {
+		customer.MembershipType = constants.MembershipTypeStandard
+	}
+	if customer.MembershipStartedAt == nil {
+		today := truncateToDate(time.Now())
+		customer.MembershipStartedAt = &today
+	}
+	if customer.MembershipExpiresAt == nil {
+		expiresAt := customer.MembershipStartedAt.AddDate(0, s.config.Membership.Months, 0)
+		customer.MembershipExpiresAt = &expiresAt
+	}
+	if customer.NotificationChannel == "" {
+		customer.NotificationChannel = defaultNotificationChannel(&customer)
+	}
+
+	if err := validateCustomerProfile(&customer); err != nil {
+		return nil, err
+	}
+
+	if _, err := customerTier(context.Background(), s.tierRepo, &customer); err != nil {
+		return nil, err
+	}
+
+	if err := s.customerRepo.Create(&customer); err != nil {
+		return nil, err
+	}
+
+	response := toCustomerResponse(&customer)
+	return &response, nil
 }
 
 func (s *CustomerService) UpdateCustomer(id uuid.UUID, req dto.CustomerUpdateRequest) (*dto.CustomerResponse, error) {
@@ -87,18 +144,285 @@ func (s *CustomerService) UpdateCustomer(id uuid.UUID, req dto.CustomerUpdateReq
 	customer.Code = req.Code
 	customer.Name = req.Name
 
+	if req.Email != "" {
+		customer.Email = req.Email
+	}
+	if req.Phone != "" {
+		customer.Phone = req.Phone
+	}
+	if req.Address != "" {
+		customer.Address = req.Address
+	}
+	if req.MembershipType != "" && req.MembershipType != customer.MembershipType {
+		customer.MembershipType = req.MembershipType
+		if _, err := customerTier(context.Background(), s.tierRepo, customer); err != nil {
+			return nil, err
+		}
+	}
+	if req.NotificationChannel != "" {
+		customer.NotificationChannel = req.NotificationChannel
+	}
+
+	if req.BirthDate != "" {
+		if customer.BirthDate, err = parseDate("birth_date", req.BirthDate); err != nil {
+			return nil, err
+		}
+	}
+	if req.MembershipStartedAt != "" {
+		if customer.MembershipStartedAt, err = parseDate("membership_started_at", req.MembershipStartedAt); err != nil {
+			return nil, err
+		}
+	}
+	if req.MembershipExpiresAt != "" {
+		if customer.MembershipExpiresAt, err = parseDate("membership_expires_at", req.MembershipExpiresAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateCustomerProfile(customer); err != nil {
+		return nil, err
+	}
+
 	err = s.customerRepo.Update(customer)
 	if err != nil {
 		return nil, err
 	}
 
-	return &dto.CustomerResponse{
-		ID:   customer.ID,
-		Code: customer.Code,
-		Name: customer.Name,
+	response := toCustomerResponse(customer)
+	return &response, nil
+}
+
+// RenewMembership extends the customer's membership from its current expiry,
+// or from today when it has already lapsed.
+func (s *CustomerService) RenewMembership(id uuid.UUID, req dto.MembershipRenewRequest) (*dto.CustomerResponse, error) {
+	customer, err := s.customerRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	months := req.Months
+	if months == 0 {
+		months = s.config.Membership.Months
+	}
+
+	today := truncateToDate(time.Now())
+	from := today
+	if customer.MembershipExpiresAt == nil || customer.MembershipExpiresAt.Before(today) {
+		// A lapsed membership starts over from today.
+		customer.MembershipStartedAt = &today
+	} else {
+		from = *customer.MembershipExpiresAt
+	}
+
+	expiresAt := from.AddDate(0, months, 0)
+	customer.MembershipExpiresAt = &expiresAt
+
+	if err := s.customerRepo.Update(customer); err != nil {
+		return nil, err
+	}
+
+	response := toCustomerResponse(customer)
+	return &response, nil
+}
+
+// IssuePatronClaim creates a one-time code the customer uses to register a
+// patron account, replacing any code issued before.
+func (s *CustomerService) IssuePatronClaim(id uuid.UUID) (*dto.PatronClaimResponse, error) {
+	customer, err := s.customerRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 10)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	code := base32.StdEncoding.EncodeToString(raw)
+	expiresAt := time.Now().Add(constants.PatronClaimValidHours * time.Hour)
+
+	customer.PatronClaimHash = hashClaimCode(code)
+	customer.PatronClaimExpiresAt = &expiresAt
+	if err := s.customerRepo.Update(customer); err != nil {
+		return nil, err
+	}
+
+	return &dto.PatronClaimResponse{
+		CustomerCode: customer.Code,
+		ClaimCode:    code,
+		ExpiresAt:    expiresAt,
 	}, nil
 }
 
+// DeleteCustomer soft-deletes the customer under every delete rule. Open
+// holds block the block rule; the other rules cancel them and pass their
+// copies on.
 func (s *CustomerService) DeleteCustomer(id uuid.UUID) error {
-	return s.customerRepo.Delete(id)
+	if _, err := s.customerRepo.FindByID(id); err != nil {
+		return err
+	}
+
+	dependents, err := s.dependencyRepo.FindCustomerDependents(context.Background(), id)
+	if err != nil {
+		return err
+	}
+
+	if err := checkDeleteRule(s.config.Delete.Customer, dependents); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return s.customerRepo.(*repository.CustomerRepositoryImpl).GetDB().Transaction(func(tx *gorm.DB) error {
+		for i := range dependents.ActiveHolds {
+			if err := cancelHold(tx, &dependents.ActiveHolds[i], now); err != nil && !errors.Is(err, constants.ErrHoldNotActive) {
+				return err
+			}
+		}
+		return tx.Delete(&domain.Customer{}, id).Error
+	})
+}
+
+func (s *CustomerService) GetTrashedCustomers() ([]dto.CustomerResponse, error) {
+	customers, err := s.customerRepo.FindDeleted()
+	if err != nil {
+		return nil, err
+	}
+
+	customerResponses := make([]dto.CustomerResponse, len(customers))
+	for i := range customers {
+		customerResponses[i] = toCustomerResponse(&customers[i])
+	}
+
+	return customerResponses, nil
+}
+
+func (s *CustomerService) RestoreCustomer(id uuid.UUID) (*dto.CustomerResponse, error) {
+	if _, err := s.customerRepo.FindDeletedByID(id); err != nil {
+		return nil, err
+	}
+
+	if err := s.customerRepo.Restore(id); err != nil {
+		return nil, err
+	}
+
+	return s.GetCustomerByID(id)
+}
+
+// PurgeCustomer permanently removes a customer from the trash once the
+// retention period has passed and no transactions reference them.
+func (s *CustomerService) PurgeCustomer(id uuid.UUID) error {
+	customer, err := s.customerRepo.FindDeletedByID(id)
+	if err != nil {
+		return err
+	}
+
+	retention := time.Duration(s.config.Trash.RetentionDays) * 24 * time.Hour
+	if time.Since(customer.DeletedAt.Time) < retention {
+		return constants.ErrRetentionNotElapsed
+	}
+
+	dependents, err := s.customerRepo.CountDependents(id)
+	if err != nil {
+		return err
+	}
+	if dependents > 0 {
+		return constants.ErrHasDependents
+	}
+
+	return s.customerRepo.Purge(id)
+}
+
+// parseDate reads a YYYY-MM-DD date; an empty value means none.
+func parseDate(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", field, constants.ErrInvalidDate)
+	}
+	return &date, nil
+}
+
+func hashClaimCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// membershipExpired reports whether the customer's membership ended before
+// now's date. A membership is valid through its expiry day.
+func membershipExpired(customer *domain.Customer, now time.Time) bool {
+	return customer.MembershipExpiresAt != nil && customer.MembershipExpiresAt.Before(truncateToDate(now))
+}
+
+// defaultNotificationChannel picks the first channel the customer has
+// contact details for.
+func defaultNotificationChannel(customer *domain.Customer) string {
+	switch {
+	case customer.Email != "":
+		return constants.NotificationChannelEmail
+	case customer.Phone != "":
+		return constants.NotificationChannelSMS
+	case customer.Address != "":
+		return constants.NotificationChannelPost
+	}
+	return constants.NotificationChannelNone
+}
+
+func validateCustomerProfile(customer *domain.Customer) error {
+	if customer.MembershipStartedAt != nil && customer.MembershipExpiresAt != nil &&
+		!customer.MembershipExpiresAt.After(*customer.MembershipStartedAt) {
+		return constants.ErrMembershipDates
+	}
+
+	switch customer.NotificationChannel {
+	case constants.NotificationChannelEmail:
+		if customer.Email == "" {
+			return constants.ErrNotificationContact
+		}
+	case constants.NotificationChannelSMS:
+		if customer.Phone == "" {
+			return constants.ErrNotificationContact
+		}
+	case constants.NotificationChannelPost:
+		if customer.Address == "" {
+			return constants.ErrNotificationContact
+		}
+	}
+	return nil
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+func toCustomerResponse(customer *domain.Customer) dto.CustomerResponse {
+	response := dto.CustomerResponse{
+		ID:                  customer.ID,
+		Code:                customer.Code,
+		Name:                customer.Name,
+		Email:               customer.Email,
+		Phone:               customer.Phone,
+		Address:             customer.Address,
+		BirthDate:           formatDate(customer.BirthDate),
+		MembershipType:      customer.MembershipType,
+		MembershipStartedAt: formatDate(customer.MembershipStartedAt),
+		MembershipExpiresAt: formatDate(customer.MembershipExpiresAt),
+		MembershipExpired:   membershipExpired(customer, time.Now()),
+		NotificationChannel: customer.NotificationChannel,
+		CreatedAt:           customer.CreatedAt,
+		UpdatedAt:           customer.UpdatedAt,
+	}
+
+	if customer.DeletedAt.Valid {
+		response.DeletedAt = &customer.DeletedAt.Time
+	}
+
+	return response
 }
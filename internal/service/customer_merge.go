@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type customerMergeService struct {
+	mergeRepo    domain.CustomerMergeRepository
+	customerRepo domain.CustomerRepository
+}
+
+func NewCustomerMergeService(mergeRepo domain.CustomerMergeRepository, customerRepo domain.CustomerRepository) domain.CustomerMergeService {
+	return &customerMergeService{
+		mergeRepo:    mergeRepo,
+		customerRepo: customerRepo,
+	}
+}
+
+type duplicatePair struct {
+	a, b    int
+	reasons []string
+}
+
+// FindDuplicates pairs customers sharing an email or phone, and customers
+// whose names are similar enough. Each pair lists the older record first as
+// the suggested survivor.
+func (s *customerMergeService) FindDuplicates(ctx context.Context, filter dto.DuplicateFilter) ([]dto.DuplicateCandidate, error) {
+	if filter.MinNameSimilarity <= 0 {
+		filter.MinNameSimilarity = constants.DuplicateNameSimilarity
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = constants.DuplicateCandidateLimit
+	}
+
+	customers, err := s.customerRepo.FindAll()
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	names := make([]string, len(customers))
+	emails := map[string][]int{}
+	phones := map[string][]int{}
+	words := map[string][]int{}
+	for i, customer := range customers {
+		names[i] = normalizeName(customer.Name)
+		if email := strings.ToLower(strings.TrimSpace(customer.Email)); email != "" {
+			emails[email] = append(emails[email], i)
+		}
+		if phone := normalizePhone(customer.Phone); phone != "" {
+			phones[phone] = append(phones[phone], i)
+		}
+		for _, word := range strings.Fields(names[i]) {
+			if len([]rune(word)) >= 2 {
+				words[word] = append(words[word], i)
+			}
+		}
+	}
+
+	pairs := map[[2]int]*duplicatePair{}
+	addReason := func(a, b int, reason string) {
+		if a > b {
+			a, b = b, a
+		}
+		pair, ok := pairs[[2]int{a, b}]
+		if !ok {
+			pair = &duplicatePair{a: a, b: b}
+			pairs[[2]int{a, b}] = pair
+		}
+		for _, existing := range pair.reasons {
+			if existing == reason {
+				return
+			}
+		}
+		pair.reasons = append(pair.reasons, reason)
+	}
+
+	for _, group := range emails {
+		forEachPair(group, func(a, b int) { addReason(a, b, constants.DuplicateReasonEmail) })
+	}
+	for _, group := range phones {
+		forEachPair(group, func(a, b int) { addReason(a, b, constants.DuplicateReasonPhone) })
+	}
+	for _, group := range words {
+		if len(group) > constants.DuplicateNameBucketLimit {
+			continue
+		}
+		forEachPair(group, func(a, b int) {
+			if nameSimilarity(names[a], names[b]) >= filter.MinNameSimilarity {
+				addReason(a, b, constants.DuplicateReasonName)
+			}
+		})
+	}
+
+	candidates := make([]dto.DuplicateCandidate, 0, len(pairs))
+	for _, pair := range pairs {
+		a, b := &customers[pair.a], &customers[pair.b]
+		if b.CreatedAt.Before(a.CreatedAt) {
+			a, b = b, a
+		}
+
+		similarity := nameSimilarity(names[pair.a], names[pair.b])
+		score := similarity
+		for _, reason := range pair.reasons {
+			if reason == constants.DuplicateReasonEmail || reason == constants.DuplicateReasonPhone {
+				score += 0.5
+			}
+		}
+		if a.BirthDate != nil && b.BirthDate != nil && a.BirthDate.Equal(*b.BirthDate) {
+			pair.reasons = append(pair.reasons, constants.DuplicateReasonBirthDate)
+			score += 0.25
+		}
+
+		candidates = append(candidates, dto.DuplicateCandidate{
+			Customer:       toDuplicateCustomer(a),
+			Duplicate:      toDuplicateCustomer(b),
+			Reasons:        pair.reasons,
+			NameSimilarity: similarity,
+			Score:          score,
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Customer.Code < candidates[j].Customer.Code
+	})
+	if len(candidates) > filter.Limit {
+		candidates = candidates[:filter.Limit]
+	}
+
+	return candidates, nil
+}
+
+// Merge folds the duplicate into the survivor. Contact details the survivor
+// lacks are taken from the duplicate, and the later membership expiry wins.
+func (s *customerMergeService) Merge(ctx context.Context, userID uuid.UUID, req dto.CustomerMergeRequest) (*dto.CustomerMergeResponse, error) {
+	if req.SurvivorID == req.DuplicateID {
+		return nil, constants.ErrMergeSameCustomer
+	}
+
+	survivor, err := s.customerRepo.FindByID(req.SurvivorID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrCustomerNotFound
+	}
+	duplicate, err := s.customerRepo.FindByID(req.DuplicateID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrCustomerNotFound
+	}
+
+	if survivor.Email == "" {
+		survivor.Email = duplicate.Email
+	}
+	if survivor.Phone == "" {
+		survivor.Phone = duplicate.Phone
+	}
+	if survivor.Address == "" {
+		survivor.Address = duplicate.Address
+	}
+	if survivor.BirthDate == nil {
+		survivor.BirthDate = duplicate.BirthDate
+	}
+	if duplicate.MembershipExpiresAt != nil && survivor.MembershipExpiresAt != nil &&
+		duplicate.MembershipExpiresAt.After(*survivor.MembershipExpiresAt) {
+		survivor.MembershipExpiresAt = duplicate.MembershipExpiresAt
+	}
+
+	merge := &domain.CustomerMerge{
+		ID:            uuid.New(),
+		SurvivorID:    survivor.ID,
+		DuplicateID:   duplicate.ID,
+		DuplicateCode: duplicate.Code,
+		DuplicateName: duplicate.Name,
+		MergedBy:      userID,
+		CreatedAt:     time.Now(),
+	}
+
+	cancel := func(tx *gorm.DB, hold *domain.Hold) error {
+		return cancelHold(tx, hold, merge.CreatedAt)
+	}
+	if err := s.mergeRepo.Merge(ctx, survivor, duplicate, merge, cancel); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toCustomerMergeResponse(merge)
+	survivorResponse := toCustomerResponse(survivor)
+	response.Survivor = &survivorResponse
+	return &response, nil
+}
+
+func (s *customerMergeService) GetMerges(ctx context.Context, customerID *uuid.UUID) ([]dto.CustomerMergeResponse, error) {
+	merges, err := s.mergeRepo.FindMerges(ctx, customerID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.CustomerMergeResponse, 0, len(merges))
+	for _, merge := range merges {
+		responses = append(responses, toCustomerMergeResponse(&merge))
+	}
+	return responses, nil
+}
+
+func forEachPair(group []int, fn func(a, b int)) {
+	for i := 0; i < len(group); i++ {
+		for j := i + 1; j < len(group); j++ {
+			fn(group[i], group[j])
+		}
+	}
+}
+
+// normalizeName lowercases a name, drops punctuation and sorts its words so
+// "Smith, John" and "john smith" compare equal.
+func normalizeName(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, name)
+	words := strings.Fields(cleaned)
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+func normalizePhone(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// nameSimilarity is one minus the edit distance over the longer name's
+// length, so identical names score 1.
+func nameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func toDuplicateCustomer(customer *domain.Customer) dto.DuplicateCustomer {
+	return dto.DuplicateCustomer{
+		ID:        customer.ID,
+		Code:      customer.Code,
+		Name:      customer.Name,
+		Email:     customer.Email,
+		Phone:     customer.Phone,
+		BirthDate: formatDate(customer.BirthDate),
+		CreatedAt: customer.CreatedAt,
+	}
+}
+
+func toCustomerMergeResponse(merge *domain.CustomerMerge) dto.CustomerMergeResponse {
+	return dto.CustomerMergeResponse{
+		ID:             merge.ID,
+		SurvivorID:     merge.SurvivorID,
+		DuplicateID:    merge.DuplicateID,
+		DuplicateCode:  merge.DuplicateCode,
+		DuplicateName:  merge.DuplicateName,
+		MergedBy:       merge.MergedBy,
+		Loans:          merge.Loans,
+		Charges:        merge.Charges,
+		Holds:          merge.Holds,
+		Suspensions:    merge.Suspensions,
+		Reviews:        merge.Reviews,
+		ReadingLists:   merge.ReadingLists,
+		AccountMoved:   merge.AccountMoved,
+		WishlistItems:  merge.WishlistItems,
+		HoldsCancelled: merge.HoldsCancelled,
+		CreatedAt:      merge.CreatedAt,
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"fmt"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+)
+
+// checkDeleteRule returns a *domain.DeleteBlockedError listing the records
+// that stop a delete under rule. Active loans and outstanding charges always
+// block, as do transfers in progress; copies and loan history only block
+// under the block rule.
+func checkDeleteRule(rule string, dependents *domain.Dependents) error {
+	var records []dto.DependentRecord
+
+	for _, loan := range dependents.ActiveLoans {
+		records = append(records, dto.DependentRecord{
+			Type:   constants.DependentActiveLoan,
+			ID:     loan.ID.String(),
+			Detail: fmt.Sprintf("stock %s borrowed by customer %s, due %s", loan.StockCode, loan.CustomerID, loan.DueDate.Format("2006-01-02")),
+		})
+	}
+
+	for _, charge := range dependents.OutstandingCharges {
+		records = append(records, dto.DependentRecord{
+			Type:   constants.DependentOutstandingCharge,
+			ID:     charge.ID.String(),
+			Detail: fmt.Sprintf("unpaid charge of %.2f on transaction %s", charge.Total, charge.BookTransactionID),
+		})
+	}
+
+	for _, transfer := range dependents.ActiveTransfers {
+		records = append(records, dto.DependentRecord{
+			Type:   constants.DependentActiveTransfer,
+			ID:     transfer.ID.String(),
+			Detail: fmt.Sprintf("stock %s transfer %s", transfer.StockCode, transfer.Status),
+		})
+	}
+
+	if rule == constants.DeleteRuleBlock {
+		for _, stock := range dependents.Copies {
+			records = append(records, dto.DependentRecord{
+				Type:   constants.DependentCopy,
+				ID:     stock.Code,
+				Detail: "copy with status " + stock.Status,
+			})
+		}
+
+		for _, loan := range dependents.LoanHistory {
+			records = append(records, dto.DependentRecord{
+				Type:   constants.DependentLoanHistory,
+				ID:     loan.ID.String(),
+				Detail: fmt.Sprintf("closed loan by customer %s", loan.CustomerID),
+			})
+		}
+
+		for _, hold := range dependents.ActiveHolds {
+			records = append(records, dto.DependentRecord{
+				Type:   constants.DependentActiveHold,
+				ID:     hold.ID.String(),
+				Detail: "hold with status " + hold.Status,
+			})
+		}
+	}
+
+	if len(records) > 0 {
+		return &domain.DeleteBlockedError{Dependents: records}
+	}
+
+	return nil
+}
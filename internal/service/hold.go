@@ -0,0 +1,424 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/repository"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type holdService struct {
+	holdRepo       domain.HoldRepository
+	bookRepo       domain.BookRepository
+	workRepo       domain.WorkRepository
+	customerRepo   domain.CustomerRepository
+	branchRepo     domain.BranchRepository
+	tierRepo       domain.MembershipTierRepository
+	suspensionRepo domain.SuspensionRepository
+}
+
+func NewHoldService(holdRepo domain.HoldRepository, bookRepo domain.BookRepository, workRepo domain.WorkRepository, customerRepo domain.CustomerRepository, branchRepo domain.BranchRepository, tierRepo domain.MembershipTierRepository, suspensionRepo domain.SuspensionRepository) domain.HoldService {
+	return &holdService{
+		holdRepo:       holdRepo,
+		bookRepo:       bookRepo,
+		workRepo:       workRepo,
+		customerRepo:   customerRepo,
+		branchRepo:     branchRepo,
+		tierRepo:       tierRepo,
+		suspensionRepo: suspensionRepo,
+	}
+}
+
+func (s *holdService) GetHolds(ctx context.Context, filter dto.HoldFilter) ([]dto.HoldResponse, error) {
+	holds, err := s.holdRepo.Find(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	holdResponses := make([]dto.HoldResponse, 0, len(holds))
+	for _, hold := range holds {
+		holdResponses = append(holdResponses, toHoldResponse(&hold))
+	}
+
+	return holdResponses, nil
+}
+
+func (s *holdService) GetHoldByID(ctx context.Context, id uuid.UUID) (*dto.HoldResponse, error) {
+	hold, err := s.holdRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toHoldResponse(hold)
+	return &response, nil
+}
+
+func (s *holdService) PlaceHold(ctx context.Context, req dto.HoldCreateRequest) (*dto.HoldResponse, error) {
+	customer, err := s.customerRepo.FindByID(req.CustomerID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrCustomerNotFound
+	}
+
+	if err := checkNotSuspended(ctx, s.suspensionRepo, customer.ID); err != nil {
+		return nil, err
+	}
+
+	tier, err := customerTier(ctx, s.tierRepo, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	holds, err := s.holdRepo.CountActive(ctx, dto.HoldFilter{CustomerID: &req.CustomerID})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	if holds >= int64(tier.HoldLimit) {
+		return nil, constants.ErrHoldLimitReached
+	}
+
+	// An edition hold and a work hold covering that edition would take two
+	// copies from the queue, so either one blocks the other.
+	db := s.holdRepo.(*repository.HoldRepositoryImpl).GetDB().WithContext(ctx)
+	overlapping := db.Model(&domain.Hold{}).
+		Where("customer_id = ? AND status IN ?", req.CustomerID, []string{constants.HoldStatusWaiting, constants.HoldStatusReady})
+	if req.WorkID != nil {
+		if _, err := s.workRepo.FindByID(ctx, *req.WorkID); err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, constants.ErrWorkNotFound
+		}
+		overlapping = overlapping.Where("work_id = ? OR book_id IN (SELECT id FROM books WHERE work_id = ?)", *req.WorkID, *req.WorkID)
+	} else {
+		book, err := s.bookRepo.FindByID(ctx, *req.BookID)
+		if err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, constants.ErrBookNotFound
+		}
+		overlapping = holdMatchesBook(overlapping, book)
+	}
+
+	var active int64
+	if err := overlapping.Count(&active).Error; err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	if active > 0 {
+		return nil, constants.ErrHoldExists
+	}
+
+	if req.PickupBranchID != nil {
+		if _, err := s.branchRepo.FindByID(ctx, *req.PickupBranchID); err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, constants.ErrBranchNotFound
+		}
+	}
+
+	now := time.Now()
+	hold := &domain.Hold{
+		ID:             uuid.New(),
+		CustomerID:     req.CustomerID,
+		BookID:         req.BookID,
+		WorkID:         req.WorkID,
+		PickupBranchID: req.PickupBranchID,
+		Status:         constants.HoldStatusWaiting,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	// A hold for pickup at a branch without a copy on the shelf pulls one in
+	// from another branch straight away.
+	var transfer *domain.Transfer
+	err = s.holdRepo.(*repository.HoldRepositoryImpl).GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(hold).Error; err != nil {
+			return err
+		}
+		var err error
+		transfer, err = requestHoldTransfer(tx, hold, now)
+		return err
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toHoldResponse(hold)
+	if transfer != nil {
+		response.TransferID = &transfer.ID
+	}
+	return &response, nil
+}
+
+func (s *holdService) CancelHold(ctx context.Context, id uuid.UUID) (*dto.HoldResponse, error) {
+	hold, err := s.holdRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if hold.Status != constants.HoldStatusWaiting && hold.Status != constants.HoldStatusReady {
+		return nil, constants.ErrHoldNotActive
+	}
+
+	now := time.Now()
+	err = s.holdRepo.(*repository.HoldRepositoryImpl).GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return cancelHold(tx, hold, now)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	hold.Status = constants.HoldStatusCancelled
+	hold.UpdatedAt = now
+
+	response := toHoldResponse(hold)
+	return &response, nil
+}
+
+// ExpireHolds closes the ready holds that were not collected in time and
+// offers their copies to the next customer waiting.
+func (s *holdService) ExpireHolds(ctx context.Context) (int, error) {
+	now := time.Now()
+	holds, err := s.holdRepo.FindExpired(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return 0, err
+	}
+
+	expired := 0
+	for _, hold := range holds {
+		err := s.holdRepo.(*repository.HoldRepositoryImpl).GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&domain.Hold{}).
+				Where("id = ? AND status = ?", hold.ID, constants.HoldStatusReady).
+				Updates(map[string]interface{}{"status": constants.HoldStatusExpired, "updated_at": now})
+			if result.Error != nil || result.RowsAffected == 0 || hold.StockCode == nil {
+				return result.Error
+			}
+			return releaseHeldCopy(tx, *hold.StockCode, now)
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return expired, err
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// cancelHold cancels a waiting or ready hold. A copy set aside for it goes to
+// the next customer waiting, and a copy not yet shipped for it stays where it
+// is.
+func cancelHold(tx *gorm.DB, hold *domain.Hold, now time.Time) error {
+	result := tx.Model(&domain.Hold{}).
+		Where("id = ? AND status IN ?", hold.ID, []string{constants.HoldStatusWaiting, constants.HoldStatusReady}).
+		Updates(map[string]interface{}{"status": constants.HoldStatusCancelled, "updated_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrHoldNotActive
+	}
+	if err := tx.Model(&domain.Transfer{}).
+		Where("hold_id = ? AND status = ?", hold.ID, constants.TransferStatusRequested).
+		Updates(map[string]interface{}{"status": constants.TransferStatusCancelled, "cancelled_at": now, "updated_at": now}).Error; err != nil {
+		return err
+	}
+	if hold.Status == constants.HoldStatusReady && hold.StockCode != nil {
+		return releaseHeldCopy(tx, *hold.StockCode, now)
+	}
+	return nil
+}
+
+// holdMatchesBook selects the holds a copy of book can fill: holds on that
+// edition and holds on any edition of its work.
+func holdMatchesBook(tx *gorm.DB, book *domain.Book) *gorm.DB {
+	if book.WorkID != nil {
+		return tx.Where("book_id = ? OR work_id = ?", book.ID, *book.WorkID)
+	}
+	return tx.Where("book_id = ?", book.ID)
+}
+
+// trapHold sets the returned copy aside for the oldest waiting hold it can
+// fill. When the hold is picked up at another branch the copy is sent there
+// instead, and the hold readies on arrival. It does nothing when no hold is
+// waiting.
+func trapHold(tx *gorm.DB, book *domain.Book, stock *domain.BookStock, now time.Time) error {
+	// Copies that never leave the library cannot fill a hold.
+	switch effectiveItemType(book, stock) {
+	case constants.ItemTypeReference, constants.ItemTypeInLibrary:
+		return nil
+	}
+
+	var hold domain.Hold
+	err := holdMatchesBook(tx.Model(&domain.Hold{}), book).
+		Where("status = ?", constants.HoldStatusWaiting).
+		Where("NOT EXISTS (SELECT 1 FROM transfers WHERE transfers.hold_id = holds.id AND transfers.status IN ?)", activeTransferStatuses).
+		Order("created_at").
+		First(&hold).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if hold.PickupBranchID != nil && stock.CurrentBranchID != nil && *hold.PickupBranchID != *stock.CurrentBranchID {
+		transfer := newTransfer(stock, *hold.PickupBranchID, constants.TransferReasonHold, &hold.ID, now)
+		return tx.Omit("BookStock", "FromBranch", "ToBranch").Create(transfer).Error
+	}
+
+	pickupBy := holdPickupBy(now)
+	hold.Status = constants.HoldStatusReady
+	hold.StockCode = &stock.Code
+	hold.ReadyAt = &now
+	hold.PickupBy = &pickupBy
+	hold.UpdatedAt = now
+	return tx.Save(&hold).Error
+}
+
+// holdPickupBy is the deadline for collecting a hold that readies at now.
+func holdPickupBy(now time.Time) time.Time {
+	return now.AddDate(0, 0, constants.HoldPickupDays)
+}
+
+// releaseHeldCopy offers a copy no longer set aside for its hold to the next
+// waiting hold. Copies that have since left the shelf are left alone.
+func releaseHeldCopy(tx *gorm.DB, stockCode string, now time.Time) error {
+	var stock domain.BookStock
+	err := tx.Preload("Book").Where("code = ?", stockCode).First(&stock).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if stock.Status != constants.BookStockStatusAvailable {
+		return nil
+	}
+	return trapHold(tx, &stock.Book, &stock, now)
+}
+
+// requeueHold puts the ready hold on a copy that has left the shelf back in
+// the queue, keeping its place, and readies it with another copy on hand or
+// asks another branch for one.
+func requeueHold(tx *gorm.DB, stockCode string, now time.Time) error {
+	var hold domain.Hold
+	err := tx.Where("stock_code = ? AND status = ?", stockCode, constants.HoldStatusReady).First(&hold).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	hold.Status = constants.HoldStatusWaiting
+	hold.StockCode = nil
+	hold.ReadyAt = nil
+	hold.PickupBy = nil
+	hold.UpdatedAt = now
+
+	var stock domain.BookStock
+	query := holdCandidates(tx, &hold)
+	if hold.PickupBranchID != nil {
+		query = query.Where("current_branch_id = ?", *hold.PickupBranchID)
+	}
+	err = query.Order("code").First(&stock).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := tx.Save(&hold).Error; err != nil {
+			return err
+		}
+		_, err = requestHoldTransfer(tx, &hold, now)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	pickupBy := holdPickupBy(now)
+	hold.Status = constants.HoldStatusReady
+	hold.StockCode = &stock.Code
+	hold.ReadyAt = &now
+	hold.PickupBy = &pickupBy
+	return tx.Save(&hold).Error
+}
+
+// requeueHoldOnChange is the status change hook that requeues the hold on a
+// copy no longer AVAILABLE.
+func requeueHoldOnChange(now time.Time) domain.StatusChangeHook {
+	return func(tx *gorm.DB, log domain.BookStockStatusLog) error {
+		if log.ToStatus == constants.BookStockStatusAvailable {
+			return nil
+		}
+		return requeueHold(tx, log.StockCode, now)
+	}
+}
+
+// checkCopyNotHeld fails when the copy is set aside for another customer's
+// ready hold.
+func checkCopyNotHeld(tx *gorm.DB, stockCode string, customerID uuid.UUID) error {
+	var count int64
+	err := tx.Model(&domain.Hold{}).
+		Where("stock_code = ? AND status = ? AND customer_id <> ?", stockCode, constants.HoldStatusReady, customerID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return constants.ErrStockOnHold
+	}
+	return nil
+}
+
+// fulfillHolds closes the customer's active holds that a loan of book
+// satisfies. Copies set aside for those holds other than the one lent out
+// are released to the next customer waiting.
+func fulfillHolds(tx *gorm.DB, book *domain.Book, customerID uuid.UUID, stockCode string, now time.Time) error {
+	var ready []domain.Hold
+	err := holdMatchesBook(tx.Model(&domain.Hold{}), book).
+		Where("customer_id = ? AND status = ? AND stock_code <> ?", customerID, constants.HoldStatusReady, stockCode).
+		Find(&ready).Error
+	if err != nil {
+		return err
+	}
+
+	err = holdMatchesBook(tx.Model(&domain.Hold{}), book).
+		Where("customer_id = ? AND status IN ?", customerID, []string{constants.HoldStatusWaiting, constants.HoldStatusReady}).
+		Updates(map[string]interface{}{"status": constants.HoldStatusFulfilled, "updated_at": now}).Error
+	if err != nil {
+		return err
+	}
+
+	for _, hold := range ready {
+		if err := releaseHeldCopy(tx, *hold.StockCode, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toHoldResponse(hold *domain.Hold) dto.HoldResponse {
+	return dto.HoldResponse{
+		ID:             hold.ID,
+		CustomerID:     hold.CustomerID,
+		BookID:         hold.BookID,
+		WorkID:         hold.WorkID,
+		PickupBranchID: hold.PickupBranchID,
+		Status:         hold.Status,
+		StockCode:      hold.StockCode,
+		ReadyAt:        hold.ReadyAt,
+		PickupBy:       hold.PickupBy,
+		CreatedAt:      hold.CreatedAt,
+		UpdatedAt:      hold.UpdatedAt,
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"image/png"
+	"log/slog"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/jung-kurt/gofpdf"
+)
+
+type labelService struct {
+	bookstockRepo domain.BookstockRepository
+}
+
+func NewLabelService(bookstockRepo domain.BookstockRepository) domain.LabelService {
+	return &labelService{
+		bookstockRepo: bookstockRepo,
+	}
+}
+
+func (s *labelService) RenderBarcode(ctx context.Context, code string, opts dto.BarcodeOptions) ([]byte, string, error) {
+	if _, err := s.bookstockRepo.FindByCode(code); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, "", constants.ErrBookstockNotFound
+	}
+
+	bc, err := encodeBarcode(code, opts.Type)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch opts.Format {
+	case constants.BarcodeFormatSVG:
+		return []byte(barcodeSVG(bc)), "image/svg+xml", nil
+	case constants.BarcodeFormatPNG:
+		data, err := barcodePNG(bc, opts.Type, opts.Width, opts.Height)
+		return data, "image/png", err
+	}
+
+	return nil, "", constants.ErrUnsupportedBarcode
+}
+
+// RenderLabelSheet prints a label per code with the book title, the barcode
+// and the code in plain text. Unknown codes fail the whole sheet.
+func (s *labelService) RenderLabelSheet(ctx context.Context, req dto.LabelSheetRequest) ([]byte, error) {
+	if req.Type == "" {
+		req.Type = constants.BarcodeTypeCode128
+	}
+	if req.Columns == 0 {
+		req.Columns = constants.LabelDefaultColumns
+	}
+	if req.Rows == 0 {
+		req.Rows = constants.LabelDefaultRows
+	}
+
+	stocks, err := s.bookstockRepo.FindByCodes(req.Codes)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	titles := make(map[string]string, len(stocks))
+	for _, stock := range stocks {
+		titles[stock.Code] = stock.Book.Title
+	}
+
+	var missing []string
+	for _, code := range req.Codes {
+		if _, ok := titles[code]; !ok {
+			missing = append(missing, code)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", constants.ErrBookstockNotFound, strings.Join(missing, ", "))
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(constants.LabelPageMargin, constants.LabelPageMargin, constants.LabelPageMargin)
+	pdf.SetAutoPageBreak(false, 0)
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	labelWidth := (pageWidth - 2*constants.LabelPageMargin) / float64(req.Columns)
+	labelHeight := (pageHeight - 2*constants.LabelPageMargin) / float64(req.Rows)
+	const padding = 2.0
+	const textHeight = 4.0
+
+	perPage := req.Columns * req.Rows
+	for i, code := range req.Codes {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+		x := constants.LabelPageMargin + float64(i%req.Columns)*labelWidth + padding
+		y := constants.LabelPageMargin + float64((i%perPage)/req.Columns)*labelHeight + padding
+		innerWidth := labelWidth - 2*padding
+		imageHeight := labelHeight - 2*padding - 2*textHeight
+
+		pdf.SetFont("Helvetica", "B", 8)
+		pdf.SetXY(x, y)
+		pdf.CellFormat(innerWidth, textHeight, fitText(pdf, translate(titles[code]), innerWidth), "", 0, "C", false, 0, "")
+
+		bc, err := encodeBarcode(code, req.Type)
+		if err != nil {
+			return nil, err
+		}
+		image, err := barcodePNG(bc, req.Type, constants.BarcodeDefaultWidth, constants.BarcodeDefaultHeight)
+		if err != nil {
+			return nil, err
+		}
+
+		imageWidth := innerWidth
+		if req.Type == constants.BarcodeTypeQR {
+			imageWidth = imageHeight
+		}
+		name := fmt.Sprintf("barcode-%d", i)
+		pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(image))
+		pdf.ImageOptions(name, x+(innerWidth-imageWidth)/2, y+textHeight, imageWidth, imageHeight, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+		pdf.SetFont("Courier", "", 8)
+		pdf.SetXY(x, y+textHeight+imageHeight)
+		pdf.CellFormat(innerWidth, textHeight, code, "", 0, "C", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeBarcode(value, kind string) (barcode.Barcode, error) {
+	switch kind {
+	case constants.BarcodeTypeCode128:
+		return code128.Encode(value)
+	case constants.BarcodeTypeQR:
+		return qr.Encode(value, qr.M, qr.Auto)
+	}
+	return nil, constants.ErrUnsupportedBarcode
+}
+
+func barcodePNG(bc barcode.Barcode, kind string, width, height int) ([]byte, error) {
+	if width <= 0 {
+		width = constants.BarcodeDefaultWidth
+	}
+	if height <= 0 || kind == constants.BarcodeTypeQR {
+		height = constants.BarcodeDefaultHeight
+	}
+	if kind == constants.BarcodeTypeQR {
+		height = width
+	}
+
+	scaled, err := barcode.Scale(bc, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, scaled); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// barcodeSVG draws the unscaled barcode one unit per module, joining runs of
+// dark modules into a single rectangle. Linear codes are one module high and
+// are stretched to a third of their width.
+func barcodeSVG(bc barcode.Barcode) string {
+	bounds := bc.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	barHeight := 1
+	if height == 1 {
+		barHeight = width / 3
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, width, height*barHeight)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="#fff"/>`, width, height*barHeight)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if !isDark(bc, bounds.Min.X+x, bounds.Min.Y+y) {
+				continue
+			}
+			run := 1
+			for x+run < width && isDark(bc, bounds.Min.X+x+run, bounds.Min.Y+y) {
+				run++
+			}
+			fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%d" height="%d"/>`, x, y*barHeight, run, barHeight)
+			x += run - 1
+		}
+	}
+	svg.WriteString(`</svg>`)
+	return svg.String()
+}
+
+func isDark(bc barcode.Barcode, x, y int) bool {
+	r, g, b, _ := bc.At(x, y).RGBA()
+	return r+g+b < 3*0x8000
+}
+
+// fitText shortens text with an ellipsis until it fits in width.
+func fitText(pdf *gofpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/marc"
+	"io"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const marcLeader = "00000nam a2200000 a 4500"
+
+var (
+	marcYearPattern = regexp.MustCompile(`\d{4}`)
+	marcISBNPattern = regexp.MustCompile(`^[0-9Xx-]+`)
+)
+
+type marcService struct {
+	bookRepo domain.BookRepository
+}
+
+func NewMARCService(bookRepo domain.BookRepository) domain.MARCService {
+	return &marcService{
+		bookRepo: bookRepo,
+	}
+}
+
+func (s *marcService) ImportRecords(ctx context.Context, r io.Reader, opts dto.MARCImportOptions) (*dto.MARCImportReport, error) {
+	var reader marc.Reader
+	switch opts.Format {
+	case constants.MARCFormatBinary:
+		reader = marc.NewBinaryReader(r)
+	case constants.MARCFormatXML:
+		reader = marc.NewXMLReader(r)
+	default:
+		return nil, fmt.Errorf("unsupported MARC format %q", opts.Format)
+	}
+
+	report := &dto.MARCImportReport{
+		DryRun:  opts.DryRun,
+		Format:  opts.Format,
+		Records: []dto.MARCRecordReport{},
+	}
+
+	// ISBNs accepted earlier in the file, since a dry run never creates them
+	// for the catalogue lookup to find.
+	seen := make(map[string]int)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if errors.Is(err, marc.ErrInvalidRecord) {
+				report.Total++
+				report.Records = append(report.Records, dto.MARCRecordReport{
+					Index:  report.Total,
+					Status: constants.MARCRecordInvalid,
+					Errors: []string{err.Error()},
+				})
+				continue
+			}
+			return nil, fmt.Errorf("failed to read MARC record %d: %w", report.Total+1, err)
+		}
+
+		report.Total++
+		book, recordReport := bookFromMARC(record)
+		recordReport.Index = report.Total
+
+		if book.Title == "" {
+			recordReport.Status = constants.MARCRecordInvalid
+			recordReport.Errors = append(recordReport.Errors, "field 245 $a (title) is missing")
+			report.Records = append(report.Records, recordReport)
+			continue
+		}
+
+		if index, ok := seen[book.ISBN]; ok && book.ISBN != "" {
+			recordReport.Status = constants.MARCRecordDuplicate
+			recordReport.Errors = append(recordReport.Errors, fmt.Sprintf("ISBN %s already appears in record %d", book.ISBN, index))
+			report.Records = append(report.Records, recordReport)
+			continue
+		}
+
+		if book.ISBN != "" {
+			existing, err := s.bookRepo.FindByISBN(ctx, book.ISBN)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				slog.ErrorContext(ctx, err.Error())
+				return nil, err
+			}
+			if existing != nil {
+				recordReport.Status = constants.MARCRecordDuplicate
+				recordReport.BookID = &existing.ID
+				recordReport.Errors = append(recordReport.Errors, "a book with ISBN "+book.ISBN+" already exists")
+				report.Records = append(report.Records, recordReport)
+				continue
+			}
+		}
+
+		if opts.DryRun {
+			seen[book.ISBN] = recordReport.Index
+			recordReport.Status = constants.MARCRecordValid
+			report.Records = append(report.Records, recordReport)
+			continue
+		}
+
+		book.CreatedAt = time.Now()
+		book.UpdatedAt = time.Now()
+		if err := s.bookRepo.Create(ctx, book); err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			recordReport.Status = constants.MARCRecordInvalid
+			recordReport.Errors = append(recordReport.Errors, err.Error())
+			report.Records = append(report.Records, recordReport)
+			continue
+		}
+
+		seen[book.ISBN] = recordReport.Index
+		recordReport.Status = constants.MARCRecordImported
+		recordReport.BookID = &book.ID
+		report.Imported++
+		report.Records = append(report.Records, recordReport)
+	}
+
+	return report, nil
+}
+
+func (s *marcService) ExportBooks(ctx context.Context, w io.Writer, ids []uuid.UUID) error {
+	writer, err := marc.NewXMLWriter(w)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) > 0 {
+		for _, id := range ids {
+			book, err := s.bookRepo.FindByID(ctx, id)
+			if err != nil {
+				slog.ErrorContext(ctx, err.Error())
+				return err
+			}
+			if err := writer.Write(marcFromBook(book)); err != nil {
+				return err
+			}
+		}
+		return writer.Close()
+	}
+
+	err = s.bookRepo.FindInBatches(ctx, constants.BookExportBatchSize, func(books []domain.Book) error {
+		for _, book := range books {
+			if err := writer.Write(marcFromBook(&book)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	return writer.Close()
+}
+
+// bookFromMARC maps the commonly used MARC21 bibliographic fields onto a
+// book and reports which tags were used and which were ignored.
+func bookFromMARC(record *marc.Record) (*domain.Book, dto.MARCRecordReport) {
+	book := &domain.Book{}
+	report := dto.MARCRecordReport{
+		Mapped:   []dto.MARCFieldMapping{},
+		Unmapped: []string{},
+	}
+	mapped := make(map[string]bool)
+
+	use := func(tag, field, value string) {
+		if value == "" {
+			return
+		}
+		mapped[tag] = true
+		report.Mapped = append(report.Mapped, dto.MARCFieldMapping{Tag: tag, Field: field, Value: value})
+	}
+
+	if fixed := record.ControlField("008"); len(fixed) >= 38 {
+		book.Language = strings.TrimSpace(fixed[35:38])
+		use("008", "language", book.Language)
+	}
+
+	for _, field := range record.Fields("020") {
+		if isbn := normalizeISBN(field.Subfield("a")); isbn != "" {
+			book.ISBN = isbn
+			use("020", "isbn", isbn)
+			break
+		}
+	}
+
+	var authors []string
+	for _, tag := range []string{"100", "700"} {
+		for _, field := range record.Fields(tag) {
+			if name := marc.Clean(field.Subfield("a")); name != "" {
+				authors = append(authors, name)
+				use(tag, "author", name)
+			}
+		}
+	}
+	book.Author = strings.Join(authors, "; ")
+
+	for _, field := range record.Fields("245") {
+		title := marc.Clean(field.Subfield("a"))
+		if subtitle := marc.Clean(field.Subfield("b")); subtitle != "" {
+			title += ": " + subtitle
+		}
+		book.Title = title
+		use("245", "title", title)
+		break
+	}
+
+	// 264 with second indicator 1 is the RDA publication statement; older
+	// records carry the same data in 260.
+	for _, tag := range []string{"264", "260"} {
+		if book.Publisher != "" {
+			break
+		}
+		for _, field := range record.Fields(tag) {
+			if tag == "264" && field.Ind2 != "1" {
+				continue
+			}
+			book.Publisher = marc.Clean(field.Subfield("b"))
+			use(tag, "publisher", book.Publisher)
+			if year := marcYearPattern.FindString(field.Subfield("c")); year != "" {
+				book.PublicationYear, _ = strconv.Atoi(year)
+				use(tag, "publication_year", year)
+			}
+			break
+		}
+	}
+
+	var summaries []string
+	for _, field := range record.Fields("520") {
+		if summary := strings.TrimSpace(field.Subfield("a")); summary != "" {
+			summaries = append(summaries, summary)
+			use("520", "description", summary)
+		}
+	}
+	book.Description = strings.Join(summaries, "\n\n")
+
+	var subjects []string
+	for _, field := range record.Fields("650") {
+		if subject := marc.Clean(field.Subfield("a")); subject != "" {
+			subjects = append(subjects, subject)
+			use("650", "subjects", subject)
+		}
+	}
+	book.Subjects = strings.Join(subjects, "; ")
+
+	for _, tag := range record.Tags() {
+		if !mapped[tag] {
+			mapped[tag] = true
+			report.Unmapped = append(report.Unmapped, tag)
+		}
+	}
+
+	report.Title = book.Title
+	return book, report
+}
+
+func marcFromBook(book *domain.Book) *marc.Record {
+	record := &marc.Record{Leader: marcLeader}
+	record.AddControlField("001", book.ID.String())
+	record.AddControlField("008", marcFixedField(book))
+
+	record.AddDataField("020", " ", " ", "a", book.ISBN)
+
+	for i, author := range splitList(book.Author) {
+		tag := "700"
+		if i == 0 {
+			tag = "100"
+		}
+		record.AddDataField(tag, "1", " ", "a", author)
+	}
+
+	record.AddDataField("245", "1", "0", "a", book.Title)
+
+	year := ""
+	if book.PublicationYear > 0 {
+		year = strconv.Itoa(book.PublicationYear)
+	}
+	record.AddDataField("264", " ", "1", "b", book.Publisher, "c", year)
+
+	record.AddDataField("520", " ", " ", "a", book.Description)
+
+	for _, subject := range splitList(book.Subjects) {
+		record.AddDataField("650", " ", "0", "a", subject)
+	}
+
+	if book.Cover != nil {
+		record.AddDataField("856", "4", "2", "u", book.Cover.Path, "3", "Cover image")
+	}
+
+	return record
+}
+
+// marcFixedField builds a 40 character 008 field carrying the entry date,
+// publication year and language.
+func marcFixedField(book *domain.Book) string {
+	fixed := []byte(strings.Repeat(" ", 40))
+	copy(fixed[0:6], book.CreatedAt.Format("060102"))
+	fixed[6] = 's'
+	if book.PublicationYear > 0 {
+		copy(fixed[7:11], fmt.Sprintf("%04d", book.PublicationYear))
+	}
+	language := book.Language
+	if len(language) != 3 {
+		language = "und"
+	}
+	copy(fixed[35:38], language)
+	fixed[39] = 'd'
+	return string(fixed)
+}
+
+func normalizeISBN(value string) string {
+	return strings.ReplaceAll(marcISBNPattern.FindString(strings.TrimSpace(value)), "-", "")
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
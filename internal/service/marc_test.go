@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/marc"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestBookFromMARC(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func(r *marc.Record)
+		want     domain.Book
+		unmapped string
+	}{
+		{
+			name: "isbn from first usable 020",
+			build: func(r *marc.Record) {
+				r.AddDataField("020", " ", " ", "z", "9999999999")
+				r.AddDataField("020", " ", " ", "a", "978-0-14-143951-8 (pbk.)")
+				r.AddDataField("020", " ", " ", "a", "0141439513")
+			},
+			want: domain.Book{ISBN: "9780141439518"},
+		},
+		{
+			name: "authors from 100 and 700",
+			build: func(r *marc.Record) {
+				r.AddDataField("100", "1", " ", "a", "Austen, Jane,")
+				r.AddDataField("700", "1", " ", "a", "Tanner, Tony.")
+			},
+			want: domain.Book{Author: "Austen, Jane; Tanner, Tony"},
+		},
+		{
+			name: "title with subtitle from 245",
+			build: func(r *marc.Record) {
+				r.AddDataField("245", "1", "0", "a", "Pride and prejudice :", "b", "a novel /", "c", "Jane Austen.")
+			},
+			want: domain.Book{Title: "Pride and prejudice: a novel"},
+		},
+		{
+			name: "publication from 260",
+			build: func(r *marc.Record) {
+				r.AddDataField("260", " ", " ", "a", "London :", "b", "Penguin,", "c", "c1996.")
+			},
+			want: domain.Book{Publisher: "Penguin", PublicationYear: 1996},
+		},
+		{
+			name: "264 publication statement wins over 260",
+			build: func(r *marc.Record) {
+				r.AddDataField("260", " ", " ", "b", "Old Press,", "c", "1990")
+				r.AddDataField("264", " ", "4", "c", "©2002")
+				r.AddDataField("264", " ", "1", "b", "Penguin Classics,", "c", "[2003]")
+			},
+			want:     domain.Book{Publisher: "Penguin Classics", PublicationYear: 2003},
+			unmapped: "260",
+		},
+		{
+			name: "subjects from 650",
+			build: func(r *marc.Record) {
+				r.AddDataField("650", " ", "0", "a", "Courtship", "v", "Fiction.")
+				r.AddDataField("650", " ", "0", "a", "Sisters.")
+			},
+			want: domain.Book{Subjects: "Courtship; Sisters"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &marc.Record{}
+			tt.build(record)
+
+			book, report := bookFromMARC(record)
+			if book.ISBN != tt.want.ISBN {
+				t.Errorf("ISBN = %q, want %q", book.ISBN, tt.want.ISBN)
+			}
+			if book.Author != tt.want.Author {
+				t.Errorf("Author = %q, want %q", book.Author, tt.want.Author)
+			}
+			if book.Title != tt.want.Title {
+				t.Errorf("Title = %q, want %q", book.Title, tt.want.Title)
+			}
+			if book.Publisher != tt.want.Publisher {
+				t.Errorf("Publisher = %q, want %q", book.Publisher, tt.want.Publisher)
+			}
+			if book.PublicationYear != tt.want.PublicationYear {
+				t.Errorf("PublicationYear = %d, want %d", book.PublicationYear, tt.want.PublicationYear)
+			}
+			if book.Subjects != tt.want.Subjects {
+				t.Errorf("Subjects = %q, want %q", book.Subjects, tt.want.Subjects)
+			}
+			if got := strings.Join(report.Unmapped, ","); got != tt.unmapped {
+				t.Errorf("Unmapped = %q, want %q", got, tt.unmapped)
+			}
+		})
+	}
+}
+
+func TestBookFromMARCReportsUnmappedTags(t *testing.T) {
+	record := &marc.Record{}
+	record.AddDataField("245", "1", "0", "a", "Persuasion")
+	record.AddDataField("500", " ", " ", "a", "Includes index.")
+	record.AddDataField("500", " ", " ", "a", "First published 1817.")
+
+	_, report := bookFromMARC(record)
+	if len(report.Unmapped) != 1 || report.Unmapped[0] != "500" {
+		t.Errorf("Unmapped = %v, want [500]", report.Unmapped)
+	}
+}
+
+// emptyCatalogue is a book repository with no books in it.
+type emptyCatalogue struct {
+	domain.BookRepository
+}
+
+func (emptyCatalogue) FindByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestImportRecordsDryRunFlagsRepeatedISBN(t *testing.T) {
+	xml := `<collection xmlns="http://www.loc.gov/MARC21/slim">
+  <record>
+    <datafield tag="020" ind1=" " ind2=" "><subfield code="a">9780141439518</subfield></datafield>
+    <datafield tag="245" ind1="1" ind2="0"><subfield code="a">Pride and prejudice</subfield></datafield>
+  </record>
+  <record>
+    <datafield tag="020" ind1=" " ind2=" "><subfield code="a">978-0-14-143951-8</subfield></datafield>
+    <datafield tag="245" ind1="1" ind2="0"><subfield code="a">Pride and prejudice</subfield></datafield>
+  </record>
+</collection>`
+
+	s := NewMARCService(emptyCatalogue{})
+	report, err := s.ImportRecords(context.Background(), strings.NewReader(xml), dto.MARCImportOptions{Format: constants.MARCFormatXML, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Records) != 2 {
+		t.Fatalf("got %d records, want 2", len(report.Records))
+	}
+	if got := report.Records[0].Status; got != constants.MARCRecordValid {
+		t.Errorf("first status = %q, want %q", got, constants.MARCRecordValid)
+	}
+	if got := report.Records[1].Status; got != constants.MARCRecordDuplicate {
+		t.Errorf("second status = %q, want %q", got, constants.MARCRecordDuplicate)
+	}
+}
@@ -85,7 +85,7 @@ func (s *mediaService) DeleteMedia(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
-	books, err := s.bookService.GetBooks(ctx, 1, 9999, "", &media.ID)
+	books, err := s.bookService.GetBooks(ctx, 1, 9999, dto.BookFilter{CoverID: &media.ID})
 	if err != nil {
 		return err
 	}
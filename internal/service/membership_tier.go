@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type membershipTierService struct {
+	tierRepo domain.MembershipTierRepository
+}
+
+func NewMembershipTierService(tierRepo domain.MembershipTierRepository) domain.MembershipTierService {
+	return &membershipTierService{
+		tierRepo: tierRepo,
+	}
+}
+
+func (s *membershipTierService) GetTiers(ctx context.Context) ([]dto.MembershipTierResponse, error) {
+	tiers, err := s.tierRepo.FindAll(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.MembershipTierResponse, 0, len(tiers))
+	for _, tier := range tiers {
+		responses = append(responses, toMembershipTierResponse(&tier))
+	}
+
+	return responses, nil
+}
+
+func (s *membershipTierService) GetTierByID(ctx context.Context, id uuid.UUID) (*dto.MembershipTierResponse, error) {
+	tier, err := s.findTier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toMembershipTierResponse(tier)
+	return &response, nil
+}
+
+func (s *membershipTierService) CreateTier(ctx context.Context, req dto.MembershipTierRequest) (*dto.MembershipTierResponse, error) {
+	if _, err := s.tierRepo.FindByCode(ctx, req.Code); err == nil {
+		return nil, constants.ErrMembershipTierExists
+	}
+
+	tier := &domain.MembershipTier{ID: uuid.New()}
+	applyMembershipTier(tier, req)
+
+	if err := s.tierRepo.Create(ctx, tier); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toMembershipTierResponse(tier)
+	return &response, nil
+}
+
+// UpdateTier changes a tier's privileges. Its code can only change while no
+// customer is on the tier, since customers refer to it by code.
+func (s *membershipTierService) UpdateTier(ctx context.Context, id uuid.UUID, req dto.MembershipTierRequest) (*dto.MembershipTierResponse, error) {
+	tier, err := s.findTier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Code != tier.Code {
+		if _, err := s.tierRepo.FindByCode(ctx, req.Code); err == nil {
+			return nil, constants.ErrMembershipTierExists
+		}
+		if err := s.checkTierUnused(ctx, tier.Code); err != nil {
+			return nil, err
+		}
+	}
+
+	applyMembershipTier(tier, req)
+
+	if err := s.tierRepo.Update(ctx, tier); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toMembershipTierResponse(tier)
+	return &response, nil
+}
+
+func (s *membershipTierService) DeleteTier(ctx context.Context, id uuid.UUID) error {
+	tier, err := s.findTier(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkTierUnused(ctx, tier.Code); err != nil {
+		return err
+	}
+
+	if err := s.tierRepo.Delete(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *membershipTierService) checkTierUnused(ctx context.Context, code string) error {
+	customers, err := s.tierRepo.CountCustomers(ctx, code)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if customers > 0 {
+		return constants.ErrMembershipTierInUse
+	}
+	return nil
+}
+
+func (s *membershipTierService) findTier(ctx context.Context, id uuid.UUID) (*domain.MembershipTier, error) {
+	tier, err := s.tierRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrMembershipTierNotFound
+		}
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	return tier, nil
+}
+
+// customerTier loads the privileges of the customer's membership type.
+func customerTier(ctx context.Context, tierRepo domain.MembershipTierRepository, customer *domain.Customer) (*domain.MembershipTier, error) {
+	tier, err := tierRepo.FindByCode(ctx, customer.MembershipType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrMembershipTierNotFound
+		}
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	return tier, nil
+}
+
+func applyMembershipTier(tier *domain.MembershipTier, req dto.MembershipTierRequest) {
+	tier.Code = req.Code
+	tier.Name = req.Name
+	tier.LoanLimit = req.LoanLimit
+	tier.LoanPeriodDays = req.LoanPeriodDays
+	tier.RenewalLimit = req.RenewalLimit
+	tier.DailyFine = req.DailyFine
+	tier.HoldLimit = req.HoldLimit
+}
+
+func toMembershipTierResponse(tier *domain.MembershipTier) dto.MembershipTierResponse {
+	return dto.MembershipTierResponse{
+		ID:             tier.ID,
+		Code:           tier.Code,
+		Name:           tier.Name,
+		LoanLimit:      tier.LoanLimit,
+		LoanPeriodDays: tier.LoanPeriodDays,
+		RenewalLimit:   tier.RenewalLimit,
+		DailyFine:      tier.DailyFine,
+		HoldLimit:      tier.HoldLimit,
+		CreatedAt:      tier.CreatedAt,
+		UpdatedAt:      tier.UpdatedAt,
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/config"
+	"go-rest-api/internal/constants"
+	"log/slog"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type opdsService struct {
+	bookRepo domain.BookRepository
+	config   *config.Config
+}
+
+func NewOPDSService(bookRepo domain.BookRepository, config *config.Config) domain.OPDSService {
+	return &opdsService{
+		bookRepo: bookRepo,
+		config:   config,
+	}
+}
+
+func (s *opdsService) Root(baseURL string) *dto.OPDSFeed {
+	feed := newOPDSFeed(baseURL, "/opds", constants.OPDSCatalogTitle, constants.OPDSNavigationType)
+	feed.Entries = []dto.OPDSEntry{
+		navigationEntry(baseURL, "/opds/new", "new", "New arrivals", "Recently added books", constants.OPDSAcquisitionType),
+		navigationEntry(baseURL, "/opds/categories", "categories", "By category", "Browse books by category", constants.OPDSNavigationType),
+		navigationEntry(baseURL, "/opds/authors", "authors", "By author", "Browse books by author", constants.OPDSNavigationType),
+	}
+	feed.Entries[0].Links[0].Rel = constants.OPDSRelSortNew
+	return feed
+}
+
+// CoverFile only serves files that are the cover of a book still in the
+// catalog, so the public route cannot reach other uploads.
+func (s *opdsService) CoverFile(ctx context.Context, name string) (string, error) {
+	fileName := path.Base(name)
+	ok, err := s.bookRepo.HasCover(ctx, s.config.File.LinkCover+"/"+fileName)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return "", err
+	}
+	if !ok {
+		return "", constants.ErrMediaNotFound
+	}
+	return filepath.Join(s.config.File.UploadPath, fileName), nil
+}
+
+func (s *opdsService) NewArrivals(ctx context.Context, baseURL string, page int) (*dto.OPDSFeed, error) {
+	return s.acquisitionFeed(ctx, baseURL, "/opds/new", nil, "New arrivals", page, dto.BookFilter{Sort: constants.BookSortNewest})
+}
+
+func (s *opdsService) Categories(ctx context.Context, baseURL string) (*dto.OPDSFeed, error) {
+	return s.groupFeed(ctx, baseURL, "/opds/categories", "By category", constants.BookFacetCategory)
+}
+
+func (s *opdsService) BooksByCategory(ctx context.Context, baseURL, category string, page int) (*dto.OPDSFeed, error) {
+	path := "/opds/categories/" + url.PathEscape(category)
+	return s.acquisitionFeed(ctx, baseURL, path, nil, category, page, dto.BookFilter{Category: category, Sort: constants.BookSortTitle})
+}
+
+func (s *opdsService) Authors(ctx context.Context, baseURL string) (*dto.OPDSFeed, error) {
+	return s.groupFeed(ctx, baseURL, "/opds/authors", "By author", constants.BookFacetAuthor)
+}
+
+func (s *opdsService) BooksByAuthor(ctx context.Context, baseURL, author string, page int) (*dto.OPDSFeed, error) {
+	path := "/opds/authors/" + url.PathEscape(author)
+	return s.acquisitionFeed(ctx, baseURL, path, nil, author, page, dto.BookFilter{Author: author, Sort: constants.BookSortTitle})
+}
+
+func (s *opdsService) Search(ctx context.Context, baseURL, query string, page int) (*dto.OPDSFeed, error) {
+	params := url.Values{"q": {query}}
+	return s.acquisitionFeed(ctx, baseURL, "/opds/search", params, "Search: "+query, page, dto.BookFilter{Search: query, Sort: constants.BookSortTitle})
+}
+
+func (s *opdsService) Book(ctx context.Context, baseURL string, id uuid.UUID) (*dto.OPDSEntry, error) {
+	book, err := s.bookRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	entry := s.bookEntry(baseURL, book)
+	entry.Xmlns = constants.OPDSAtomNamespace
+	entry.XmlnsDC = constants.OPDSDublinCoreNS
+	return &entry, nil
+}
+
+func (s *opdsService) OpenSearchDescription(baseURL string) *dto.OpenSearchDescription {
+	return &dto.OpenSearchDescription{
+		Xmlns:          constants.OPDSOpenSearchNS,
+		ShortName:      constants.OPDSCatalogTitle,
+		Description:    "Search the library catalog by title or description",
+		InputEncoding:  "UTF-8",
+		OutputEncoding: "UTF-8",
+		URL: dto.OpenSearchURL{
+			Type:     constants.OPDSAcquisitionType,
+			Template: baseURL + "/opds/search?q={searchTerms}&page={startPage?}",
+		},
+	}
+}
+
+func (s *opdsService) groupFeed(ctx context.Context, baseURL, path, title, column string) (*dto.OPDSFeed, error) {
+	counts, err := s.bookRepo.CountBooksBy(ctx, column)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	feed := newOPDSFeed(baseURL, path, title, constants.OPDSNavigationType)
+	feed.Entries = make([]dto.OPDSEntry, 0, len(counts))
+	for _, count := range counts {
+		entry := navigationEntry(baseURL, path+"/"+url.PathEscape(count.Value), column+":"+count.Value, count.Value,
+			strconv.FormatInt(count.Count, 10)+" books", constants.OPDSAcquisitionType)
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed, nil
+}
+
+func (s *opdsService) acquisitionFeed(ctx context.Context, baseURL, path string, params url.Values, title string, page int, filter dto.BookFilter) (*dto.OPDSFeed, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	books, total, err := s.bookRepo.FindBooks(ctx, page, constants.OPDSPageSize, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	pageURL := func(page int) string {
+		values := url.Values{}
+		for key, value := range params {
+			values[key] = value
+		}
+		values.Set("page", strconv.Itoa(page))
+		return baseURL + path + "?" + values.Encode()
+	}
+
+	feed := newOPDSFeed(baseURL, path, title, constants.OPDSAcquisitionType)
+	feed.Links[0].Href = pageURL(page)
+	feed.TotalResults = total
+	feed.ItemsPerPage = constants.OPDSPageSize
+	if page > 1 {
+		feed.Links = append(feed.Links, dto.OPDSLink{Rel: constants.OPDSRelPrevious, Href: pageURL(page - 1), Type: constants.OPDSAcquisitionType})
+	}
+	if int64(page*constants.OPDSPageSize) < total {
+		feed.Links = append(feed.Links, dto.OPDSLink{Rel: constants.OPDSRelNext, Href: pageURL(page + 1), Type: constants.OPDSAcquisitionType})
+	}
+
+	feed.Entries = make([]dto.OPDSEntry, 0, len(books))
+	for _, book := range books {
+		feed.Entries = append(feed.Entries, s.bookEntry(baseURL, &book))
+	}
+
+	return feed, nil
+}
+
+func newOPDSFeed(baseURL, path, title, kind string) *dto.OPDSFeed {
+	return &dto.OPDSFeed{
+		Xmlns:     constants.OPDSAtomNamespace,
+		XmlnsDC:   constants.OPDSDublinCoreNS,
+		XmlnsOPDS: constants.OPDSNamespace,
+		XmlnsOS:   constants.OPDSOpenSearchNS,
+		ID:        constants.OPDSCatalogIDNamespace + ":" + strings.Trim(strings.ReplaceAll(path, "/", ":"), ":"),
+		Title:     title,
+		Updated:   time.Now().UTC().Format(time.RFC3339),
+		Author:    &dto.OPDSAuthor{Name: constants.OPDSCatalogTitle},
+		Links: []dto.OPDSLink{
+			{Rel: constants.OPDSRelSelf, Href: baseURL + path, Type: kind},
+			{Rel: constants.OPDSRelStart, Href: baseURL + "/opds", Type: constants.OPDSNavigationType},
+			{Rel: constants.OPDSRelSearch, Href: baseURL + "/opds/opensearch.xml", Type: constants.OPDSOpenSearchType},
+		},
+	}
+}
+
+func navigationEntry(baseURL, path, id, title, description, kind string) dto.OPDSEntry {
+	return dto.OPDSEntry{
+		ID:      constants.OPDSCatalogIDNamespace + ":" + id,
+		Title:   title,
+		Updated: time.Now().UTC().Format(time.RFC3339),
+		Content: &dto.OPDSContent{Type: "text", Value: description},
+		Links: []dto.OPDSLink{
+			{Rel: constants.OPDSRelSubsection, Href: baseURL + path, Type: kind},
+		},
+	}
+}
+
+func (s *opdsService) bookEntry(baseURL string, book *domain.Book) dto.OPDSEntry {
+	entry := dto.OPDSEntry{
+		ID:        "urn:uuid:" + book.ID.String(),
+		Title:     book.Title,
+		Updated:   book.UpdatedAt.UTC().Format(time.RFC3339),
+		Language:  book.Language,
+		Publisher: book.Publisher,
+		Summary:   book.Description,
+		Links: []dto.OPDSLink{
+			{Rel: "alternate", Href: baseURL + "/opds/books/" + book.ID.String(), Type: constants.OPDSEntryType},
+			{Rel: constants.OPDSRelBorrow, Href: baseURL + "/opds/books/" + book.ID.String(), Type: constants.OPDSEntryType},
+		},
+	}
+
+	if book.ISBN != "" {
+		entry.Identifier = "urn:isbn:" + book.ISBN
+	}
+
+	if book.PublicationYear > 0 {
+		entry.Issued = strconv.Itoa(book.PublicationYear)
+	}
+
+	for _, author := range splitList(book.Author) {
+		entry.Authors = append(entry.Authors, dto.OPDSAuthor{Name: author})
+	}
+
+	if book.Category != "" {
+		entry.Categories = append(entry.Categories, dto.OPDSCategory{Term: book.Category, Label: book.Category})
+	}
+	for _, subject := range splitList(book.Subjects) {
+		entry.Categories = append(entry.Categories, dto.OPDSCategory{Term: subject, Label: subject})
+	}
+
+	// Media.Path points at the authenticated media endpoint, which e-reader
+	// apps cannot use, so covers are linked through the public OPDS route.
+	if book.Cover != nil {
+		fileName := path.Base(strings.TrimPrefix(book.Cover.Path, s.config.File.LinkCover+"/"))
+		href := baseURL + "/opds/covers/" + url.PathEscape(fileName)
+		mimeType := coverMimeType(fileName)
+		entry.Links = append(entry.Links,
+			dto.OPDSLink{Rel: constants.OPDSRelImage, Href: href, Type: mimeType},
+			dto.OPDSLink{Rel: constants.OPDSRelThumbnail, Href: href, Type: mimeType},
+		)
+	}
+
+	return entry
+}
+
+func coverMimeType(path string) string {
+	switch {
+	case strings.HasSuffix(strings.ToLower(path), ".png"):
+		return "image/png"
+	case strings.HasSuffix(strings.ToLower(path), ".gif"):
+		return "image/gif"
+	default:
+		return "image/jpeg"
+	}
+}
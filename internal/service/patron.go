@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type patronService struct {
+	customerService        domain.CustomerService
+	bookTransactionService domain.BookTransactionService
+	holdService            domain.HoldService
+	reviewService          domain.ReviewService
+	bookTransactionRepo    domain.BookTransactionRepository
+	holdRepo               domain.HoldRepository
+	chargeRepo             domain.ChargeRepository
+	reviewRepo             domain.ReviewRepository
+}
+
+func NewPatronService(
+	customerService domain.CustomerService,
+	bookTransactionService domain.BookTransactionService,
+	holdService domain.HoldService,
+	reviewService domain.ReviewService,
+	bookTransactionRepo domain.BookTransactionRepository,
+	holdRepo domain.HoldRepository,
+	chargeRepo domain.ChargeRepository,
+	reviewRepo domain.ReviewRepository,
+) domain.PatronService {
+	return &patronService{
+		customerService:        customerService,
+		bookTransactionService: bookTransactionService,
+		holdService:            holdService,
+		reviewService:          reviewService,
+		bookTransactionRepo:    bookTransactionRepo,
+		holdRepo:               holdRepo,
+		chargeRepo:             chargeRepo,
+		reviewRepo:             reviewRepo,
+	}
+}
+
+func (s *patronService) GetProfile(ctx context.Context, customerID uuid.UUID) (*dto.CustomerResponse, error) {
+	customer, err := s.customerService.GetCustomerByID(customerID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrCustomerNotFound
+	}
+	return customer, nil
+}
+
+func (s *patronService) GetLoans(ctx context.Context, customerID uuid.UUID, status string) ([]dto.BookTransactionResponse, error) {
+	filter := map[string]interface{}{"book_transactions.customer_id": customerID}
+	if status != "" {
+		filter["book_transactions.status"] = status
+	}
+	return s.bookTransactionService.GetAllBookTransactions(filter)
+}
+
+func (s *patronService) RenewLoan(ctx context.Context, customerID uuid.UUID, loanID uuid.UUID) (*dto.BookTransactionResponse, error) {
+	loan, err := s.bookTransactionRepo.FindByID(loanID)
+	if err != nil || loan.CustomerID != customerID {
+		return nil, constants.ErrBookTransactionNotFound
+	}
+	return s.bookTransactionService.RenewBookTransaction(ctx, loanID)
+}
+
+func (s *patronService) GetCharges(ctx context.Context, customerID uuid.UUID) ([]dto.ChargeResponse, error) {
+	charges, err := s.chargeRepo.FindByCustomerID(customerID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.ChargeResponse, 0, len(charges))
+	for _, charge := range charges {
+		responses = append(responses, toChargeResponse(&charge))
+	}
+	return responses, nil
+}
+
+func (s *patronService) GetHolds(ctx context.Context, customerID uuid.UUID) ([]dto.HoldResponse, error) {
+	return s.holdService.GetHolds(ctx, dto.HoldFilter{CustomerID: &customerID})
+}
+
+func (s *patronService) PlaceHold(ctx context.Context, customerID uuid.UUID, req dto.PatronHoldRequest) (*dto.HoldResponse, error) {
+	return s.holdService.PlaceHold(ctx, dto.HoldCreateRequest{
+		CustomerID:     customerID,
+		BookID:         req.BookID,
+		WorkID:         req.WorkID,
+		PickupBranchID: req.PickupBranchID,
+	})
+}
+
+func (s *patronService) CancelHold(ctx context.Context, customerID uuid.UUID, holdID uuid.UUID) (*dto.HoldResponse, error) {
+	hold, err := s.holdRepo.FindByID(ctx, holdID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrHoldNotFound
+		}
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	if hold.CustomerID != customerID {
+		return nil, constants.ErrHoldNotFound
+	}
+	return s.holdService.CancelHold(ctx, holdID)
+}
+
+func (s *patronService) GetReviews(ctx context.Context, customerID uuid.UUID) ([]dto.ReviewResponse, error) {
+	return s.reviewService.GetReviews(ctx, dto.ReviewFilter{CustomerID: &customerID})
+}
+
+func (s *patronService) CreateReview(ctx context.Context, customerID uuid.UUID, req dto.PatronReviewRequest) (*dto.ReviewResponse, error) {
+	return s.reviewService.CreateReview(ctx, dto.ReviewCreateRequest{
+		BookID:     req.BookID,
+		CustomerID: customerID,
+		Rating:     req.Rating,
+		Body:       req.Body,
+	})
+}
+
+func (s *patronService) UpdateReview(ctx context.Context, customerID uuid.UUID, reviewID uuid.UUID, req dto.ReviewUpdateRequest) (*dto.ReviewResponse, error) {
+	if err := s.checkReviewOwner(ctx, customerID, reviewID); err != nil {
+		return nil, err
+	}
+	return s.reviewService.UpdateReview(ctx, reviewID, req)
+}
+
+func (s *patronService) DeleteReview(ctx context.Context, customerID uuid.UUID, reviewID uuid.UUID) error {
+	if err := s.checkReviewOwner(ctx, customerID, reviewID); err != nil {
+		return err
+	}
+	return s.reviewService.DeleteReview(ctx, reviewID)
+}
+
+// checkReviewOwner reports another customer's review as not found.
+func (s *patronService) checkReviewOwner(ctx context.Context, customerID uuid.UUID, reviewID uuid.UUID) error {
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constants.ErrReviewNotFound
+		}
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if review.CustomerID != customerID {
+		return constants.ErrReviewNotFound
+	}
+	return nil
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type readingListService struct {
+	readingListRepo domain.ReadingListRepository
+	bookRepo        domain.BookRepository
+	mediaRepo       domain.MediaRepository
+	customerRepo    domain.CustomerRepository
+}
+
+func NewReadingListService(
+	readingListRepo domain.ReadingListRepository,
+	bookRepo domain.BookRepository,
+	mediaRepo domain.MediaRepository,
+	customerRepo domain.CustomerRepository,
+) domain.ReadingListService {
+	return &readingListService{
+		readingListRepo: readingListRepo,
+		bookRepo:        bookRepo,
+		mediaRepo:       mediaRepo,
+		customerRepo:    customerRepo,
+	}
+}
+
+func (s *readingListService) GetReadingLists(ctx context.Context, filter dto.ReadingListFilter) ([]dto.ReadingListResponse, error) {
+	lists, err := s.readingListRepo.FindAll(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return toReadingListResponses(lists), nil
+}
+
+func (s *readingListService) GetPublishedReadingLists(ctx context.Context) ([]dto.ReadingListResponse, error) {
+	lists, err := s.readingListRepo.FindPublished(ctx, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return toReadingListResponses(lists), nil
+}
+
+func (s *readingListService) GetReadingListByID(ctx context.Context, id uuid.UUID) (*dto.ReadingListResponse, error) {
+	list, err := s.readingListRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toReadingListResponse(list)
+	return &response, nil
+}
+
+// GetPublishedReadingListByID hides lists that are not public or outside
+// their publish window, as if they did not exist.
+func (s *readingListService) GetPublishedReadingListByID(ctx context.Context, id uuid.UUID) (*dto.ReadingListResponse, error) {
+	list, err := s.readingListRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	now := time.Now()
+	published := list.Visibility == constants.ReadingListVisibilityPublic &&
+		(list.PublishFrom == nil || !list.PublishFrom.After(now)) &&
+		(list.PublishUntil == nil || list.PublishUntil.After(now))
+	if !published {
+		return nil, constants.ErrReadingListNotFound
+	}
+
+	response := toReadingListResponse(list)
+	return &response, nil
+}
+
+func (s *readingListService) CreateReadingList(ctx context.Context, req dto.ReadingListCreateRequest) (*dto.ReadingListResponse, error) {
+	list := &domain.ReadingList{
+		Title:        req.Title,
+		Description:  req.Description,
+		Kind:         req.Kind,
+		Visibility:   req.Visibility,
+		PublishFrom:  req.PublishFrom,
+		PublishUntil: req.PublishUntil,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	if req.Kind == constants.ReadingListKindWishlist {
+		if _, err := s.customerRepo.FindByID(*req.CustomerID); err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, constants.ErrCustomerNotFound
+		}
+		list.CustomerID = req.CustomerID
+	}
+
+	if err := s.applyCover(ctx, list, req.CoverID); err != nil {
+		return nil, err
+	}
+
+	if err := validatePublishRange(list); err != nil {
+		return nil, err
+	}
+
+	if err := s.readingListRepo.Create(ctx, list); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toReadingListResponse(list)
+	return &response, nil
+}
+
+func (s *readingListService) UpdateReadingList(ctx context.Context, id uuid.UUID, req dto.ReadingListUpdateRequest) (*dto.ReadingListResponse, error) {
+	list, err := s.readingListRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if req.Title != "" {
+		list.Title = req.Title
+	}
+
+	if req.Description != "" {
+		list.Description = req.Description
+	}
+
+	if req.Visibility != "" {
+		list.Visibility = req.Visibility
+	}
+
+	if req.PublishFrom != nil {
+		list.PublishFrom = req.PublishFrom
+	}
+
+	if req.PublishUntil != nil {
+		list.PublishUntil = req.PublishUntil
+	}
+
+	if err := s.applyCover(ctx, list, req.CoverID); err != nil {
+		return nil, err
+	}
+
+	if err := validatePublishRange(list); err != nil {
+		return nil, err
+	}
+
+	list.UpdatedAt = time.Now()
+
+	if err := s.readingListRepo.Update(ctx, list); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toReadingListResponse(list)
+	return &response, nil
+}
+
+func (s *readingListService) DeleteReadingList(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.readingListRepo.FindByID(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	return s.readingListRepo.Delete(ctx, id)
+}
+
+func (s *readingListService) AddItem(ctx context.Context, id uuid.UUID, req dto.ReadingListItemRequest) (*dto.ReadingListResponse, error) {
+	list, err := s.readingListRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if _, err := s.bookRepo.FindByID(ctx, req.BookID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBookNotFound
+	}
+
+	if findReadingListItem(list, req.BookID) != nil {
+		return nil, constants.ErrReadingListItemExists
+	}
+
+	item := &domain.ReadingListItem{
+		ReadingListID: id,
+		BookID:        req.BookID,
+		Position:      req.Position,
+		Note:          req.Note,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	if err := s.readingListRepo.AddItem(ctx, item); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.touch(ctx, list)
+}
+
+func (s *readingListService) UpdateItem(ctx context.Context, id, bookID uuid.UUID, req dto.ReadingListItemUpdateRequest) (*dto.ReadingListResponse, error) {
+	list, err := s.readingListRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	item := findReadingListItem(list, bookID)
+	if item == nil {
+		return nil, constants.ErrReadingListItemNotFound
+	}
+
+	item.Note = req.Note
+	item.UpdatedAt = time.Now()
+
+	if err := s.readingListRepo.UpdateItem(ctx, item); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.touch(ctx, list)
+}
+
+func (s *readingListService) RemoveItem(ctx context.Context, id, bookID uuid.UUID) (*dto.ReadingListResponse, error) {
+	list, err := s.readingListRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if err := s.readingListRepo.RemoveItem(ctx, id, bookID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrReadingListItemNotFound
+		}
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.touch(ctx, list)
+}
+
+// Reorder requires every book shown on the list so that no item is left with
+// a stale position. Entries for trashed books keep their relative order after
+// the shown ones.
+func (s *readingListService) Reorder(ctx context.Context, id uuid.UUID, req dto.ReadingListReorderRequest) (*dto.ReadingListResponse, error) {
+	list, err := s.readingListRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	order := make([]uuid.UUID, 0, len(list.Items))
+	var hidden []uuid.UUID
+	for _, item := range list.Items {
+		if item.Book.ID == uuid.Nil {
+			hidden = append(hidden, item.BookID)
+		}
+	}
+	if len(req.BookIDs) != len(list.Items)-len(hidden) {
+		return nil, constants.ErrReadingListOrder
+	}
+	seen := make(map[uuid.UUID]bool, len(req.BookIDs))
+	for _, bookID := range req.BookIDs {
+		item := findReadingListItem(list, bookID)
+		if seen[bookID] || item == nil || item.Book.ID == uuid.Nil {
+			return nil, constants.ErrReadingListOrder
+		}
+		seen[bookID] = true
+	}
+	order = append(append(order, req.BookIDs...), hidden...)
+
+	if err := s.readingListRepo.Reorder(ctx, id, order); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.touch(ctx, list)
+}
+
+// touch bumps the list's update time after an item change and returns the
+// list as now stored.
+func (s *readingListService) touch(ctx context.Context, list *domain.ReadingList) (*dto.ReadingListResponse, error) {
+	list.UpdatedAt = time.Now()
+	if err := s.readingListRepo.Update(ctx, list); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.GetReadingListByID(ctx, list.ID)
+}
+
+func (s *readingListService) applyCover(ctx context.Context, list *domain.ReadingList, coverID *uuid.UUID) error {
+	if coverID == nil {
+		return nil
+	}
+
+	media, err := s.mediaRepo.FindByID(*coverID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return errors.New("invalid cover ID: media not found")
+	}
+
+	list.CoverID = &media.ID
+	list.Cover = media
+	return nil
+}
+
+func validatePublishRange(list *domain.ReadingList) error {
+	if list.PublishFrom != nil && list.PublishUntil != nil && !list.PublishUntil.After(*list.PublishFrom) {
+		return constants.ErrReadingListPublishRange
+	}
+	return nil
+}
+
+func findReadingListItem(list *domain.ReadingList, bookID uuid.UUID) *domain.ReadingListItem {
+	for i := range list.Items {
+		if list.Items[i].BookID == bookID {
+			return &list.Items[i]
+		}
+	}
+	return nil
+}
+
+func toReadingListResponses(lists []domain.ReadingList) []dto.ReadingListResponse {
+	responses := make([]dto.ReadingListResponse, 0, len(lists))
+	for _, list := range lists {
+		response := toReadingListResponse(&list)
+		response.Items = nil
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func toReadingListResponse(list *domain.ReadingList) dto.ReadingListResponse {
+	response := dto.ReadingListResponse{
+		ID:           list.ID,
+		Title:        list.Title,
+		Description:  list.Description,
+		Kind:         list.Kind,
+		Visibility:   list.Visibility,
+		CustomerID:   list.CustomerID,
+		PublishFrom:  list.PublishFrom,
+		PublishUntil: list.PublishUntil,
+		CreatedAt:    list.CreatedAt,
+		UpdatedAt:    list.UpdatedAt,
+	}
+
+	if list.Cover != nil {
+		response.Cover = &dto.MediaResponse{
+			ID:        list.Cover.ID,
+			Path:      list.Cover.Path,
+			CreatedAt: list.Cover.CreatedAt,
+		}
+	}
+
+	for _, item := range list.Items {
+		// Books moved to the trash keep their list entry but are not shown.
+		if item.Book.ID == uuid.Nil {
+			continue
+		}
+		response.Items = append(response.Items, dto.ReadingListItemResponse{
+			Position: item.Position,
+			Note:     item.Note,
+			Book:     toBookSummary(&item.Book),
+		})
+	}
+	response.ItemCount = len(response.Items)
+
+	return response
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/config"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type recommendationService struct {
+	recommendationRepo domain.RecommendationRepository
+	bookRepo           domain.BookRepository
+	customerRepo       domain.CustomerRepository
+	config             *config.Config
+}
+
+func NewRecommendationService(
+	recommendationRepo domain.RecommendationRepository,
+	bookRepo domain.BookRepository,
+	customerRepo domain.CustomerRepository,
+	config *config.Config,
+) domain.RecommendationService {
+	return &recommendationService{
+		recommendationRepo: recommendationRepo,
+		bookRepo:           bookRepo,
+		customerRepo:       customerRepo,
+		config:             config,
+	}
+}
+
+// Rebuild recomputes all recommendations from the loan history. It is run by
+// the periodic job and can be triggered by an admin.
+func (s *recommendationService) Rebuild(ctx context.Context) (*dto.RecommendationRunReport, error) {
+	started := time.Now()
+
+	report, err := s.recommendationRepo.Rebuild(ctx, s.config.Recommendation.MinCoBorrow, s.config.Recommendation.Limit, started)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	report.Duration = time.Since(started).String()
+	return report, nil
+}
+
+func (s *recommendationService) GetBookRecommendations(ctx context.Context, bookID uuid.UUID) ([]dto.RecommendationResponse, error) {
+	if _, err := s.bookRepo.FindByID(ctx, bookID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	recommendations, err := s.recommendationRepo.FindForBook(ctx, bookID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.RecommendationResponse, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		// Books trashed since the last run are skipped until it runs again.
+		if recommendation.RecommendedBook.ID == uuid.Nil {
+			continue
+		}
+		responses = append(responses, dto.RecommendationResponse{
+			Book:       toBookSummary(&recommendation.RecommendedBook),
+			Score:      recommendation.Score,
+			ComputedAt: recommendation.ComputedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+func (s *recommendationService) GetCustomerRecommendations(ctx context.Context, customerID uuid.UUID) ([]dto.RecommendationResponse, error) {
+	if _, err := s.customerRepo.FindByID(customerID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	recommendations, err := s.recommendationRepo.FindForCustomer(ctx, customerID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.RecommendationResponse, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		if recommendation.Book.ID == uuid.Nil {
+			continue
+		}
+		responses = append(responses, dto.RecommendationResponse{
+			Book:       toBookSummary(&recommendation.Book),
+			Score:      recommendation.Score,
+			ComputedAt: recommendation.ComputedAt,
+		})
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type reviewService struct {
+	reviewRepo   domain.ReviewRepository
+	bookRepo     domain.BookRepository
+	customerRepo domain.CustomerRepository
+}
+
+func NewReviewService(reviewRepo domain.ReviewRepository, bookRepo domain.BookRepository, customerRepo domain.CustomerRepository) domain.ReviewService {
+	return &reviewService{
+		reviewRepo:   reviewRepo,
+		bookRepo:     bookRepo,
+		customerRepo: customerRepo,
+	}
+}
+
+func (s *reviewService) GetReviews(ctx context.Context, filter dto.ReviewFilter) ([]dto.ReviewResponse, error) {
+	reviews, err := s.reviewRepo.Find(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	reviewResponses := make([]dto.ReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		reviewResponses = append(reviewResponses, toReviewResponse(&review))
+	}
+
+	return reviewResponses, nil
+}
+
+func (s *reviewService) GetReviewByID(ctx context.Context, id uuid.UUID) (*dto.ReviewResponse, error) {
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toReviewResponse(review)
+	return &response, nil
+}
+
+// CreateReview accepts a review only from a customer with a completed loan
+// of the book. New reviews wait in the moderation queue.
+func (s *reviewService) CreateReview(ctx context.Context, req dto.ReviewCreateRequest) (*dto.ReviewResponse, error) {
+	if _, err := s.bookRepo.FindByID(ctx, req.BookID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBookNotFound
+	}
+
+	if _, err := s.customerRepo.FindByID(req.CustomerID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrCustomerNotFound
+	}
+
+	eligible, err := s.reviewRepo.HasCompletedLoan(ctx, req.BookID, req.CustomerID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	if !eligible {
+		return nil, constants.ErrReviewNotEligible
+	}
+
+	exists, err := s.reviewRepo.ExistsForBook(ctx, req.BookID, req.CustomerID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	if exists {
+		return nil, constants.ErrReviewExists
+	}
+
+	review := &domain.Review{
+		BookID:     req.BookID,
+		CustomerID: req.CustomerID,
+		Rating:     req.Rating,
+		Body:       req.Body,
+		Status:     constants.ReviewStatusPending,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toReviewResponse(review)
+	return &response, nil
+}
+
+// UpdateReview sends an edited review back to the moderation queue, taking
+// it out of the book rating until it is approved again.
+func (s *reviewService) UpdateReview(ctx context.Context, id uuid.UUID, req dto.ReviewUpdateRequest) (*dto.ReviewResponse, error) {
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if req.Rating != 0 {
+		review.Rating = req.Rating
+	}
+
+	if req.Body != "" {
+		review.Body = req.Body
+	}
+
+	wasApproved := review.Status == constants.ReviewStatusApproved
+	review.Status = constants.ReviewStatusPending
+	review.ModerationNote = ""
+	review.ModeratedBy = nil
+	review.ModeratedAt = nil
+	review.UpdatedAt = time.Now()
+
+	if err := s.reviewRepo.Update(ctx, review); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if wasApproved {
+		if err := s.reviewRepo.RefreshBookRating(ctx, review.BookID); err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, err
+		}
+	}
+
+	response := toReviewResponse(review)
+	return &response, nil
+}
+
+func (s *reviewService) ModerateReview(ctx context.Context, id, moderatorID uuid.UUID, req dto.ReviewModerationRequest) (*dto.ReviewResponse, error) {
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	now := time.Now()
+	review.Status = req.Status
+	review.ModerationNote = req.Note
+	review.ModeratedBy = &moderatorID
+	review.ModeratedAt = &now
+	review.UpdatedAt = now
+
+	if err := s.reviewRepo.Update(ctx, review); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if err := s.reviewRepo.RefreshBookRating(ctx, review.BookID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toReviewResponse(review)
+	return &response, nil
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, id uuid.UUID) error {
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	if err := s.reviewRepo.Delete(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	return s.reviewRepo.RefreshBookRating(ctx, review.BookID)
+}
+
+func toReviewResponse(review *domain.Review) dto.ReviewResponse {
+	return dto.ReviewResponse{
+		ID:             review.ID,
+		BookID:         review.BookID,
+		CustomerID:     review.CustomerID,
+		CustomerName:   review.Customer.Name,
+		Rating:         review.Rating,
+		Body:           review.Body,
+		Status:         review.Status,
+		ModerationNote: review.ModerationNote,
+		ModeratedBy:    review.ModeratedBy,
+		ModeratedAt:    review.ModeratedAt,
+		CreatedAt:      review.CreatedAt,
+		UpdatedAt:      review.UpdatedAt,
+	}
+}
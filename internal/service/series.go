@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type seriesService struct {
+	seriesRepo domain.SeriesRepository
+}
+
+func NewSeriesService(seriesRepo domain.SeriesRepository) domain.SeriesService {
+	return &seriesService{
+		seriesRepo: seriesRepo,
+	}
+}
+
+func (s *seriesService) GetSeries(ctx context.Context, search string) ([]dto.SeriesResponse, error) {
+	series, err := s.seriesRepo.FindAll(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	seriesResponses := make([]dto.SeriesResponse, 0, len(series))
+	for _, item := range series {
+		seriesResponses = append(seriesResponses, toSeriesResponse(&item))
+	}
+
+	return seriesResponses, nil
+}
+
+func (s *seriesService) GetSeriesByID(ctx context.Context, id uuid.UUID) (*dto.SeriesResponse, error) {
+	series, err := s.seriesRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toSeriesResponse(series)
+	return &response, nil
+}
+
+func (s *seriesService) CreateSeries(ctx context.Context, req dto.SeriesCreateRequest) (*dto.SeriesResponse, error) {
+	series := &domain.Series{
+		Title:       req.Title,
+		Description: req.Description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.seriesRepo.Create(ctx, series); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toSeriesResponse(series)
+	return &response, nil
+}
+
+func (s *seriesService) UpdateSeries(ctx context.Context, id uuid.UUID, req dto.SeriesUpdateRequest) (*dto.SeriesResponse, error) {
+	series, err := s.seriesRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if req.Title != "" {
+		series.Title = req.Title
+	}
+
+	if req.Description != "" {
+		series.Description = req.Description
+	}
+
+	series.UpdatedAt = time.Now()
+
+	if err := s.seriesRepo.Update(ctx, series); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toSeriesResponse(series)
+	return &response, nil
+}
+
+func (s *seriesService) DeleteSeries(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.seriesRepo.FindByID(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	return s.seriesRepo.Delete(ctx, id)
+}
+
+func toSeriesResponse(series *domain.Series) dto.SeriesResponse {
+	response := dto.SeriesResponse{
+		ID:          series.ID,
+		Title:       series.Title,
+		Description: series.Description,
+		CreatedAt:   series.CreatedAt,
+		UpdatedAt:   series.UpdatedAt,
+	}
+
+	for _, book := range series.Books {
+		response.Books = append(response.Books, toBookSummary(&book))
+	}
+
+	return response
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/internal/config"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/stockcode"
+)
+
+// stockCodeGenerator hands out copy codes that are not in use yet.
+type stockCodeGenerator struct {
+	stockCodeRepo domain.StockCodeRepository
+	bookstockRepo domain.BookstockRepository
+	config        *config.Config
+}
+
+// Generate returns count new codes for branch, or for the configured default
+// branch when branch is empty. Numbers that collide with hand-entered codes
+// are skipped.
+func (g *stockCodeGenerator) Generate(ctx context.Context, branch string, count int) ([]string, error) {
+	if branch == "" {
+		branch = g.config.StockCode.Branch
+	}
+	if !stockcode.ValidPart(branch) {
+		return nil, constants.ErrStockCodeBranch
+	}
+	prefix := g.config.StockCode.Prefix
+
+	codes := make([]string, 0, count)
+	for len(codes) < count {
+		need := count - len(codes)
+		first, err := g.stockCodeRepo.Reserve(ctx, stockcode.Scope(prefix, branch), need)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]string, 0, need)
+		for i := 0; i < need; i++ {
+			candidates = append(candidates, stockcode.Format(prefix, branch, first+int64(i), g.config.StockCode.Digits))
+		}
+
+		existing, err := g.bookstockRepo.FindByCodes(candidates)
+		if err != nil {
+			return nil, err
+		}
+		taken := make(map[string]bool, len(existing))
+		for _, stock := range existing {
+			taken[stock.Code] = true
+		}
+
+		for _, code := range candidates {
+			if !taken[code] {
+				codes = append(codes, code)
+			}
+		}
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/config"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/stockcode"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type stocktakeService struct {
+	stocktakeRepo domain.StocktakeRepository
+	branchRepo    domain.BranchRepository
+	config        *config.Config
+}
+
+func NewStocktakeService(stocktakeRepo domain.StocktakeRepository, branchRepo domain.BranchRepository, config *config.Config) domain.StocktakeService {
+	return &stocktakeService{
+		stocktakeRepo: stocktakeRepo,
+		branchRepo:    branchRepo,
+		config:        config,
+	}
+}
+
+func (s *stocktakeService) GetStocktakes(ctx context.Context) ([]dto.StocktakeResponse, error) {
+	stocktakes, err := s.stocktakeRepo.FindAll(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.StocktakeResponse, 0, len(stocktakes))
+	for _, stocktake := range stocktakes {
+		responses = append(responses, toStocktakeResponse(&stocktake))
+	}
+
+	return responses, nil
+}
+
+func (s *stocktakeService) GetStocktakeByID(ctx context.Context, id uuid.UUID) (*dto.StocktakeResponse, error) {
+	stocktake, err := s.findStocktake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toStocktakeResponse(stocktake)
+	return &response, nil
+}
+
+func (s *stocktakeService) OpenStocktake(ctx context.Context, openedBy uuid.UUID, req dto.StocktakeCreateRequest) (*dto.StocktakeResponse, error) {
+	if req.CodeFrom > req.CodeTo {
+		return nil, errors.New("code_from must not be after code_to")
+	}
+
+	branchID, _, err := resolveLocation(ctx, s.branchRepo, req.BranchID, req.ShelfID)
+	if err != nil {
+		return nil, err
+	}
+
+	stocktake := &domain.Stocktake{
+		ID:       uuid.New(),
+		Name:     req.Name,
+		BranchID: branchID,
+		ShelfID:  req.ShelfID,
+		Prefix:   req.Prefix,
+		CodeFrom: req.CodeFrom,
+		CodeTo:   req.CodeTo,
+		Status:   constants.StocktakeStatusOpen,
+		OpenedBy: &openedBy,
+	}
+
+	if err := s.stocktakeRepo.Create(ctx, stocktake); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toStocktakeResponse(stocktake)
+	return &response, nil
+}
+
+// Scan records a batch of codes. Blank lines and repeats are ignored so
+// scanner output can be posted as is.
+func (s *stocktakeService) Scan(ctx context.Context, id uuid.UUID, codes []string) (*dto.StocktakeScanResult, error) {
+	stocktake, err := s.findStocktake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stocktake.Status != constants.StocktakeStatusOpen {
+		return nil, constants.ErrStocktakeNotOpen
+	}
+
+	// Mistyped codes are handed back to be rescanned rather than recorded as
+	// unknown copies.
+	result := &dto.StocktakeScanResult{Received: len(codes)}
+	unique := make([]string, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		code = strings.TrimSpace(code)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		if stockcode.Mistyped(s.config.StockCode.Prefix, code) {
+			result.Rejected = append(result.Rejected, code)
+			continue
+		}
+		unique = append(unique, code)
+	}
+
+	if len(unique) > 0 {
+		if result.Added, err = s.stocktakeRepo.AddScans(ctx, id, unique, time.Now()); err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, err
+		}
+	}
+
+	if result.Scanned, err = s.stocktakeRepo.CountScans(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *stocktakeService) CloseStocktake(ctx context.Context, id uuid.UUID) (*dto.StocktakeReport, error) {
+	stocktake, err := s.findStocktake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stocktake.Status != constants.StocktakeStatusOpen {
+		return nil, constants.ErrStocktakeNotOpen
+	}
+
+	now := time.Now()
+	stocktake.Status = constants.StocktakeStatusClosed
+	stocktake.ClosedAt = &now
+	if err := s.stocktakeRepo.Close(ctx, stocktake); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.buildReport(ctx, stocktake)
+}
+
+func (s *stocktakeService) GetReport(ctx context.Context, id uuid.UUID) (*dto.StocktakeReport, error) {
+	stocktake, err := s.findStocktake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildReport(ctx, stocktake)
+}
+
+// ApproveStocktake marks the copies reported missing at close as LOST and
+// logs the change against the session. A copy whose status has changed since
+// the close, e.g. one returned from loan, is left alone.
+func (s *stocktakeService) ApproveStocktake(ctx context.Context, id uuid.UUID, approvedBy uuid.UUID) (*dto.StocktakeReport, error) {
+	stocktake, err := s.findStocktake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stocktake.Status != constants.StocktakeStatusClosed {
+		return nil, constants.ErrStocktakeNotClosed
+	}
+
+	missing, err := s.stocktakeRepo.FindMissingSnapshot(ctx, stocktake.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	now := time.Now()
+	logs := make([]domain.BookStockStatusLog, 0, len(missing))
+	for _, item := range missing {
+		logs = append(logs, domain.BookStockStatusLog{
+			ID:         uuid.New(),
+			StockCode:  item.Code,
+			FromStatus: item.Status,
+			ToStatus:   constants.BookStockStatusLost,
+			Reason:     "Not found in stocktake " + stocktake.Name,
+			ChangedBy:  &approvedBy,
+			CreatedAt:  now,
+		})
+	}
+
+	report, err := s.buildReport(ctx, stocktake)
+	if err != nil {
+		return nil, err
+	}
+
+	stocktake.Status = constants.StocktakeStatusApproved
+	stocktake.ApprovedBy = &approvedBy
+	stocktake.ApprovedAt = &now
+	if err := s.stocktakeRepo.Approve(ctx, stocktake, logs, requeueHoldOnChange(now)); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	report.Stocktake = toStocktakeResponse(stocktake)
+	return report, nil
+}
+
+func (s *stocktakeService) findStocktake(ctx context.Context, id uuid.UUID) (*domain.Stocktake, error) {
+	stocktake, err := s.stocktakeRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrStocktakeNotFound
+		}
+		return nil, err
+	}
+	return stocktake, nil
+}
+
+func (s *stocktakeService) buildReport(ctx context.Context, stocktake *domain.Stocktake) (*dto.StocktakeReport, error) {
+	report := &dto.StocktakeReport{Stocktake: toStocktakeResponse(stocktake)}
+
+	var err error
+	if report.Expected, err = s.stocktakeRepo.CountExpected(ctx, stocktake); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	if report.Scanned, err = s.stocktakeRepo.CountScans(ctx, stocktake.ID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	// Once closed, the report shows the missing copies approval will act on.
+	if stocktake.Status == constants.StocktakeStatusOpen {
+		missing, err := s.stocktakeRepo.FindMissing(ctx, stocktake)
+		if err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, err
+		}
+		report.Missing = toStocktakeReportItems(missing)
+	} else {
+		missing, err := s.stocktakeRepo.FindMissingSnapshot(ctx, stocktake.ID)
+		if err != nil {
+			slog.ErrorContext(ctx, err.Error())
+			return nil, err
+		}
+		report.Missing = make([]dto.StocktakeReportItem, 0, len(missing))
+		for _, item := range missing {
+			report.Missing = append(report.Missing, dto.StocktakeReportItem{
+				Code:   item.Code,
+				BookID: item.BookStock.BookID,
+				Title:  item.BookStock.Book.Title,
+				Status: item.Status,
+			})
+		}
+	}
+
+	flagged, err := s.stocktakeRepo.FindScannedWithStatus(ctx, stocktake.ID,
+		[]string{constants.BookStockStatusBorrowed, constants.BookStockStatusLost})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	report.Borrowed = make([]dto.StocktakeReportItem, 0)
+	report.Lost = make([]dto.StocktakeReportItem, 0)
+	for _, item := range toStocktakeReportItems(flagged) {
+		if item.Status == constants.BookStockStatusBorrowed {
+			report.Borrowed = append(report.Borrowed, item)
+		} else {
+			report.Lost = append(report.Lost, item)
+		}
+	}
+
+	if report.Unknown, err = s.stocktakeRepo.FindUnknownScans(ctx, stocktake.ID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	if report.OutOfScope, err = s.stocktakeRepo.FindOutOfScopeScans(ctx, stocktake); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func toStocktakeReportItems(bookstocks []domain.BookStock) []dto.StocktakeReportItem {
+	items := make([]dto.StocktakeReportItem, 0, len(bookstocks))
+	for _, stock := range bookstocks {
+		items = append(items, dto.StocktakeReportItem{
+			Code:   stock.Code,
+			BookID: stock.BookID,
+			Title:  stock.Book.Title,
+			Status: stock.Status,
+		})
+	}
+	return items
+}
+
+func toStocktakeResponse(stocktake *domain.Stocktake) dto.StocktakeResponse {
+	return dto.StocktakeResponse{
+		ID:         stocktake.ID,
+		Name:       stocktake.Name,
+		BranchID:   stocktake.BranchID,
+		ShelfID:    stocktake.ShelfID,
+		Prefix:     stocktake.Prefix,
+		CodeFrom:   stocktake.CodeFrom,
+		CodeTo:     stocktake.CodeTo,
+		Status:     stocktake.Status,
+		OpenedBy:   stocktake.OpenedBy,
+		ApprovedBy: stocktake.ApprovedBy,
+		ClosedAt:   stocktake.ClosedAt,
+		ApprovedAt: stocktake.ApprovedAt,
+		CreatedAt:  stocktake.CreatedAt,
+		UpdatedAt:  stocktake.UpdatedAt,
+	}
+}
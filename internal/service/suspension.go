@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type suspensionService struct {
+	suspensionRepo domain.SuspensionRepository
+	customerRepo   domain.CustomerRepository
+}
+
+func NewSuspensionService(suspensionRepo domain.SuspensionRepository, customerRepo domain.CustomerRepository) domain.SuspensionService {
+	return &suspensionService{
+		suspensionRepo: suspensionRepo,
+		customerRepo:   customerRepo,
+	}
+}
+
+func (s *suspensionService) GetSuspensions(ctx context.Context, customerID uuid.UUID) ([]dto.SuspensionResponse, error) {
+	if _, err := s.customerRepo.FindByID(customerID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrCustomerNotFound
+	}
+
+	suspensions, err := s.suspensionRepo.FindByCustomer(ctx, customerID)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return toSuspensionResponses(suspensions, time.Now()), nil
+}
+
+func (s *suspensionService) Suspend(ctx context.Context, customerID uuid.UUID, userID uuid.UUID, req dto.SuspensionCreateRequest) (*dto.SuspensionResponse, error) {
+	if _, err := s.customerRepo.FindByID(customerID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrCustomerNotFound
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, constants.ErrSuspensionExpiry
+	}
+
+	suspension := &domain.Suspension{
+		ID:          uuid.New(),
+		CustomerID:  customerID,
+		Reason:      req.Reason,
+		Note:        req.Note,
+		ExpiresAt:   req.ExpiresAt,
+		SuspendedBy: userID,
+		CreatedAt:   now,
+	}
+
+	if err := s.suspensionRepo.Create(ctx, suspension); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toSuspensionResponse(suspension, now)
+	return &response, nil
+}
+
+// Lift ends a suspension early. Expired suspensions have already lifted
+// themselves and cannot be lifted again.
+func (s *suspensionService) Lift(ctx context.Context, customerID uuid.UUID, id uuid.UUID, userID uuid.UUID) (*dto.SuspensionResponse, error) {
+	suspension, err := s.suspensionRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrSuspensionNotFound
+		}
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	if suspension.CustomerID != customerID {
+		return nil, constants.ErrSuspensionNotFound
+	}
+
+	now := time.Now()
+	if suspensionStatus(suspension, now) != constants.SuspensionStatusActive {
+		return nil, constants.ErrSuspensionNotActive
+	}
+
+	suspension.LiftedAt = &now
+	suspension.LiftedBy = &userID
+
+	if err := s.suspensionRepo.Update(ctx, suspension); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toSuspensionResponse(suspension, now)
+	return &response, nil
+}
+
+// checkNotSuspended refuses customers with a suspension in force.
+func checkNotSuspended(ctx context.Context, suspensionRepo domain.SuspensionRepository, customerID uuid.UUID) error {
+	suspended, err := suspensionRepo.HasActive(ctx, customerID, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if suspended {
+		return constants.ErrCustomerSuspended
+	}
+	return nil
+}
+
+func suspensionStatus(suspension *domain.Suspension, now time.Time) string {
+	switch {
+	case suspension.LiftedAt != nil:
+		return constants.SuspensionStatusLifted
+	case suspension.ExpiresAt != nil && !suspension.ExpiresAt.After(now):
+		return constants.SuspensionStatusExpired
+	}
+	return constants.SuspensionStatusActive
+}
+
+func toSuspensionResponses(suspensions []domain.Suspension, now time.Time) []dto.SuspensionResponse {
+	responses := make([]dto.SuspensionResponse, 0, len(suspensions))
+	for _, suspension := range suspensions {
+		responses = append(responses, toSuspensionResponse(&suspension, now))
+	}
+	return responses
+}
+
+func toSuspensionResponse(suspension *domain.Suspension, now time.Time) dto.SuspensionResponse {
+	return dto.SuspensionResponse{
+		ID:          suspension.ID,
+		CustomerID:  suspension.CustomerID,
+		Reason:      suspension.Reason,
+		Note:        suspension.Note,
+		Status:      suspensionStatus(suspension, now),
+		ExpiresAt:   suspension.ExpiresAt,
+		SuspendedBy: suspension.SuspendedBy,
+		LiftedAt:    suspension.LiftedAt,
+		LiftedBy:    suspension.LiftedBy,
+		CreatedAt:   suspension.CreatedAt,
+	}
+}
@@ -0,0 +1,398 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"go-rest-api/internal/repository"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var activeTransferStatuses = []string{constants.TransferStatusRequested, constants.TransferStatusInTransit}
+
+type transferService struct {
+	transferRepo  domain.TransferRepository
+	bookstockRepo domain.BookstockRepository
+	branchRepo    domain.BranchRepository
+}
+
+func NewTransferService(transferRepo domain.TransferRepository, bookstockRepo domain.BookstockRepository, branchRepo domain.BranchRepository) domain.TransferService {
+	return &transferService{
+		transferRepo:  transferRepo,
+		bookstockRepo: bookstockRepo,
+		branchRepo:    branchRepo,
+	}
+}
+
+func (s *transferService) GetTransfers(ctx context.Context, filter dto.TransferFilter) ([]dto.TransferResponse, error) {
+	transfers, err := s.transferRepo.Find(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return toTransferResponses(transfers), nil
+}
+
+func (s *transferService) GetTransferByID(ctx context.Context, id uuid.UUID) (*dto.TransferResponse, error) {
+	transfer, err := s.findTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toTransferResponse(transfer)
+	return &response, nil
+}
+
+func (s *transferService) GetQueue(ctx context.Context, branchID uuid.UUID) (*dto.TransferQueue, error) {
+	if _, err := s.branchRepo.FindByID(ctx, branchID); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBranchNotFound
+	}
+
+	incoming, err := s.transferRepo.Find(ctx, dto.TransferFilter{
+		BranchID:  &branchID,
+		Direction: constants.TransferDirectionIncoming,
+		Status:    constants.TransferStatusInTransit,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	outgoing, err := s.transferRepo.Find(ctx, dto.TransferFilter{
+		BranchID:  &branchID,
+		Direction: constants.TransferDirectionOutgoing,
+		Status:    constants.TransferStatusRequested,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return &dto.TransferQueue{
+		BranchID: branchID,
+		Incoming: toTransferResponses(incoming),
+		Outgoing: toTransferResponses(outgoing),
+	}, nil
+}
+
+func (s *transferService) RequestTransfer(ctx context.Context, requestedBy uuid.UUID, req dto.TransferCreateRequest) (*dto.TransferResponse, error) {
+	stock, err := s.bookstockRepo.FindByCode(req.StockCode)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, constants.ErrBookstockNotFound
+	}
+	if stock.Status != constants.BookStockStatusAvailable {
+		return nil, errors.New("only available copies can be transferred")
+	}
+	if err := checkCopyNotTrapped(s.db(ctx), stock.Code); err != nil {
+		return nil, err
+	}
+	if stock.CurrentBranchID == nil {
+		return nil, constants.ErrTransferNoLocation
+	}
+	if *stock.CurrentBranchID == req.ToBranchID {
+		return nil, constants.ErrTransferSameBranch
+	}
+	if _, _, err := resolveLocation(ctx, s.branchRepo, &req.ToBranchID, req.ToShelfID); err != nil {
+		return nil, err
+	}
+
+	active, err := s.transferRepo.Find(ctx, dto.TransferFilter{StockCode: req.StockCode, Status: constants.TransferStatusRequested})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+	if len(active) > 0 {
+		return nil, constants.ErrTransferExists
+	}
+
+	transfer := newTransfer(stock, req.ToBranchID, constants.TransferReasonManual, nil, time.Now())
+	transfer.ToShelfID = req.ToShelfID
+	transfer.Note = req.Note
+	transfer.RequestedBy = &requestedBy
+
+	if err := s.db(ctx).Omit("BookStock", "FromBranch", "ToBranch").Create(transfer).Error; err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.GetTransferByID(ctx, transfer.ID)
+}
+
+// ShipTransfer takes the copy off the shelf and marks it IN_TRANSIT.
+func (s *transferService) ShipTransfer(ctx context.Context, id uuid.UUID) (*dto.TransferResponse, error) {
+	transfer, err := s.findTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != constants.TransferStatusRequested {
+		return nil, constants.ErrTransferNotRequested
+	}
+
+	now := time.Now()
+	err = s.db(ctx).Transaction(func(tx *gorm.DB) error {
+		// The copy may have been set aside for a hold since the request.
+		if err := checkCopyNotTrapped(tx, transfer.StockCode); err != nil {
+			return err
+		}
+
+		result := tx.Model(&domain.BookStock{}).
+			Where("code = ? AND status = ?", transfer.StockCode, constants.BookStockStatusAvailable).
+			Updates(map[string]interface{}{"status": constants.BookStockStatusInTransit, "current_shelf_id": nil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("book stock is no longer available to ship")
+		}
+
+		result = tx.Model(&domain.Transfer{}).
+			Where("id = ? AND status = ?", id, constants.TransferStatusRequested).
+			Updates(map[string]interface{}{"status": constants.TransferStatusInTransit, "shipped_at": now, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return constants.ErrTransferNotRequested
+		}
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.GetTransferByID(ctx, id)
+}
+
+// ReceiveTransfer shelves the copy at the destination. A copy sent for a
+// hold readies that hold; any other copy may be trapped for a waiting hold.
+func (s *transferService) ReceiveTransfer(ctx context.Context, id uuid.UUID) (*dto.TransferResponse, error) {
+	transfer, err := s.findTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != constants.TransferStatusInTransit {
+		return nil, constants.ErrTransferNotInTransit
+	}
+
+	now := time.Now()
+	stock := transfer.BookStock
+	err = s.db(ctx).Transaction(func(tx *gorm.DB) error {
+		// Only one receive can win; a concurrent one finds the transfer done.
+		result := tx.Model(&domain.Transfer{}).
+			Where("id = ? AND status = ?", id, constants.TransferStatusInTransit).
+			Updates(map[string]interface{}{"status": constants.TransferStatusReceived, "received_at": now, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return constants.ErrTransferNotInTransit
+		}
+
+		shelfID := transfer.ToShelfID
+		if shelfID == nil && stock.HomeBranchID != nil && *stock.HomeBranchID == transfer.ToBranchID {
+			shelfID = stock.HomeShelfID
+		}
+
+		stock.Status = constants.BookStockStatusAvailable
+		stock.CurrentBranchID = &transfer.ToBranchID
+		stock.CurrentShelfID = shelfID
+		if err := tx.Model(&domain.BookStock{}).Where("code = ?", stock.Code).
+			Updates(map[string]interface{}{
+				"status":            stock.Status,
+				"current_branch_id": stock.CurrentBranchID,
+				"current_shelf_id":  stock.CurrentShelfID,
+			}).Error; err != nil {
+			return err
+		}
+
+		// The copy stays AVAILABLE; checkout refuses it to anyone but the
+		// customer whose hold it readies.
+		if transfer.HoldID != nil {
+			result := tx.Model(&domain.Hold{}).
+				Where("id = ? AND status = ?", *transfer.HoldID, constants.HoldStatusWaiting).
+				Updates(map[string]interface{}{"status": constants.HoldStatusReady, "stock_code": stock.Code, "ready_at": now, "pickup_by": holdPickupBy(now), "updated_at": now})
+			if result.Error != nil || result.RowsAffected > 0 {
+				return result.Error
+			}
+		}
+
+		return trapHold(tx, &stock.Book, &stock, now)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.GetTransferByID(ctx, id)
+}
+
+func (s *transferService) CancelTransfer(ctx context.Context, id uuid.UUID) (*dto.TransferResponse, error) {
+	transfer, err := s.findTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != constants.TransferStatusRequested {
+		return nil, constants.ErrTransferNotRequested
+	}
+
+	now := time.Now()
+	result := s.db(ctx).Model(&domain.Transfer{}).
+		Where("id = ? AND status = ?", id, constants.TransferStatusRequested).
+		Updates(map[string]interface{}{"status": constants.TransferStatusCancelled, "cancelled_at": now, "updated_at": now})
+	if result.Error != nil {
+		slog.ErrorContext(ctx, result.Error.Error())
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, constants.ErrTransferNotRequested
+	}
+
+	return s.GetTransferByID(ctx, id)
+}
+
+func (s *transferService) findTransfer(ctx context.Context, id uuid.UUID) (*domain.Transfer, error) {
+	transfer, err := s.transferRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrTransferNotFound
+		}
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (s *transferService) db(ctx context.Context) *gorm.DB {
+	return s.transferRepo.(*repository.TransferRepositoryImpl).GetDB().WithContext(ctx)
+}
+
+func newTransfer(stock *domain.BookStock, toBranchID uuid.UUID, reason string, holdID *uuid.UUID, now time.Time) *domain.Transfer {
+	return &domain.Transfer{
+		ID:           uuid.New(),
+		StockCode:    stock.Code,
+		FromBranchID: *stock.CurrentBranchID,
+		ToBranchID:   toBranchID,
+		Status:       constants.TransferStatusRequested,
+		Reason:       reason,
+		HoldID:       holdID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// holdCandidates selects the copies that could fill hold: on the shelf, not
+// set aside for another hold and not already being moved.
+func holdCandidates(tx *gorm.DB, hold *domain.Hold) *gorm.DB {
+	query := tx.Model(&domain.BookStock{}).
+		Where("status = ?", constants.BookStockStatusAvailable).
+		Where("NOT "+repository.CopyHeldExpr).
+		Where("NOT EXISTS (SELECT 1 FROM transfers WHERE transfers.stock_code = book_stocks.code AND transfers.status IN ?)", activeTransferStatuses)
+	if hold.WorkID != nil {
+		return query.Where("book_id IN (SELECT id FROM books WHERE work_id = ? AND deleted_at IS NULL)", *hold.WorkID)
+	}
+	return query.Where("book_id = ?", *hold.BookID)
+}
+
+// requestHoldTransfer asks another branch for a copy when the hold's pickup
+// branch has none available. It returns nil when no transfer is needed or
+// no copy can be found.
+func requestHoldTransfer(tx *gorm.DB, hold *domain.Hold, now time.Time) (*domain.Transfer, error) {
+	if hold.PickupBranchID == nil {
+		return nil, nil
+	}
+
+	var local int64
+	if err := holdCandidates(tx, hold).Where("current_branch_id = ?", *hold.PickupBranchID).Count(&local).Error; err != nil {
+		return nil, err
+	}
+	if local > 0 {
+		return nil, nil
+	}
+
+	var stock domain.BookStock
+	err := holdCandidates(tx, hold).Where("current_branch_id IS NOT NULL AND current_branch_id <> ?", *hold.PickupBranchID).
+		Order("code").First(&stock).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := newTransfer(&stock, *hold.PickupBranchID, constants.TransferReasonHold, &hold.ID, now)
+	if err := tx.Omit("BookStock", "FromBranch", "ToBranch").Create(transfer).Error; err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// checkCopyNotTrapped fails when the copy is set aside for a ready hold and
+// so must stay where the customer will collect it.
+func checkCopyNotTrapped(tx *gorm.DB, stockCode string) error {
+	var count int64
+	err := tx.Model(&domain.BookStock{}).Where("code = ? AND "+repository.CopyHeldExpr, stockCode).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return constants.ErrStockOnHold
+	}
+	return nil
+}
+
+// cancelRequestedTransfers drops transfers not yet shipped for a copy that
+// is leaving the shelf another way, e.g. on loan.
+func cancelRequestedTransfers(tx *gorm.DB, stockCode string, now time.Time) error {
+	return tx.Model(&domain.Transfer{}).
+		Where("stock_code = ? AND status = ?", stockCode, constants.TransferStatusRequested).
+		Updates(map[string]interface{}{"status": constants.TransferStatusCancelled, "cancelled_at": now, "updated_at": now}).Error
+}
+
+func toTransferResponses(transfers []domain.Transfer) []dto.TransferResponse {
+	responses := make([]dto.TransferResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		responses = append(responses, toTransferResponse(&transfer))
+	}
+	return responses
+}
+
+func toTransferResponse(transfer *domain.Transfer) dto.TransferResponse {
+	response := dto.TransferResponse{
+		ID:           transfer.ID,
+		StockCode:    transfer.StockCode,
+		BookID:       transfer.BookStock.BookID,
+		Title:        transfer.BookStock.Book.Title,
+		FromBranchID: transfer.FromBranchID,
+		ToBranchID:   transfer.ToBranchID,
+		ToShelfID:    transfer.ToShelfID,
+		Status:       transfer.Status,
+		Reason:       transfer.Reason,
+		HoldID:       transfer.HoldID,
+		Note:         transfer.Note,
+		RequestedBy:  transfer.RequestedBy,
+		ShippedAt:    transfer.ShippedAt,
+		ReceivedAt:   transfer.ReceivedAt,
+		CancelledAt:  transfer.CancelledAt,
+		CreatedAt:    transfer.CreatedAt,
+		UpdatedAt:    transfer.UpdatedAt,
+	}
+
+	if transfer.FromBranch != nil {
+		response.FromBranchCode = transfer.FromBranch.Code
+	}
+	if transfer.ToBranch != nil {
+		response.ToBranchCode = transfer.ToBranch.Code
+	}
+
+	return response
+}
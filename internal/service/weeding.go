@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type weedingService struct {
+	weedingRepo   domain.WeedingRepository
+	bookstockRepo domain.BookstockRepository
+}
+
+func NewWeedingService(weedingRepo domain.WeedingRepository, bookstockRepo domain.BookstockRepository) domain.WeedingService {
+	return &weedingService{
+		weedingRepo:   weedingRepo,
+		bookstockRepo: bookstockRepo,
+	}
+}
+
+func (s *weedingService) GetCandidates(ctx context.Context, filter dto.WeedingFilter) ([]dto.WeedingCandidate, error) {
+	if filter.Years <= 0 {
+		filter.Years = constants.WeedingDefaultYears
+	}
+	if filter.Limit <= 0 || filter.Limit > constants.WeedingCandidateLimit {
+		filter.Limit = constants.WeedingCandidateLimit
+	}
+	cutoff := time.Now().AddDate(-filter.Years, 0, 0)
+
+	candidates, err := s.weedingRepo.FindCandidates(ctx, filter, cutoff)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	for i := range candidates {
+		candidate := &candidates[i]
+		candidate.Reasons = []string{}
+		if candidate.Status == constants.BookStockStatusDamaged {
+			candidate.Reasons = append(candidate.Reasons, constants.WeedingReasonDamaged)
+		}
+		if candidate.Condition == constants.ConditionPoor {
+			candidate.Reasons = append(candidate.Reasons, constants.WeedingReasonPoor)
+		}
+		lastUsed := candidate.LastBorrowedAt
+		if lastUsed == nil {
+			lastUsed = candidate.AddedAt
+		}
+		if lastUsed == nil || lastUsed.Before(cutoff) {
+			candidate.Reasons = append(candidate.Reasons, constants.WeedingReasonNotBorrowed)
+		}
+	}
+
+	return candidates, nil
+}
+
+func (s *weedingService) GetDeaccessions(ctx context.Context, status string) ([]dto.DeaccessionResponse, error) {
+	deaccessions, err := s.weedingRepo.FindAll(ctx, status)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.DeaccessionResponse, 0, len(deaccessions))
+	for _, deaccession := range deaccessions {
+		responses = append(responses, toDeaccessionResponse(&deaccession))
+	}
+
+	return responses, nil
+}
+
+func (s *weedingService) GetDeaccessionByID(ctx context.Context, id uuid.UUID) (*dto.DeaccessionResponse, error) {
+	deaccession, err := s.findDeaccession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toDeaccessionResponse(deaccession)
+	return &response, nil
+}
+
+// CreateDeaccession proposes copies for withdrawal. Like bulk status
+// changes it is all or nothing: when any code cannot be proposed the report
+// is returned with ErrBulkRejected and no batch is created.
+func (s *weedingService) CreateDeaccession(ctx context.Context, requestedBy uuid.UUID, req dto.DeaccessionCreateRequest) (*dto.DeaccessionResponse, *dto.BookstockBulkReport, error) {
+	existing, err := s.bookstockRepo.FindByCodes(req.Codes)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, nil, err
+	}
+	byCode := make(map[string]domain.BookStock, len(existing))
+	for _, stock := range existing {
+		byCode[stock.Code] = stock
+	}
+
+	pendingCodes, err := s.weedingRepo.FindPendingCodes(ctx, req.Codes)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, nil, err
+	}
+	pending := make(map[string]bool, len(pendingCodes))
+	for _, code := range pendingCodes {
+		pending[code] = true
+	}
+
+	deaccession := &domain.Deaccession{
+		ID:          uuid.New(),
+		Reason:      req.Reason,
+		Status:      constants.DeaccessionStatusPending,
+		RequestedBy: &requestedBy,
+	}
+	report := &dto.BookstockBulkReport{Total: len(req.Codes), Items: make([]dto.BookstockBulkItem, 0, len(req.Codes))}
+	seen := make(map[string]bool, len(req.Codes))
+
+	for _, code := range req.Codes {
+		item := dto.BookstockBulkItem{Code: code}
+		stock, ok := byCode[code]
+
+		switch {
+		case seen[code]:
+			item.Result, item.Error = constants.BulkItemFailed, "duplicate code in request"
+		case !ok:
+			item.Result, item.Error = constants.BulkItemFailed, constants.ErrBookstockNotFound.Error()
+		case stock.Status == constants.BookStockStatusBorrowed:
+			item.Result, item.Error = constants.BulkItemFailed, constants.ErrBookstockOnLoan.Error()
+		case stock.Status == constants.BookStockStatusInTransit:
+			item.Result, item.Error = constants.BulkItemFailed, constants.ErrBookstockInTransit.Error()
+		case stock.Status == constants.BookStockStatusWithdrawn, stock.Status == constants.BookStockStatusArchived:
+			item.Result, item.Error = constants.BulkItemFailed, constants.ErrBookstockWithdrawn.Error()
+		case pending[code]:
+			item.Result, item.Error = constants.BulkItemFailed, constants.ErrBookstockPendingWeeding.Error()
+		default:
+			item.Result = constants.BulkItemCreated
+			deaccession.Items = append(deaccession.Items, domain.DeaccessionItem{
+				DeaccessionID: deaccession.ID,
+				StockCode:     code,
+				FromStatus:    stock.Status,
+			})
+		}
+		seen[code] = true
+
+		if item.Result == constants.BulkItemFailed {
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	if report.Failed > 0 {
+		return nil, report, constants.ErrBulkRejected
+	}
+
+	if err := s.weedingRepo.Create(ctx, deaccession); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, nil, err
+	}
+	report.Applied = true
+
+	response, err := s.GetDeaccessionByID(ctx, deaccession.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, report, nil
+}
+
+// ApproveDeaccession withdraws the proposed copies. Copies whose status
+// changed since they were proposed are skipped and stay unwithdrawn in the
+// batch.
+func (s *weedingService) ApproveDeaccession(ctx context.Context, id uuid.UUID, reviewedBy uuid.UUID) (*dto.DeaccessionResponse, error) {
+	deaccession, err := s.findDeaccession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if deaccession.Status != constants.DeaccessionStatusPending {
+		return nil, constants.ErrDeaccessionNotPending
+	}
+
+	now := time.Now()
+	logs := make([]domain.BookStockStatusLog, 0, len(deaccession.Items))
+	for _, item := range deaccession.Items {
+		logs = append(logs, domain.BookStockStatusLog{
+			ID:         uuid.New(),
+			StockCode:  item.StockCode,
+			FromStatus: item.FromStatus,
+			ToStatus:   constants.BookStockStatusWithdrawn,
+			Reason:     deaccession.Reason,
+			ChangedBy:  &reviewedBy,
+			CreatedAt:  now,
+		})
+	}
+
+	deaccession.Status = constants.DeaccessionStatusApproved
+	deaccession.ReviewedBy = &reviewedBy
+	deaccession.ReviewedAt = &now
+	if err := s.weedingRepo.Approve(ctx, deaccession, logs, requeueHoldOnChange(now)); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	return s.GetDeaccessionByID(ctx, id)
+}
+
+func (s *weedingService) RejectDeaccession(ctx context.Context, id uuid.UUID, reviewedBy uuid.UUID) (*dto.DeaccessionResponse, error) {
+	deaccession, err := s.findDeaccession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if deaccession.Status != constants.DeaccessionStatusPending {
+		return nil, constants.ErrDeaccessionNotPending
+	}
+
+	now := time.Now()
+	deaccession.Status = constants.DeaccessionStatusRejected
+	deaccession.ReviewedBy = &reviewedBy
+	deaccession.ReviewedAt = &now
+	if err := s.weedingRepo.Update(ctx, deaccession); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toDeaccessionResponse(deaccession)
+	return &response, nil
+}
+
+func (s *weedingService) findDeaccession(ctx context.Context, id uuid.UUID) (*domain.Deaccession, error) {
+	deaccession, err := s.weedingRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrDeaccessionNotFound
+		}
+		return nil, err
+	}
+	return deaccession, nil
+}
+
+func toDeaccessionResponse(deaccession *domain.Deaccession) dto.DeaccessionResponse {
+	response := dto.DeaccessionResponse{
+		ID:          deaccession.ID,
+		Reason:      deaccession.Reason,
+		Status:      deaccession.Status,
+		RequestedBy: deaccession.RequestedBy,
+		ReviewedBy:  deaccession.ReviewedBy,
+		ReviewedAt:  deaccession.ReviewedAt,
+		Items:       make([]dto.DeaccessionItemResponse, 0, len(deaccession.Items)),
+		CreatedAt:   deaccession.CreatedAt,
+		UpdatedAt:   deaccession.UpdatedAt,
+	}
+
+	for _, item := range deaccession.Items {
+		itemResponse := dto.DeaccessionItemResponse{
+			StockCode:  item.StockCode,
+			FromStatus: item.FromStatus,
+			Withdrawn:  item.Withdrawn,
+		}
+		if item.BookStock != nil {
+			itemResponse.BookID = item.BookStock.BookID
+			itemResponse.Title = item.BookStock.Book.Title
+			itemResponse.Status = item.BookStock.Status
+		}
+		response.Items = append(response.Items, itemResponse)
+	}
+
+	return response
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"go-rest-api/domain"
+	"go-rest-api/dto"
+	"go-rest-api/internal/constants"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type workService struct {
+	workRepo domain.WorkRepository
+	holdRepo domain.HoldRepository
+}
+
+func NewWorkService(workRepo domain.WorkRepository, holdRepo domain.HoldRepository) domain.WorkService {
+	return &workService{
+		workRepo: workRepo,
+		holdRepo: holdRepo,
+	}
+}
+
+func (s *workService) GetWorks(ctx context.Context, search string) ([]dto.WorkResponse, error) {
+	works, err := s.workRepo.FindAll(ctx, search)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(works))
+	for _, work := range works {
+		ids = append(ids, work.ID)
+	}
+
+	counts, err := s.workRepo.CountEditions(ctx, ids)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	workResponses := make([]dto.WorkResponse, 0, len(works))
+	for _, work := range works {
+		response := toWorkResponse(&work)
+		response.EditionCount = counts[work.ID]
+		workResponses = append(workResponses, response)
+	}
+
+	return workResponses, nil
+}
+
+func (s *workService) GetWorkByID(ctx context.Context, id uuid.UUID) (*dto.WorkResponse, error) {
+	work, err := s.workRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toWorkResponse(work)
+	return &response, nil
+}
+
+func (s *workService) CreateWork(ctx context.Context, req dto.WorkCreateRequest) (*dto.WorkResponse, error) {
+	work := &domain.Work{
+		Title:       req.Title,
+		Author:      req.Author,
+		Description: req.Description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.workRepo.Create(ctx, work); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toWorkResponse(work)
+	return &response, nil
+}
+
+func (s *workService) UpdateWork(ctx context.Context, id uuid.UUID, req dto.WorkUpdateRequest) (*dto.WorkResponse, error) {
+	work, err := s.workRepo.FindByID(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	if req.Title != "" {
+		work.Title = req.Title
+	}
+
+	if req.Author != "" {
+		work.Author = req.Author
+	}
+
+	if req.Description != "" {
+		work.Description = req.Description
+	}
+
+	work.UpdatedAt = time.Now()
+
+	if err := s.workRepo.Update(ctx, work); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return nil, err
+	}
+
+	response := toWorkResponse(work)
+	return &response, nil
+}
+
+// DeleteWork refuses while holds wait for any edition of the work, since
+// those holds could not be filled once the grouping is gone.
+func (s *workService) DeleteWork(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.workRepo.FindByID(ctx, id); err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+
+	holds, err := s.holdRepo.CountActive(ctx, dto.HoldFilter{WorkID: &id})
+	if err != nil {
+		slog.ErrorContext(ctx, err.Error())
+		return err
+	}
+	if holds > 0 {
+		return constants.ErrHasDependents
+	}
+
+	return s.workRepo.Delete(ctx, id)
+}
+
+func toWorkResponse(work *domain.Work) dto.WorkResponse {
+	response := dto.WorkResponse{
+		ID:           work.ID,
+		Title:        work.Title,
+		Author:       work.Author,
+		Description:  work.Description,
+		EditionCount: int64(len(work.Editions)),
+		CreatedAt:    work.CreatedAt,
+		UpdatedAt:    work.UpdatedAt,
+	}
+
+	for _, book := range work.Editions {
+		response.Editions = append(response.Editions, toBookSummary(&book))
+	}
+
+	return response
+}
+
+func toBookSummary(book *domain.Book) dto.BookSummary {
+	return dto.BookSummary{
+		ID:              book.ID,
+		Title:           book.Title,
+		Author:          book.Author,
+		ISBN:            book.ISBN,
+		Edition:         book.Edition,
+		Language:        book.Language,
+		Format:          book.Format,
+		PublicationYear: book.PublicationYear,
+		SeriesVolume:    book.SeriesVolume,
+	}
+}
@@ -0,0 +1,81 @@
+// Package stockcode builds and checks generated copy codes of the form
+// PREFIX-BRANCH-SEQUENCE, where the last digit of SEQUENCE is a Luhn check
+// digit over the digits before it.
+package stockcode
+
+import (
+	"fmt"
+	"strings"
+)
+
+const separator = "-"
+
+// Format returns the code for sequence, zero padded to digits characters
+// before the check digit is appended.
+func Format(prefix, branch string, sequence int64, digits int) string {
+	number := fmt.Sprintf("%0*d", digits, sequence)
+	return strings.Join([]string{prefix, branch, number + string(CheckDigit(number))}, separator)
+}
+
+// ValidPart reports whether part can be used as the prefix or branch of a
+// code. A part holding the separator would make the code unreadable.
+func ValidPart(part string) bool {
+	return part != "" && !strings.Contains(part, separator)
+}
+
+// Scope is the sequence counter a prefix and branch draw from.
+func Scope(prefix, branch string) string {
+	return prefix + separator + branch
+}
+
+// CheckDigit computes the Luhn check digit of a string of decimal digits.
+func CheckDigit(number string) byte {
+	sum := 0
+	double := true
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// Valid reports whether code has the generated layout and a correct check
+// digit. Hand-made codes from before generation was added are not valid.
+func Valid(code string) bool {
+	parts := strings.Split(code, separator)
+	if len(parts) != 3 || len(parts[2]) < 2 {
+		return false
+	}
+
+	number := parts[2]
+	for i := 0; i < len(number); i++ {
+		if number[i] < '0' || number[i] > '9' {
+			return false
+		}
+	}
+
+	return CheckDigit(number[:len(number)-1]) == number[len(number)-1]
+}
+
+// Mistyped reports whether code looks generated under prefix but its check
+// digit is wrong, which almost always means a typo. Other codes, including
+// hand-made ones, are never reported.
+func Mistyped(prefix, code string) bool {
+	parts := strings.Split(code, separator)
+	if len(parts) != 3 || parts[0] != prefix || len(parts[2]) < 2 {
+		return false
+	}
+	for i := 0; i < len(parts[2]); i++ {
+		if parts[2][i] < '0' || parts[2][i] > '9' {
+			return false
+		}
+	}
+	return !Valid(code)
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"go-rest-api/internal/api"
 	"go-rest-api/internal/config"
 	"go-rest-api/internal/connection"
+	"go-rest-api/internal/job"
 	"go-rest-api/internal/middleware"
 	"go-rest-api/internal/repository"
 	"go-rest-api/internal/service"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -22,30 +25,110 @@ func main() {
 	BookstockRepository := repository.NewBookstockRepositoryImpl(dbGorm)
 	BookTransactionRepository := repository.NewBookTransactionRepositoryImpl(dbGorm)
 	CustomerRepository := repository.NewCustomerRepositoryImpl(dbGorm)
+	dependencyRepository := repository.NewDependencyRepositoryImpl(dbGorm)
+	workRepository := repository.NewWorkRepositoryImpl(dbGorm)
+	seriesRepository := repository.NewSeriesRepositoryImpl(dbGorm)
+	holdRepository := repository.NewHoldRepositoryImpl(dbGorm)
+	reviewRepository := repository.NewReviewRepositoryImpl(dbGorm)
+	readingListRepository := repository.NewReadingListRepositoryImpl(dbGorm)
+	recommendationRepository := repository.NewRecommendationRepositoryImpl(dbGorm)
+	stockCodeRepository := repository.NewStockCodeRepositoryImpl(dbGorm)
+	stocktakeRepository := repository.NewStocktakeRepositoryImpl(dbGorm)
+	branchRepository := repository.NewBranchRepositoryImpl(dbGorm)
+	transferRepository := repository.NewTransferRepositoryImpl(dbGorm)
+	acquisitionRepository := repository.NewAcquisitionRepositoryImpl(dbGorm)
+	weedingRepository := repository.NewWeedingRepositoryImpl(dbGorm)
+	membershipTierRepository := repository.NewMembershipTierRepositoryImpl(dbGorm)
+	chargeRepository := repository.NewChargeRepositoryImpl(dbGorm)
+	suspensionRepository := repository.NewSuspensionRepositoryImpl(dbGorm)
+	customerMergeRepository := repository.NewCustomerMergeRepositoryImpl(dbGorm)
 
-	bookService := service.NewBookService(bookRepository, mediaRepository, cnf)
+	bookService := service.NewBookService(bookRepository, mediaRepository, dependencyRepository, workRepository, seriesRepository, cnf)
 	mediaService := service.NewMediaService(mediaRepository, bookService, cnf)
-	bookstockService := service.NewBookstockService(BookstockRepository, bookRepository)
-	bookTransactionService := service.NewBookTransactionService(BookTransactionRepository, bookRepository, BookstockRepository, CustomerRepository)
-	customerService := service.NewCustomerService(CustomerRepository)
+	bookstockService := service.NewBookstockService(BookstockRepository, bookRepository, dependencyRepository, branchRepository, stockCodeRepository, cnf)
+	bookTransactionService := service.NewBookTransactionService(BookTransactionRepository, bookRepository, BookstockRepository, CustomerRepository, membershipTierRepository, suspensionRepository, cnf)
+	customerService := service.NewCustomerService(CustomerRepository, dependencyRepository, membershipTierRepository, suspensionRepository, cnf)
+	bookCSVService := service.NewBookCSVService(bookRepository, BookstockRepository, mediaRepository)
+	marcService := service.NewMARCService(bookRepository)
+	opdsService := service.NewOPDSService(bookRepository, cnf)
+	workService := service.NewWorkService(workRepository, holdRepository)
+	seriesService := service.NewSeriesService(seriesRepository)
+	holdService := service.NewHoldService(holdRepository, bookRepository, workRepository, CustomerRepository, branchRepository, membershipTierRepository, suspensionRepository)
+	reviewService := service.NewReviewService(reviewRepository, bookRepository, CustomerRepository)
+	readingListService := service.NewReadingListService(readingListRepository, bookRepository, mediaRepository, CustomerRepository)
+	recommendationService := service.NewRecommendationService(recommendationRepository, bookRepository, CustomerRepository, cnf)
+	labelService := service.NewLabelService(BookstockRepository)
+	stocktakeService := service.NewStocktakeService(stocktakeRepository, branchRepository, cnf)
+	branchService := service.NewBranchService(branchRepository)
+	transferService := service.NewTransferService(transferRepository, BookstockRepository, branchRepository)
+	acquisitionService := service.NewAcquisitionService(acquisitionRepository, bookRepository, BookstockRepository, branchRepository, stockCodeRepository, cnf)
+	weedingService := service.NewWeedingService(weedingRepository, BookstockRepository)
+	membershipTierService := service.NewMembershipTierService(membershipTierRepository)
+	suspensionService := service.NewSuspensionService(suspensionRepository, CustomerRepository)
+	customerMergeService := service.NewCustomerMergeService(customerMergeRepository, CustomerRepository)
+	chargeService := service.NewChargeService(chargeRepository, BookTransactionRepository)
+	patronService := service.NewPatronService(customerService, bookTransactionService, holdService, reviewService, BookTransactionRepository, holdRepository, chargeRepository, reviewRepository)
 
-	authService := service.NewAuth(cnf, userRepository)
+	authService := service.NewAuth(cnf, userRepository, CustomerRepository)
 
-	authHandler := middleware.Authenticate(authService)
+	// Staff routes turn patron accounts away; patrons only get /v1/me.
+	authHandler := middleware.AuthenticateStaff(authService)
+	patronHandler := middleware.Authenticate(authService)
 	fileHandler := middleware.FileUploadMiddleware(cnf)
 
-	app := fiber.New()
+	// Bodies are streamed so the CSV import never holds the whole upload in
+	// memory; every other route is still held to BODY_LIMIT.
+	app := fiber.New(fiber.Config{
+		BodyLimit:                    cnf.Server.BodyLimit,
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
+	})
+	app.Use(middleware.BodyLimit(cnf.Server.BodyLimit, api.BookImportPath))
 
-	api.NewAuth(app, authHandler, authService)
+	api.NewAuth(app, patronHandler, authService)
+	api.NewBookCSVApi(app, authHandler, bookCSVService, cnf)
+	api.NewMARCApi(app, authHandler, marcService)
 	api.NewBookApi(app, authHandler, bookService)
 	api.NewMediaApi(app, authHandler, fileHandler, mediaService, cnf)
+	api.NewLabelApi(app, authHandler, labelService)
 	api.NewBookstockApi(app, authHandler, bookstockService)
 	api.NewBookTransactionApi(app, authHandler, bookTransactionService)
 	api.NewCustomerApi(app, authHandler, customerService)
+	api.NewWorkApi(app, authHandler, workService)
+	api.NewSeriesApi(app, authHandler, seriesService)
+	api.NewHoldApi(app, authHandler, holdService)
+	api.NewReviewApi(app, authHandler, reviewService)
+	api.NewReadingListApi(app, authHandler, readingListService)
+	api.NewRecommendationApi(app, authHandler, recommendationService)
+	api.NewStocktakeApi(app, authHandler, stocktakeService)
+	api.NewBranchApi(app, authHandler, branchService)
+	api.NewTransferApi(app, authHandler, transferService)
+	api.NewAcquisitionApi(app, authHandler, acquisitionService)
+	api.NewWeedingApi(app, authHandler, weedingService)
+	api.NewMembershipTierApi(app, authHandler, membershipTierService)
+	api.NewSuspensionApi(app, authHandler, suspensionService)
+	api.NewCustomerMergeApi(app, authHandler, customerMergeService)
+	api.NewChargeApi(app, authHandler, chargeService)
+	api.NewPatronApi(app, patronHandler, patronService)
+	api.NewOPDSApi(app, opdsService, cnf)
 
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.SendString("Hello, World!")
 	})
 
+	if cnf.Recommendation.IntervalHours > 0 {
+		go job.RunPeriodically(context.Background(), "recommendations", time.Duration(cnf.Recommendation.IntervalHours)*time.Hour,
+			func(ctx context.Context) error {
+				_, err := recommendationService.Rebuild(ctx)
+				return err
+			})
+	}
+
+	go job.RunPeriodically(context.Background(), "hold expiry", time.Hour,
+		func(ctx context.Context) error {
+			_, err := holdService.ExpireHolds(ctx)
+			return err
+		})
+
 	_ = app.Listen(cnf.Server.Host + ":" + cnf.Server.Port)
 }